@@ -17,8 +17,20 @@ require (
 require (
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	go.opentelemetry.io/otel/exporters/retry v1.11.2 // indirect
+	go.opentelemetry.io/otel/exporters/retry/retrygrpc v1.11.2 // indirect
 	go.opentelemetry.io/otel/trace v1.11.2 // indirect
+	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
 	golang.org/x/sys v0.0.0-20220919091848-fb04ddd9f9c8 // indirect
+	golang.org/x/text v0.4.0 // indirect
+	google.golang.org/genproto v0.0.0-20211118181313-81c1377c94b1 // indirect
+	google.golang.org/grpc v1.51.0 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
 )
 
 replace go.opentelemetry.io/otel/trace => ../../trace
+
+replace go.opentelemetry.io/otel/exporters/retry => ../../exporters/retry
+
+replace go.opentelemetry.io/otel/exporters/retry/retrygrpc => ../../exporters/retry/retrygrpc
@@ -22,9 +22,13 @@ require (
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
+	go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform v0.34.0 // indirect
+	go.opentelemetry.io/otel/exporters/internal/prometheus/naming v0.34.0 // indirect
 	go.opentelemetry.io/otel/metric v0.34.0 // indirect
 	go.opentelemetry.io/otel/trace v1.11.2 // indirect
+	go.opentelemetry.io/proto/otlp v0.19.0 // indirect
 	golang.org/x/sys v0.0.0-20220919091848-fb04ddd9f9c8 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
 )
 
 replace go.opentelemetry.io/otel/metric => ../../metric
@@ -36,3 +40,7 @@ replace go.opentelemetry.io/otel/trace => ../../trace
 replace go.opentelemetry.io/otel/exporters/stdout/stdoutmetric => ../../exporters/stdout/stdoutmetric
 
 replace go.opentelemetry.io/otel/exporters/stdout/stdouttrace => ../../exporters/stdout/stdouttrace
+
+replace go.opentelemetry.io/otel/exporters/internal/prometheus/naming => ../../exporters/internal/prometheus/naming
+
+replace go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform => ../../exporters/internal/otlpmetric/transform
@@ -16,13 +16,15 @@ require (
 )
 
 require (
-	github.com/cenkalti/backoff/v4 v4.2.0 // indirect
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.7.0 // indirect
-	go.opentelemetry.io/otel/exporters/otlp/internal/retry v1.11.2 // indirect
+	github.com/klauspost/compress v1.15.15 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/internal/compress v1.11.2 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.11.2 // indirect
+	go.opentelemetry.io/otel/exporters/retry v1.11.2 // indirect
+	go.opentelemetry.io/otel/exporters/retry/retrygrpc v1.11.2 // indirect
 	go.opentelemetry.io/proto/otlp v0.19.0 // indirect
 	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
 	golang.org/x/sys v0.0.0-20220919091848-fb04ddd9f9c8 // indirect
@@ -37,4 +39,8 @@ replace go.opentelemetry.io/otel/exporters/otlp/otlptrace => ../../exporters/otl
 
 replace go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc => ../../exporters/otlp/otlptrace/otlptracegrpc
 
-replace go.opentelemetry.io/otel/exporters/otlp/internal/retry => ../../exporters/otlp/internal/retry
+replace go.opentelemetry.io/otel/exporters/retry => ../../exporters/retry
+
+replace go.opentelemetry.io/otel/exporters/otlp/internal/compress => ../../exporters/otlp/internal/compress
+
+replace go.opentelemetry.io/otel/exporters/retry/retrygrpc => ../../exporters/retry/retrygrpc
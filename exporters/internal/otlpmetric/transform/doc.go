@@ -13,5 +13,7 @@
 // limitations under the License.
 
 // Package transform provides transformation functionality from the
-// sdk/metric/metricdata data-types into OTLP data-types.
-package transform // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/transform"
+// sdk/metric/metricdata data-types into OTLP data-types. It is shared by the
+// OTLP metric exporters and the OTLP JSON encoder of the stdoutmetric
+// exporter.
+package transform // import "go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform"
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package transform // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/transform"
+package transform // import "go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform"
 
 import (
 	"testing"
@@ -371,3 +371,15 @@ func TestTransformations(t *testing.T) {
 	assert.ErrorIs(t, err, errUnknownAggregation)
 	require.Equal(t, pbResourceMetrics, rm)
 }
+
+func TestZeroTimeTransformation(t *testing.T) {
+	dPts := DataPoints([]metricdata.DataPoint[int64]{{Time: end, Value: 1}})
+	require.Len(t, dPts, 1)
+	assert.Equal(t, uint64(0), dPts[0].StartTimeUnixNano)
+	assert.Equal(t, uint64(end.UnixNano()), dPts[0].TimeUnixNano)
+
+	hDPts := HistogramDataPoints([]metricdata.HistogramDataPoint{{Time: end}})
+	require.Len(t, hDPts, 1)
+	assert.Equal(t, uint64(0), hDPts[0].StartTimeUnixNano)
+	assert.Equal(t, uint64(end.UnixNano()), hDPts[0].TimeUnixNano)
+}
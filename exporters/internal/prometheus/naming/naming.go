@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package naming provides the Prometheus metric and label naming rules
+// shared by the Prometheus exporters and the Prometheus encoder of the
+// stdoutmetric exporter.
+package naming // import "go.opentelemetry.io/otel/exporters/internal/prometheus/naming"
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CounterSuffix is the suffix added to the name of Prometheus counters.
+//
+// Prometheus counters MUST have a _total suffix:
+// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.14.0/specification/metrics/data-model.md#sums-1
+const CounterSuffix = "_total"
+
+// unitSuffixes are the metric name suffixes of the units defined in
+// go.opentelemetry.io/otel/metric/unit.
+var unitSuffixes = map[string]string{
+	"1":  "_ratio",
+	"By": "_bytes",
+	"ms": "_milliseconds",
+}
+
+// UnitSuffix returns the metric name suffix for the unit u. If u has no
+// known suffix, false is returned.
+func UnitSuffix(u string) (string, bool) {
+	suffix, ok := unitSuffixes[u]
+	return suffix, ok
+}
+
+// SanitizeLabel returns l with all characters that are invalid in a
+// Prometheus label name replaced with an underscore. If l starts with a
+// digit, it is prefixed with "key_".
+//
+// Prometheus label names need to match [a-zA-Z_][a-zA-Z0-9_]*:
+// https://prometheus.io/docs/concepts/data_model/#metric-names-and-labels
+func SanitizeLabel(l string) string {
+	l = strings.Map(sanitizeLabelRune, l)
+	if l != "" && l[0] >= '0' && l[0] <= '9' {
+		l = "key_" + l
+	}
+	return l
+}
+
+func sanitizeLabelRune(r rune) rune {
+	if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+		return r
+	}
+	return '_'
+}
+
+// SanitizeName returns n with all characters that are invalid in a
+// Prometheus metric name replaced with an underscore. If n starts with a
+// digit, it is prefixed with an underscore.
+func SanitizeName(n string) string {
+	// This algorithm is based on strings.Map from Go 1.19.
+	const replacement = '_'
+
+	valid := func(i int, r rune) bool {
+		// Taken from
+		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric.go#L92-L102
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == ':' || (r >= '0' && r <= '9' && i > 0) {
+			return true
+		}
+		return false
+	}
+
+	// This output buffer b is initialized on demand, the first time a
+	// character needs to be replaced.
+	var b strings.Builder
+	for i, c := range n {
+		if valid(i, c) {
+			continue
+		}
+
+		if i == 0 && c >= '0' && c <= '9' {
+			// Prefix leading number with replacement character.
+			b.Grow(len(n) + 1)
+			_ = b.WriteByte(byte(replacement))
+			break
+		}
+		b.Grow(len(n))
+		_, _ = b.WriteString(n[:i])
+		_ = b.WriteByte(byte(replacement))
+		width := utf8.RuneLen(c)
+		n = n[i+width:]
+		break
+	}
+
+	// Fast path for unchanged input.
+	if b.Cap() == 0 { // b.Grow was not called above.
+		return n
+	}
+
+	for _, c := range n {
+		// Due to inlining, it is more performant to invoke WriteByte rather then
+		// WriteRune.
+		if valid(1, c) { // We are guaranteed to not be at the start.
+			_ = b.WriteByte(byte(c))
+		} else {
+			_ = b.WriteByte(byte(replacement))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSantitizeName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"nam€_with_3_width_rune", "nam__with_3_width_rune"},
+		{"`", "_"},
+		{
+			`! "#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWKYZ[]\^_abcdefghijklmnopqrstuvwkyz{|}~`,
+			`________________0123456789:______ABCDEFGHIJKLMNOPQRSTUVWKYZ_____abcdefghijklmnopqrstuvwkyz____`,
+		},
+
+		// Test cases taken from
+		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric_test.go#L85-L136
+		{"Avalid_23name", "Avalid_23name"},
+		{"_Avalid_23name", "_Avalid_23name"},
+		{"1valid_23name", "_1valid_23name"},
+		{"avalid_23name", "avalid_23name"},
+		{"Ava:lid_23name", "Ava:lid_23name"},
+		{"a lid_23name", "a_lid_23name"},
+		{":leading_colon", ":leading_colon"},
+		{"colon:in:the:middle", "colon:in:the:middle"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		require.Equalf(t, test.want, SanitizeName(test.input), "input: %q", test.input)
+	}
+}
+
+func TestSanitizeLabel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"http.method", "http_method"},
+		{"a/b:c", "a_b_c"},
+		{"nam€", "nam_"},
+		{"0abc", "key_0abc"},
+		{"_abc", "_abc"},
+		{"ABC_123", "ABC_123"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equalf(t, test.want, SanitizeLabel(test.input), "input: %q", test.input)
+	}
+}
+
+func TestUnitSuffix(t *testing.T) {
+	suffix, ok := UnitSuffix("By")
+	assert.True(t, ok)
+	assert.Equal(t, "_bytes", suffix)
+
+	_, ok = UnitSuffix("furlongs")
+	assert.False(t, ok)
+}
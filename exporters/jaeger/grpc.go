@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger // import "go.opentelemetry.io/otel/exporters/jaeger"
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
+	gen "go.opentelemetry.io/otel/exporters/jaeger/internal/gen-go/jaeger"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/exporters/retry/retrygrpc"
+)
+
+// WithGRPCCollectorEndpoint configures the Jaeger exporter to send spans to
+// the api_v2 gRPC endpoint of a Jaeger collector.
+//
+// By default, spans are sent to "localhost:14250" using a secure connection
+// verified with the system certificate pool.
+func WithGRPCCollectorEndpoint(options ...GRPCCollectorEndpointOption) EndpointOption {
+	return endpointOptionFunc(func() (batchUploader, error) {
+		cfg := grpcCollectorEndpointConfig{
+			endpoint: "localhost:14250",
+		}
+		for _, opt := range options {
+			cfg = opt.apply(cfg)
+		}
+
+		var creds credentials.TransportCredentials
+		switch {
+		case cfg.insecure:
+			creds = insecure.NewCredentials()
+		default:
+			creds = credentials.NewTLS(cfg.tlsConfig)
+		}
+		dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, cfg.dialOptions...)
+		conn, err := grpc.Dial(cfg.endpoint, dialOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &grpcCollectorUploader{
+			conn:        conn,
+			metadata:    metadata.New(cfg.headers),
+			requestFunc: cfg.retryPolicy.RequestFunc(retrygrpc.Evaluate(cfg.retryPolicy)),
+		}, nil
+	})
+}
+
+// GRPCCollectorEndpointOption configures a Jaeger gRPC collector endpoint.
+type GRPCCollectorEndpointOption interface {
+	apply(grpcCollectorEndpointConfig) grpcCollectorEndpointConfig
+}
+
+type grpcCollectorEndpointConfig struct {
+	// endpoint is the host and port of the collector gRPC endpoint.
+	endpoint string
+
+	// insecure disables transport security.
+	insecure bool
+
+	// tlsConfig is the TLS configuration used to connect to the collector.
+	tlsConfig *tls.Config
+
+	// headers are sent as gRPC metadata with every request.
+	headers map[string]string
+
+	// dialOptions are additional options used to dial the collector.
+	dialOptions []grpc.DialOption
+
+	// retryPolicy is used to retry failed requests to the collector.
+	retryPolicy retry.Policy
+}
+
+type grpcCollectorEndpointOptionFunc func(grpcCollectorEndpointConfig) grpcCollectorEndpointConfig
+
+func (fn grpcCollectorEndpointOptionFunc) apply(cfg grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+	return fn(cfg)
+}
+
+// WithGRPCEndpoint sets the host and port of the Jaeger collector gRPC
+// endpoint spans are sent to.
+// If this option is not passed, "localhost:14250" will be used by default.
+func WithGRPCEndpoint(endpoint string) GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.endpoint = endpoint
+		return o
+	})
+}
+
+// WithGRPCInsecure disables transport security for the connection to the
+// collector.
+func WithGRPCInsecure() GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.insecure = true
+		return o
+	})
+}
+
+// WithGRPCTLSClientConfig sets the TLS configuration used to connect to the
+// collector. It has no effect if WithGRPCInsecure is also passed.
+func WithGRPCTLSClientConfig(tlsCfg *tls.Config) GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.tlsConfig = tlsCfg.Clone()
+		return o
+	})
+}
+
+// WithGRPCHeaders sets headers sent as gRPC metadata with every request to
+// the collector.
+func WithGRPCHeaders(headers map[string]string) GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.headers = headers
+		return o
+	})
+}
+
+// WithGRPCDialOption sets additional options used to dial the collector.
+func WithGRPCDialOption(opts ...grpc.DialOption) GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.dialOptions = append(o.dialOptions, opts...)
+		return o
+	})
+}
+
+// WithGRPCRetry sets the retry policy for requests to the collector that
+// fail with a transient error. Requests failing with a gRPC code the policy
+// considers retryable are retried, honoring any RetryInfo returned.
+//
+// If this option is not passed, failed requests are not retried.
+func WithGRPCRetry(policy retry.Policy) GRPCCollectorEndpointOption {
+	return grpcCollectorEndpointOptionFunc(func(o grpcCollectorEndpointConfig) grpcCollectorEndpointConfig {
+		o.retryPolicy = policy
+		return o
+	})
+}
+
+// grpcCollectorUploader implements batchUploader interface sending batches
+// to Jaeger through the collector gRPC endpoint.
+type grpcCollectorUploader struct {
+	conn        *grpc.ClientConn
+	metadata    metadata.MD
+	requestFunc retry.RequestFunc
+}
+
+var _ batchUploader = (*grpcCollectorUploader)(nil)
+
+func (c *grpcCollectorUploader) shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.conn.Close()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+func (c *grpcCollectorUploader) upload(ctx context.Context, batch *gen.Batch) error {
+	req := &apiv2.PostSpansRequest{Batch: batchToProto(batch)}
+	if c.metadata.Len() > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, c.metadata)
+	}
+	return c.requestFunc(ctx, func(ctx context.Context) error {
+		_, err := apiv2.PostSpans(ctx, c.conn, req)
+		return err
+	})
+}
+
+// batchToProto transforms a Thrift batch into the api_v2 model.
+func batchToProto(batch *gen.Batch) apiv2.Batch {
+	b := apiv2.Batch{
+		Spans: make([]*apiv2.Span, 0, len(batch.Spans)),
+	}
+	if batch.Process != nil {
+		b.Process = &apiv2.Process{
+			ServiceName: batch.Process.ServiceName,
+			Tags:        tagsToProto(batch.Process.Tags),
+		}
+	}
+	for _, span := range batch.Spans {
+		b.Spans = append(b.Spans, spanToProto(span))
+	}
+	return b
+}
+
+func spanToProto(span *gen.Span) *apiv2.Span {
+	traceID := protoTraceID(span.TraceIdHigh, span.TraceIdLow)
+
+	refs := make([]apiv2.SpanRef, 0, len(span.References)+1)
+	if span.ParentSpanId != 0 {
+		refs = append(refs, apiv2.SpanRef{
+			TraceID: traceID,
+			SpanID:  protoSpanID(span.ParentSpanId),
+			RefType: apiv2.SpanRefTypeChildOf,
+		})
+	}
+	for _, ref := range span.References {
+		refType := apiv2.SpanRefTypeChildOf
+		if ref.RefType == gen.SpanRefType_FOLLOWS_FROM {
+			refType = apiv2.SpanRefTypeFollowsFrom
+		}
+		refs = append(refs, apiv2.SpanRef{
+			TraceID: protoTraceID(ref.TraceIdHigh, ref.TraceIdLow),
+			SpanID:  protoSpanID(ref.SpanId),
+			RefType: refType,
+		})
+	}
+
+	logs := make([]apiv2.Log, 0, len(span.Logs))
+	for _, l := range span.Logs {
+		logs = append(logs, apiv2.Log{
+			Timestamp: time.UnixMicro(l.Timestamp).UTC(),
+			Fields:    tagsToProto(l.Fields),
+		})
+	}
+
+	return &apiv2.Span{
+		TraceID:       traceID,
+		SpanID:        protoSpanID(span.SpanId),
+		OperationName: span.OperationName,
+		References:    refs,
+		Flags:         uint32(span.Flags),
+		StartTime:     time.UnixMicro(span.StartTime).UTC(),
+		Duration:      time.Duration(span.Duration) * time.Microsecond,
+		Tags:          tagsToProto(span.Tags),
+		Logs:          logs,
+	}
+}
+
+func tagsToProto(tags []*gen.Tag) []apiv2.KeyValue {
+	kvs := make([]apiv2.KeyValue, 0, len(tags))
+	for _, tag := range tags {
+		kv := apiv2.KeyValue{Key: tag.Key}
+		switch tag.VType {
+		case gen.TagType_STRING:
+			kv.VType = apiv2.ValueTypeString
+			kv.VStr = tag.GetVStr()
+		case gen.TagType_BOOL:
+			kv.VType = apiv2.ValueTypeBool
+			kv.VBool = tag.GetVBool()
+		case gen.TagType_LONG:
+			kv.VType = apiv2.ValueTypeInt64
+			kv.VInt64 = tag.GetVLong()
+		case gen.TagType_DOUBLE:
+			kv.VType = apiv2.ValueTypeFloat64
+			kv.VFloat64 = tag.GetVDouble()
+		case gen.TagType_BINARY:
+			kv.VType = apiv2.ValueTypeBinary
+			kv.VBinary = tag.GetVBinary()
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs
+}
+
+func protoTraceID(high, low int64) [16]byte {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[0:8], uint64(high))
+	binary.BigEndian.PutUint64(id[8:16], uint64(low))
+	return id
+}
+
+func protoSpanID(id int64) [8]byte {
+	var sid [8]byte
+	binary.BigEndian.PutUint64(sid[:], uint64(id))
+	return sid
+}
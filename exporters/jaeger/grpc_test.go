@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
+	gen "go.opentelemetry.io/otel/exporters/jaeger/internal/gen-go/jaeger"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// grpcCollector is an in-process Jaeger api_v2 gRPC collector.
+type grpcCollector struct {
+	endpoint string
+	server   *grpc.Server
+
+	mu       sync.Mutex
+	batches  []apiv2.Batch
+	metadata []metadata.MD
+	errs     []error
+}
+
+func runGRPCCollector(t *testing.T, errs ...error) *grpcCollector {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	c := &grpcCollector{
+		endpoint: ln.Addr().String(),
+		server:   grpc.NewServer(grpc.ForceServerCodec(apiv2.Codec{})),
+		errs:     errs,
+	}
+	apiv2.RegisterCollectorServer(c.server, c)
+	go func() { _ = c.server.Serve(ln) }()
+	t.Cleanup(c.server.Stop)
+	return c
+}
+
+func (c *grpcCollector) PostSpans(ctx context.Context, req *apiv2.PostSpansRequest) (*apiv2.PostSpansResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.metadata = append(c.metadata, md)
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.batches = append(c.batches, req.Batch)
+	return &apiv2.PostSpansResponse{}, nil
+}
+
+func (c *grpcCollector) Batches() []apiv2.Batch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batches
+}
+
+func (c *grpcCollector) Metadata() []metadata.MD {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metadata
+}
+
+func TestGRPCCollectorExportSpans(t *testing.T) {
+	collector := runGRPCCollector(t)
+	exp, err := New(WithGRPCCollectorEndpoint(
+		WithGRPCEndpoint(collector.endpoint),
+		WithGRPCInsecure(),
+		WithGRPCHeaders(map[string]string{"authorization": "token"}),
+	))
+	require.NoError(t, err)
+
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	start := time.Unix(1666000000, 123000).UTC()
+	spans := tracetest.SpanStubs{{
+		Name:     "span",
+		SpanKind: trace.SpanKindInternal,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  trace.SpanID{2},
+		}),
+		Links: []sdktrace.Link{{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{3},
+				SpanID:  trace.SpanID{4},
+			}),
+		}},
+		StartTime:  start,
+		EndTime:    start.Add(time.Second),
+		Attributes: []attribute.KeyValue{attribute.Int("int", 1)},
+		Resource: resource.NewSchemaless(
+			semconv.ServiceNameKey.String("service"),
+			attribute.String("host", "localhost"),
+		),
+	}}.Snapshots()
+	ctx := context.Background()
+	require.NoError(t, exp.ExportSpans(ctx, spans))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	require.Len(t, collector.Batches(), 1)
+	batch := collector.Batches()[0]
+	assert.Equal(t, &apiv2.Process{
+		ServiceName: "service",
+		Tags:        []apiv2.KeyValue{{Key: "host", VType: apiv2.ValueTypeString, VStr: "localhost"}},
+	}, batch.Process)
+	assert.Equal(t, []*apiv2.Span{{
+		TraceID:       traceID,
+		SpanID:        [8]byte{1},
+		OperationName: "span",
+		References: []apiv2.SpanRef{
+			{TraceID: traceID, SpanID: [8]byte{2}, RefType: apiv2.SpanRefTypeChildOf},
+			{TraceID: [16]byte{3}, SpanID: [8]byte{4}, RefType: apiv2.SpanRefTypeFollowsFrom},
+		},
+		Flags:     1,
+		StartTime: start,
+		Duration:  time.Second,
+		Tags:      []apiv2.KeyValue{{Key: "int", VType: apiv2.ValueTypeInt64, VInt64: 1}},
+		Logs:      nil,
+	}}, batch.Spans)
+	assert.Equal(t, []string{"token"}, collector.Metadata()[0].Get("authorization"))
+}
+
+func TestGRPCCollectorRetry(t *testing.T) {
+	collector := runGRPCCollector(t,
+		status.Error(codes.Unavailable, "unavailable"),
+		status.Error(codes.InvalidArgument, "invalid"),
+	)
+	uploader, err := WithGRPCCollectorEndpoint(
+		WithGRPCEndpoint(collector.endpoint),
+		WithGRPCInsecure(),
+		WithGRPCRetry(retry.Policy{Enabled: true, InitialInterval: time.Nanosecond}),
+	).newBatchUploader()
+	require.NoError(t, err)
+	ctx := context.Background()
+	t.Cleanup(func() { require.NoError(t, uploader.shutdown(ctx)) })
+
+	batch := &gen.Batch{Process: &gen.Process{ServiceName: "test"}}
+	// The Unavailable error is retried, the InvalidArgument one is not.
+	err = uploader.upload(ctx, batch)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, uploader.upload(ctx, batch))
+	assert.Len(t, collector.Metadata(), 3)
+	assert.Len(t, collector.Batches(), 1)
+}
+
+func TestGRPCCollectorNoRetryByDefault(t *testing.T) {
+	collector := runGRPCCollector(t, status.Error(codes.Unavailable, "unavailable"))
+	uploader, err := WithGRPCCollectorEndpoint(
+		WithGRPCEndpoint(collector.endpoint),
+		WithGRPCInsecure(),
+	).newBatchUploader()
+	require.NoError(t, err)
+	ctx := context.Background()
+	t.Cleanup(func() { require.NoError(t, uploader.shutdown(ctx)) })
+
+	err = uploader.upload(ctx, &gen.Batch{Process: &gen.Process{ServiceName: "test"}})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Len(t, collector.Metadata(), 1)
+}
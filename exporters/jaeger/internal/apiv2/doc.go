@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apiv2 provides the Jaeger api_v2 model and collector service used
+// to send spans to a Jaeger collector over gRPC.
+//
+// The messages are encoded and decoded directly in the protobuf wire format
+// defined by the model.proto and collector.proto files of the jaeger-idl
+// repository (https://github.com/jaegertracing/jaeger-idl). The encoding
+// is tested against bytes produced by the code Jaeger generates from those
+// files (see testdata/gen.go).
+package apiv2 // import "go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv2 // import "go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// PostSpansMethod is the full name of the CollectorService PostSpans
+// method.
+const PostSpansMethod = "/jaeger.api_v2.CollectorService/PostSpans"
+
+// Codec is a gRPC codec encoding the messages of this package in the
+// protobuf wire format.
+type Codec struct{}
+
+// Name returns the name of the codec. It is the name of the default gRPC
+// codec so requests use the content-type a Jaeger collector expects.
+func (Codec) Name() string { return "proto" }
+
+// Marshal returns the wire format encoding of v.
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("apiv2: cannot marshal %T", v)
+	}
+	return m.marshal(nil), nil
+}
+
+// Unmarshal decodes the wire format encoded data into v.
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("apiv2: cannot unmarshal into %T", v)
+	}
+	return m.unmarshal(data)
+}
+
+// PostSpans sends req to the Jaeger collector CollectorService of conn.
+func PostSpans(ctx context.Context, conn grpc.ClientConnInterface, req *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error) {
+	resp := new(PostSpansResponse)
+	opts = append([]grpc.CallOption{grpc.ForceCodec(Codec{})}, opts...)
+	if err := conn.Invoke(ctx, PostSpansMethod, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CollectorServer is the server API of the Jaeger CollectorService.
+type CollectorServer interface {
+	PostSpans(context.Context, *PostSpansRequest) (*PostSpansResponse, error)
+}
+
+// RegisterCollectorServer registers srv with s. The server s needs to be
+// created with the grpc.ForceServerCodec(Codec{}) option.
+func RegisterCollectorServer(s *grpc.Server, srv CollectorServer) {
+	s.RegisterService(&collectorServiceDesc, srv)
+}
+
+var collectorServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.api_v2.CollectorService",
+	HandlerType: (*CollectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PostSpans",
+			Handler:    postSpansHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "collector.proto",
+}
+
+func postSpansHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSpansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectorServer).PostSpans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostSpansMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectorServer).PostSpans(ctx, req.(*PostSpansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
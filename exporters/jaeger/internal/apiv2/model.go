@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv2 // import "go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
+
+import (
+	"time"
+)
+
+// ValueType is the type of the value held by a KeyValue.
+type ValueType int32
+
+// ValueType values.
+const (
+	ValueTypeString ValueType = iota
+	ValueTypeBool
+	ValueTypeInt64
+	ValueTypeFloat64
+	ValueTypeBinary
+)
+
+// KeyValue is a typed tag of a span, log or process.
+type KeyValue struct {
+	Key      string
+	VType    ValueType
+	VStr     string
+	VBool    bool
+	VInt64   int64
+	VFloat64 float64
+	VBinary  []byte
+}
+
+// Log is a timestamped event of a span.
+type Log struct {
+	Timestamp time.Time
+	Fields    []KeyValue
+}
+
+// SpanRefType is the relationship of a span to the span it references.
+type SpanRefType int32
+
+// SpanRefType values.
+const (
+	SpanRefTypeChildOf SpanRefType = iota
+	SpanRefTypeFollowsFrom
+)
+
+// SpanRef is a reference from a span to another span.
+type SpanRef struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	RefType SpanRefType
+}
+
+// Process describes the service that emitted spans.
+type Process struct {
+	ServiceName string
+	Tags        []KeyValue
+}
+
+// Span is a Jaeger span.
+type Span struct {
+	TraceID       [16]byte
+	SpanID        [8]byte
+	OperationName string
+	References    []SpanRef
+	Flags         uint32
+	StartTime     time.Time
+	Duration      time.Duration
+	Tags          []KeyValue
+	Logs          []Log
+	Process       *Process
+	ProcessID     string
+	Warnings      []string
+}
+
+// Batch is a collection of spans reported by a single process.
+type Batch struct {
+	Spans   []*Span
+	Process *Process
+}
+
+// PostSpansRequest is the request of the CollectorService PostSpans method.
+type PostSpansRequest struct {
+	Batch Batch
+}
+
+// PostSpansResponse is the response of the CollectorService PostSpans
+// method.
+type PostSpansResponse struct{}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+// +build ignore
+
+// This program writes the golden files used by the wire tests. They are
+// encoded with the code Jaeger generates from its model.proto and
+// collector.proto, so the tests verify this package against the real
+// protocol definitions.
+//
+// Run it from a module that requires github.com/jaegertracing/jaeger
+// v1.39.0:
+//
+//	go run gen.go
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+)
+
+func main() {
+	process := &model.Process{
+		ServiceName: "service",
+		Tags:        []model.KeyValue{model.String("host", "localhost")},
+	}
+	full := &api_v2.PostSpansRequest{Batch: model.Batch{
+		Process: process,
+		Spans: []*model.Span{{
+			TraceID:       model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10),
+			SpanID:        model.NewSpanID(0x0102030405060708),
+			OperationName: "operation",
+			References: []model.SpanRef{{
+				TraceID: model.NewTraceID(0x0100000000000000, 0),
+				SpanID:  model.NewSpanID(0x0200000000000000),
+				RefType: model.SpanRefType_FOLLOWS_FROM,
+			}},
+			Flags:     1,
+			StartTime: time.Unix(1666000000, 123456000).UTC(),
+			Duration:  1500 * time.Millisecond,
+			Tags: []model.KeyValue{
+				model.String("string", "value"),
+				model.Bool("bool", true),
+				model.Int64("int64", -42),
+				model.Float64("float64", 3.14),
+				model.Binary("binary", []byte{0xff}),
+			},
+			Logs: []model.Log{{
+				Timestamp: time.Unix(1666000001, 0).UTC(),
+				Fields:    []model.KeyValue{model.String("event", "name")},
+			}},
+			Process:   process,
+			ProcessID: "p1",
+			Warnings:  []string{"w1", "w2"},
+		}},
+	}}
+	write("post_spans_request.binpb", full)
+
+	minimal := &api_v2.PostSpansRequest{Batch: model.Batch{
+		Spans: []*model.Span{{
+			TraceID:   model.NewTraceID(0, 1),
+			SpanID:    model.NewSpanID(1),
+			StartTime: time.Unix(0, 0).UTC(),
+		}},
+	}}
+	write("post_spans_request_minimal.binpb", minimal)
+}
+
+func write(name string, m interface{ Marshal() ([]byte, error) }) {
+	b, err := m.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(name, b, 0o600); err != nil {
+		panic(err)
+	}
+}
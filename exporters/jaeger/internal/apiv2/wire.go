@@ -0,0 +1,377 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiv2 // import "go.opentelemetry.io/otel/exporters/jaeger/internal/apiv2"
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message is a type that can be encoded to and decoded from the protobuf
+// wire format.
+type message interface {
+	marshal(b []byte) []byte
+	unmarshal(b []byte) error
+}
+
+var (
+	_ message = (*KeyValue)(nil)
+	_ message = (*Log)(nil)
+	_ message = (*SpanRef)(nil)
+	_ message = (*Process)(nil)
+	_ message = (*Span)(nil)
+	_ message = (*Batch)(nil)
+	_ message = (*PostSpansRequest)(nil)
+	_ message = (*PostSpansResponse)(nil)
+)
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendMessage(b []byte, num protowire.Number, m message) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m.marshal(nil))
+}
+
+// appendTimestamp appends t as a google.protobuf.Timestamp.
+func appendTimestamp(b []byte, num protowire.Number, t time.Time) []byte {
+	var ts []byte
+	ts = appendVarint(ts, 1, uint64(t.Unix()))
+	ts = appendVarint(ts, 2, uint64(int64(t.Nanosecond())))
+	return appendBytes(b, num, ts)
+}
+
+// appendDuration appends d as a google.protobuf.Duration.
+func appendDuration(b []byte, num protowire.Number, d time.Duration) []byte {
+	var dur []byte
+	dur = appendVarint(dur, 1, uint64(int64(d/time.Second)))
+	dur = appendVarint(dur, 2, uint64(int64(d%time.Second)))
+	return appendBytes(b, num, dur)
+}
+
+// field is a decoded protobuf field. Varint, fixed32 and fixed64 values are
+// held by value, length-delimited values by bytes.
+type field struct {
+	num   protowire.Number
+	typ   protowire.Type
+	value uint64
+	bytes []byte
+}
+
+// forEachField decodes the fields of the message encoded in b and calls fn
+// for each one.
+func forEachField(b []byte, fn func(field) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := field{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			f.value, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			f.value, n = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			f.value = uint64(v)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consumeTimestamp(b []byte) (time.Time, error) {
+	var sec, nsec int64
+	err := forEachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			sec = int64(f.value)
+		case 2:
+			nsec = int64(int32(f.value))
+		}
+		return nil
+	})
+	return time.Unix(sec, nsec).UTC(), err
+}
+
+func consumeDuration(b []byte) (time.Duration, error) {
+	var sec, nsec int64
+	err := forEachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			sec = int64(f.value)
+		case 2:
+			nsec = int64(int32(f.value))
+		}
+		return nil
+	})
+	return time.Duration(sec)*time.Second + time.Duration(nsec), err
+}
+
+func consumeID(dst []byte, b []byte) error {
+	if len(b) != len(dst) {
+		return fmt.Errorf("invalid ID length %d, expected %d", len(b), len(dst))
+	}
+	copy(dst, b)
+	return nil
+}
+
+func (kv *KeyValue) marshal(b []byte) []byte {
+	b = appendString(b, 1, kv.Key)
+	b = appendVarint(b, 2, uint64(kv.VType))
+	b = appendString(b, 3, kv.VStr)
+	if kv.VBool {
+		b = appendVarint(b, 4, 1)
+	}
+	b = appendVarint(b, 5, uint64(kv.VInt64))
+	if kv.VFloat64 != 0 {
+		b = protowire.AppendTag(b, 6, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(kv.VFloat64))
+	}
+	if len(kv.VBinary) > 0 {
+		b = appendBytes(b, 7, kv.VBinary)
+	}
+	return b
+}
+
+func (kv *KeyValue) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			kv.Key = string(f.bytes)
+		case 2:
+			kv.VType = ValueType(f.value)
+		case 3:
+			kv.VStr = string(f.bytes)
+		case 4:
+			kv.VBool = f.value != 0
+		case 5:
+			kv.VInt64 = int64(f.value)
+		case 6:
+			kv.VFloat64 = math.Float64frombits(f.value)
+		case 7:
+			kv.VBinary = append([]byte(nil), f.bytes...)
+		}
+		return nil
+	})
+}
+
+func (l *Log) marshal(b []byte) []byte {
+	b = appendTimestamp(b, 1, l.Timestamp)
+	for i := range l.Fields {
+		b = appendMessage(b, 2, &l.Fields[i])
+	}
+	return b
+}
+
+func (l *Log) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) (err error) {
+		switch f.num {
+		case 1:
+			l.Timestamp, err = consumeTimestamp(f.bytes)
+		case 2:
+			var kv KeyValue
+			err = kv.unmarshal(f.bytes)
+			l.Fields = append(l.Fields, kv)
+		}
+		return err
+	})
+}
+
+func (r *SpanRef) marshal(b []byte) []byte {
+	b = appendBytes(b, 1, r.TraceID[:])
+	b = appendBytes(b, 2, r.SpanID[:])
+	return appendVarint(b, 3, uint64(r.RefType))
+}
+
+func (r *SpanRef) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) error {
+		switch f.num {
+		case 1:
+			return consumeID(r.TraceID[:], f.bytes)
+		case 2:
+			return consumeID(r.SpanID[:], f.bytes)
+		case 3:
+			r.RefType = SpanRefType(f.value)
+		}
+		return nil
+	})
+}
+
+func (p *Process) marshal(b []byte) []byte {
+	b = appendString(b, 1, p.ServiceName)
+	for i := range p.Tags {
+		b = appendMessage(b, 2, &p.Tags[i])
+	}
+	return b
+}
+
+func (p *Process) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) (err error) {
+		switch f.num {
+		case 1:
+			p.ServiceName = string(f.bytes)
+		case 2:
+			var kv KeyValue
+			err = kv.unmarshal(f.bytes)
+			p.Tags = append(p.Tags, kv)
+		}
+		return err
+	})
+}
+
+func (s *Span) marshal(b []byte) []byte {
+	b = appendBytes(b, 1, s.TraceID[:])
+	b = appendBytes(b, 2, s.SpanID[:])
+	b = appendString(b, 3, s.OperationName)
+	for i := range s.References {
+		b = appendMessage(b, 4, &s.References[i])
+	}
+	b = appendVarint(b, 5, uint64(s.Flags))
+	b = appendTimestamp(b, 6, s.StartTime)
+	b = appendDuration(b, 7, s.Duration)
+	for i := range s.Tags {
+		b = appendMessage(b, 8, &s.Tags[i])
+	}
+	for i := range s.Logs {
+		b = appendMessage(b, 9, &s.Logs[i])
+	}
+	if s.Process != nil {
+		b = appendMessage(b, 10, s.Process)
+	}
+	b = appendString(b, 11, s.ProcessID)
+	for _, w := range s.Warnings {
+		b = protowire.AppendTag(b, 12, protowire.BytesType)
+		b = protowire.AppendString(b, w)
+	}
+	return b
+}
+
+func (s *Span) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) (err error) {
+		switch f.num {
+		case 1:
+			err = consumeID(s.TraceID[:], f.bytes)
+		case 2:
+			err = consumeID(s.SpanID[:], f.bytes)
+		case 3:
+			s.OperationName = string(f.bytes)
+		case 4:
+			var r SpanRef
+			err = r.unmarshal(f.bytes)
+			s.References = append(s.References, r)
+		case 5:
+			s.Flags = uint32(f.value)
+		case 6:
+			s.StartTime, err = consumeTimestamp(f.bytes)
+		case 7:
+			s.Duration, err = consumeDuration(f.bytes)
+		case 8:
+			var kv KeyValue
+			err = kv.unmarshal(f.bytes)
+			s.Tags = append(s.Tags, kv)
+		case 9:
+			var l Log
+			err = l.unmarshal(f.bytes)
+			s.Logs = append(s.Logs, l)
+		case 10:
+			s.Process = new(Process)
+			err = s.Process.unmarshal(f.bytes)
+		case 11:
+			s.ProcessID = string(f.bytes)
+		case 12:
+			s.Warnings = append(s.Warnings, string(f.bytes))
+		}
+		return err
+	})
+}
+
+func (b *Batch) marshal(buf []byte) []byte {
+	for _, s := range b.Spans {
+		buf = appendMessage(buf, 1, s)
+	}
+	if b.Process != nil {
+		buf = appendMessage(buf, 2, b.Process)
+	}
+	return buf
+}
+
+func (b *Batch) unmarshal(buf []byte) error {
+	return forEachField(buf, func(f field) (err error) {
+		switch f.num {
+		case 1:
+			s := new(Span)
+			err = s.unmarshal(f.bytes)
+			b.Spans = append(b.Spans, s)
+		case 2:
+			b.Process = new(Process)
+			err = b.Process.unmarshal(f.bytes)
+		}
+		return err
+	})
+}
+
+func (r *PostSpansRequest) marshal(b []byte) []byte {
+	return appendMessage(b, 1, &r.Batch)
+}
+
+func (r *PostSpansRequest) unmarshal(b []byte) error {
+	return forEachField(b, func(f field) error {
+		if f.num == 1 {
+			return r.Batch.unmarshal(f.bytes)
+		}
+		return nil
+	})
+}
+
+func (*PostSpansResponse) marshal(b []byte) []byte { return b }
+
+func (*PostSpansResponse) unmarshal([]byte) error { return nil }
@@ -28,6 +28,7 @@ import (
 
 	gen "go.opentelemetry.io/otel/exporters/jaeger/internal/gen-go/jaeger"
 	"go.opentelemetry.io/otel/exporters/jaeger/internal/third_party/thrift/lib/go/thrift"
+	"go.opentelemetry.io/otel/exporters/retry"
 )
 
 // batchUploader send a batch of spans to Jaeger.
@@ -180,10 +181,12 @@ func WithCollectorEndpoint(options ...CollectorEndpointOption) EndpointOption {
 		}
 
 		return &collectorUploader{
-			endpoint:   cfg.endpoint,
-			username:   cfg.username,
-			password:   cfg.password,
-			httpClient: cfg.httpClient,
+			endpoint:    cfg.endpoint,
+			username:    cfg.username,
+			password:    cfg.password,
+			httpClient:  cfg.httpClient,
+			requestFunc: cfg.retryPolicy.RequestFunc(retry.EvaluateHTTP),
+			retryPolicy: cfg.retryPolicy,
 		}, nil
 	})
 }
@@ -205,6 +208,10 @@ type collectorEndpointConfig struct {
 
 	// httpClient to be used to make requests to the collector endpoint.
 	httpClient *http.Client
+
+	// retryPolicy is used to retry failed requests to the collector
+	// endpoint.
+	retryPolicy retry.Policy
 }
 
 type collectorEndpointOptionFunc func(collectorEndpointConfig) collectorEndpointConfig
@@ -255,6 +262,19 @@ func WithHTTPClient(client *http.Client) CollectorEndpointOption {
 	})
 }
 
+// WithRetry sets the retry policy for requests to the collector endpoint
+// that fail with a transient error. Responses with an HTTP status code the
+// policy considers retryable are retried, honoring any Retry-After header
+// received.
+//
+// If this option is not passed, failed requests are not retried.
+func WithRetry(policy retry.Policy) CollectorEndpointOption {
+	return collectorEndpointOptionFunc(func(o collectorEndpointConfig) collectorEndpointConfig {
+		o.retryPolicy = policy
+		return o
+	})
+}
+
 // agentUploader implements batchUploader interface sending batches to
 // Jaeger through the UDP agent.
 type agentUploader struct {
@@ -286,10 +306,12 @@ func (a *agentUploader) upload(ctx context.Context, batch *gen.Batch) error {
 // collectorUploader implements batchUploader interface sending batches to
 // Jaeger through the collector http endpoint.
 type collectorUploader struct {
-	endpoint   string
-	username   string
-	password   string
-	httpClient *http.Client
+	endpoint    string
+	username    string
+	password    string
+	httpClient  *http.Client
+	requestFunc retry.RequestFunc
+	retryPolicy retry.Policy
 }
 
 var _ batchUploader = (*collectorUploader)(nil)
@@ -305,29 +327,32 @@ func (c *collectorUploader) upload(ctx context.Context, batch *gen.Batch) error
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, body)
-	if err != nil {
-		return err
-	}
-	if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
-	}
-	req.Header.Set("Content-Type", "application/x-thrift")
+	return c.requestFunc(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return err
+		}
+		if c.username != "" && c.password != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+		req.Header.Set("Content-Type", "application/x-thrift")
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return retry.TransportError(err)
+		}
 
-	_, _ = io.Copy(io.Discard, resp.Body)
-	if err = resp.Body.Close(); err != nil {
-		return err
-	}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if err = resp.Body.Close(); err != nil {
+			return err
+		}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("failed to upload traces; HTTP status code: %d", resp.StatusCode)
-	}
-	return nil
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err = fmt.Errorf("failed to upload traces; HTTP status code: %d", resp.StatusCode)
+			return c.retryPolicy.HTTPResponseError(resp, err)
+		}
+		return nil
+	})
 }
 
 func serialize(obj thrift.TStruct) (*bytes.Buffer, error) {
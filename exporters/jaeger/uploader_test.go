@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gen "go.opentelemetry.io/otel/exporters/jaeger/internal/gen-go/jaeger"
+	"go.opentelemetry.io/otel/exporters/retry"
+)
+
+func TestCollectorUploaderRetry(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	uploader, err := WithCollectorEndpoint(
+		WithEndpoint(srv.URL),
+		WithRetry(retry.Policy{Enabled: true, InitialInterval: time.Nanosecond}),
+	).newBatchUploader()
+	require.NoError(t, err)
+	ctx := context.Background()
+	batch := &gen.Batch{Process: &gen.Process{ServiceName: "test"}}
+
+	// The 503 response is retried, the 400 one is not.
+	assert.EqualError(t, uploader.upload(ctx, batch), "failed to upload traces; HTTP status code: 400")
+	assert.NoError(t, uploader.upload(ctx, batch))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestCollectorUploaderNoRetryByDefault(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	uploader, err := WithCollectorEndpoint(WithEndpoint(srv.URL)).newBatchUploader()
+	require.NoError(t, err)
+	batch := &gen.Batch{Process: &gen.Process{ServiceName: "test"}}
+	assert.Error(t, uploader.upload(context.Background(), batch))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+// failingTransport fails the first n requests it receives with a transport
+// error before forwarding the rest to http.DefaultTransport.
+type failingTransport struct {
+	n        int32
+	requests int32
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&t.requests, 1) <= t.n {
+		return nil, errors.New("connection reset by peer")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCollectorUploaderRetryTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	transport := &failingTransport{n: 2}
+	uploader, err := WithCollectorEndpoint(
+		WithEndpoint(srv.URL),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetry(retry.Policy{Enabled: true, InitialInterval: time.Nanosecond}),
+	).newBatchUploader()
+	require.NoError(t, err)
+	batch := &gen.Batch{Process: &gen.Process{ServiceName: "test"}}
+	assert.NoError(t, uploader.upload(context.Background(), batch))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.requests))
+}
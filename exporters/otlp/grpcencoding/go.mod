@@ -0,0 +1,15 @@
+module go.opentelemetry.io/otel/exporters/otlp/grpcencoding
+
+go 1.18
+
+require (
+	github.com/klauspost/compress v1.15.15
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/grpc v1.51.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
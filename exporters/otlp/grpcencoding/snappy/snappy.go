@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snappy registers the snappy compressor with gRPC.
+//
+// The compressor is registered with google.golang.org/grpc/encoding when this
+// package is imported. Import it for its side effect to use the "snappy"
+// compressor with the OTLP gRPC exporters:
+//
+//	import _ "go.opentelemetry.io/otel/exporters/otlp/grpcencoding/snappy"
+//
+// Payloads are encoded in the snappy framing format.
+package snappy // import "go.opentelemetry.io/otel/exporters/otlp/grpcencoding/snappy"
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name the snappy compressor is registered with.
+const Name = "snappy"
+
+func init() {
+	encoding.RegisterCompressor(compressor{})
+}
+
+// compressor is the gRPC compressor for the snappy framed encoding.
+type compressor struct{}
+
+var _ encoding.Compressor = (*compressor)(nil)
+
+func (compressor) Name() string { return Name }
+
+func (compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return s2.NewWriter(w, s2.WriterSnappyCompat(), s2.WriterConcurrency(1)), nil
+}
+
+func (compressor) Decompress(r io.Reader) (io.Reader, error) {
+	return s2.NewReader(r), nil
+}
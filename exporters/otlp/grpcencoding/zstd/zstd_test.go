@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressorRegistered(t *testing.T) {
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c, "compressor not registered")
+	assert.Equal(t, Name, c.Name())
+}
+
+func TestCompressorRoundTrip(t *testing.T) {
+	var src bytes.Buffer
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&src, "span-%d service.name=checkout http.method=GET http.route=/cart/{id}\n", i)
+	}
+
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c, "compressor not registered")
+	// Compressors are reused, compress more than once.
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(src.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Less(t, buf.Len(), src.Len(), "payload not compressed")
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, src.Bytes(), got)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compress provides the payload compression used by the OTLP HTTP
+// exporters.
+//
+// The gRPC compressors are provided by the packages in
+// go.opentelemetry.io/otel/exporters/otlp/grpcencoding.
+package compress // import "go.opentelemetry.io/otel/exporters/otlp/internal/compress"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Names of the supported compression encodings. These are the values used in
+// the Content-Encoding HTTP header and the grpc-encoding gRPC header.
+const (
+	Gzip   = "gzip"
+	Zstd   = "zstd"
+	Snappy = "snappy"
+)
+
+// DefaultLevel is the compression level that selects the default level of an
+// encoding.
+const DefaultLevel = 0
+
+// writer is a compressing io.WriteCloser that can be reused.
+type writer interface {
+	io.WriteCloser
+	Reset(io.Writer)
+}
+
+// Compressor compresses payloads with a single encoding and level. A
+// Compressor is safe for concurrent use.
+type Compressor struct {
+	name string
+	pool sync.Pool
+}
+
+// New returns a Compressor that compresses payloads with the encoding name
+// at level. The meaning of level depends on the encoding:
+//
+//   - gzip: 1 (best speed) through 9 (best compression), or -2 for Huffman
+//     only compression.
+//   - zstd: 1 (fastest) through 22 (best compression). Levels are mapped to
+//     the closest level supported by the encoder.
+//   - snappy: ignored, snappy has no compression levels.
+//
+// If level is DefaultLevel, the default level of the encoding is used. An
+// error is returned if name is not a supported encoding or level is invalid.
+func New(name string, level int) (*Compressor, error) {
+	var newWriter func() writer
+	switch name {
+	case Gzip:
+		if level == DefaultLevel {
+			level = gzip.DefaultCompression
+		}
+		// Validate the level once, instead of when the pool is used.
+		if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+			return nil, err
+		}
+		newWriter = func() writer {
+			w, _ := gzip.NewWriterLevel(io.Discard, level)
+			return w
+		}
+	case Zstd:
+		opts := []zstd.EOption{
+			// Payloads are compressed as a whole, concurrency only adds
+			// overhead.
+			zstd.WithEncoderConcurrency(1),
+		}
+		if level != DefaultLevel {
+			if level < 1 || level > 22 {
+				return nil, fmt.Errorf("invalid zstd compression level: %d", level)
+			}
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		if _, err := zstd.NewWriter(nil, opts...); err != nil {
+			return nil, err
+		}
+		newWriter = func() writer {
+			w, _ := zstd.NewWriter(nil, opts...)
+			return w
+		}
+	case Snappy:
+		newWriter = func() writer {
+			return s2.NewWriter(nil, s2.WriterSnappyCompat(), s2.WriterConcurrency(1))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression: %q", name)
+	}
+
+	c := &Compressor{name: name}
+	c.pool.New = func() interface{} { return newWriter() }
+	return c, nil
+}
+
+// Name returns the name of the encoding c compresses with.
+func (c *Compressor) Name() string {
+	return c.name
+}
+
+// Compress writes the compressed form of src to dst.
+func (c *Compressor) Compress(dst *bytes.Buffer, src []byte) error {
+	w := c.pool.Get().(writer)
+	defer c.pool.Put(w)
+
+	w.Reset(dst)
+	if _, err := w.Write(src); err != nil {
+		return err
+	}
+	// Close needs to be called to ensure the payload is fully written.
+	return w.Close()
+}
+
+// NewReader returns an io.ReadCloser that decompresses r using the encoding
+// name.
+func NewReader(name string, r io.Reader) (io.ReadCloser, error) {
+	switch name {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case Snappy:
+		return io.NopCloser(s2.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression: %q", name)
+	}
+}
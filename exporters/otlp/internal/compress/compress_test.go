@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// payload returns data resembling an encoded batch of n spans: mostly
+// repeated attribute keys and values with random trace and span IDs.
+func payload(n int) []byte {
+	r := rand.New(rand.NewSource(1)) // nolint:gosec  // Deterministic test data.
+	var b bytes.Buffer
+	id := make([]byte, 24)
+	for i := 0; i < n; i++ {
+		_, _ = r.Read(id)
+		fmt.Fprintf(&b, "%x span-%d service.name=checkout http.method=GET http.route=/cart/{id} http.status_code=200 duration=%d\n", id, i, r.Int63n(1e9))
+	}
+	return b.Bytes()
+}
+
+func TestCompressorRoundTrip(t *testing.T) {
+	src := payload(100)
+	for _, tc := range []struct {
+		name  string
+		level int
+	}{
+		{Gzip, DefaultLevel},
+		{Gzip, gzip.BestSpeed},
+		{Gzip, gzip.BestCompression},
+		{Gzip, gzip.HuffmanOnly},
+		{Zstd, DefaultLevel},
+		{Zstd, 1},
+		{Zstd, 19},
+		{Snappy, DefaultLevel},
+	} {
+		t.Run(fmt.Sprintf("%s/%d", tc.name, tc.level), func(t *testing.T) {
+			c, err := New(tc.name, tc.level)
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, c.Name())
+
+			// Compress twice to ensure pooled writers are reset.
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				require.NoError(t, c.Compress(&buf, src))
+				assert.Less(t, buf.Len(), len(src))
+
+				r, err := NewReader(tc.name, &buf)
+				require.NoError(t, err)
+				got, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.NoError(t, r.Close())
+				assert.Equal(t, src, got)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New("deflate", DefaultLevel)
+	assert.Error(t, err)
+	_, err = New(Gzip, 10)
+	assert.Error(t, err)
+	_, err = New(Zstd, 23)
+	assert.Error(t, err)
+	_, err = New(Zstd, -1)
+	assert.Error(t, err)
+
+	_, err = NewReader("deflate", nil)
+	assert.Error(t, err)
+}
+
+// gzPool mirrors the writer pool the OTLP HTTP exporters used before
+// compression levels were configurable.
+var gzPool = sync.Pool{
+	New: func() interface{} {
+		w := gzip.NewWriter(io.Discard)
+		return w
+	},
+}
+
+func BenchmarkCompress(b *testing.B) {
+	src := payload(1000)
+
+	b.Run("GzipPool", func(b *testing.B) {
+		var buf bytes.Buffer
+		b.SetBytes(int64(len(src)))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			gz := gzPool.Get().(*gzip.Writer)
+			gz.Reset(&buf)
+			_, _ = gz.Write(src)
+			_ = gz.Close()
+			gzPool.Put(gz)
+		}
+		b.ReportMetric(float64(buf.Len())/float64(len(src)), "ratio")
+	})
+
+	for _, bc := range []struct {
+		name  string
+		level int
+	}{
+		{Gzip, DefaultLevel},
+		{Gzip, gzip.BestSpeed},
+		{Zstd, DefaultLevel},
+		{Zstd, 1},
+		{Zstd, 9},
+		{Snappy, DefaultLevel},
+	} {
+		b.Run(fmt.Sprintf("%s/%d", bc.name, bc.level), func(b *testing.B) {
+			c, err := New(bc.name, bc.level)
+			require.NoError(b, err)
+			var buf bytes.Buffer
+			b.SetBytes(int64(len(src)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				_ = c.Compress(&buf, src)
+			}
+			b.ReportMetric(float64(buf.Len())/float64(len(src)), "ratio")
+		})
+	}
+}
@@ -1,9 +1,9 @@
-module go.opentelemetry.io/otel/exporters/otlp/internal/retry
+module go.opentelemetry.io/otel/exporters/otlp/internal/compress
 
 go 1.18
 
 require (
-	github.com/cenkalti/backoff/v4 v4.2.0
+	github.com/klauspost/compress v1.15.15
 	github.com/stretchr/testify v1.8.1
 )
 
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/exporters/otlp/internal"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonIDKeys are the JSON keys of all OTLP trace and span ID fields.
+var jsonIDKeys = map[string]struct{}{
+	"traceId":      {},
+	"spanId":       {},
+	"parentSpanId": {},
+}
+
+// JSONIDsToHex converts all trace and span IDs in b, the canonical protobuf
+// JSON encoding of an OTLP message, from base64 to hex encoding.
+//
+// The OTLP/JSON mapping deviates from the canonical protobuf JSON mapping by
+// requiring IDs be hex encoded.
+func JSONIDsToHex(b []byte) ([]byte, error) {
+	return convertJSONIDs(b, func(s string) (string, error) {
+		id, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(id), nil
+	})
+}
+
+// JSONIDsToBase64 converts all trace and span IDs in b, an OTLP/JSON encoded
+// message, from hex to base64 encoding. The returned value can be decoded as
+// canonical protobuf JSON.
+func JSONIDsToBase64(b []byte) ([]byte, error) {
+	return convertJSONIDs(b, func(s string) (string, error) {
+		id, err := hex.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(id), nil
+	})
+}
+
+func convertJSONIDs(b []byte, conv func(string) (string, error)) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// Preserve the exact representation of all numbers.
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := walkJSONIDs(v, conv); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encode terminates the value with a newline, do not include it.
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func walkJSONIDs(v interface{}, conv func(string) (string, error)) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if s, ok := val.(string); ok {
+				if _, isID := jsonIDKeys[k]; isID {
+					id, err := conv(s)
+					if err != nil {
+						return fmt.Errorf("invalid %s %q: %w", k, s, err)
+					}
+					t[k] = id
+				}
+				continue
+			}
+			if err := walkJSONIDs(val, conv); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, val := range t {
+			if err := walkJSONIDs(val, conv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
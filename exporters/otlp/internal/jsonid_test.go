@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONIDsToHex(t *testing.T) {
+	in := `{"resourceSpans":[{"scopeSpans":[{"spans":[{"traceId":"AQIDBAUGBwgJCgsMDQ4PEA==","spanId":"AQIDBAUGBwg=","parentSpanId":"","name":"<a>","startTimeUnixNano":"1","links":[{"traceId":"AQIDBAUGBwgJCgsMDQ4PEA==","spanId":"CAcGBQQDAgE="}]}]}]}]}`
+	want := `{"resourceSpans":[{"scopeSpans":[{"spans":[{"links":[{"spanId":"0807060504030201","traceId":"0102030405060708090a0b0c0d0e0f10"}],"name":"<a>","parentSpanId":"","spanId":"0102030405060708","startTimeUnixNano":"1","traceId":"0102030405060708090a0b0c0d0e0f10"}]}]}]}`
+
+	got, err := JSONIDsToHex([]byte(in))
+	require.NoError(t, err)
+	assert.JSONEq(t, want, string(got))
+	assert.Contains(t, string(got), `"<a>"`, "HTML characters should not be escaped")
+
+	back, err := JSONIDsToBase64(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, in, string(back))
+}
+
+func TestJSONIDsNumbersPreserved(t *testing.T) {
+	in := `{"value":12345678901234567890,"asDouble":0.1}`
+	got, err := JSONIDsToHex([]byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, `{"asDouble":0.1,"value":12345678901234567890}`, string(got))
+}
+
+func TestJSONIDsInvalid(t *testing.T) {
+	_, err := JSONIDsToBase64([]byte(`{"traceId":"not hex"}`))
+	assert.Error(t, err)
+
+	_, err = JSONIDsToHex([]byte(`{"spanId":"@@"}`))
+	assert.Error(t, err)
+
+	_, err = JSONIDsToHex([]byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,304 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rotate provides a size based rotating file writer used by the OTLP
+// file exporters.
+package rotate // import "go.opentelemetry.io/otel/exporters/otlp/internal/rotate"
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the time layout used to name backup files.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// now returns the current time. It is a variable so tests can override it.
+var now = time.Now
+
+// errClosed is returned when writing to a closed Writer.
+var errClosed = errors.New("rotate: writer closed")
+
+// Config configures a Writer.
+type Config struct {
+	// Path is the path of the file written to.
+	Path string
+	// MaxSize is the size in bytes the file can grow to before it is
+	// rotated. The file is never rotated if MaxSize is less than or equal to
+	// zero.
+	MaxSize int64
+	// MaxBackups is the maximum number of rotated files to retain. All
+	// rotated files are retained if MaxBackups is less than or equal to zero.
+	MaxBackups int
+	// Compress determines if rotated files are compressed using gzip.
+	Compress bool
+}
+
+// Writer writes newline delimited records to a file, rotating the file when
+// it grows beyond a configured size.
+//
+// When the file is rotated it is renamed to include the time of rotation
+// between its base name and extension (e.g. "traces.jsonl" is renamed to
+// "traces-20060102T150405.000000000.jsonl"), compressed if configured to do
+// so, and a new file is created at the original path.
+type Writer struct {
+	cfg Config
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+
+	// backupMu serializes the compression and pruning of rotated files.
+	// These are done without holding mu so writes are not blocked.
+	backupMu sync.Mutex
+}
+
+// New returns a Writer that appends to the file at cfg.Path, creating it and
+// any parent directories if they do not exist.
+func New(cfg Config) (*Writer, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("rotate: empty path")
+	}
+	w := &Writer{cfg: cfg}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.cfg.Path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file, w.size = f, info.Size()
+	return nil
+}
+
+// WriteLine writes record followed by a newline to the file. The file is
+// rotated prior to writing if the write would grow it beyond the configured
+// maximum size. Records are never split across files.
+//
+// If the file cannot be rotated, the record is not written and an error is
+// returned. The Writer remains usable and retries the rotation on the next
+// write.
+func (w *Writer) WriteLine(record []byte) error {
+	backup, err := w.writeLine(record)
+	if backup != "" {
+		if bErr := w.processBackup(backup); err == nil {
+			err = bErr
+		}
+	}
+	return err
+}
+
+// writeLine writes record to the file, rotating it first if needed. The path
+// of the rotated file is returned if the file was rotated.
+func (w *Writer) writeLine(record []byte) (backup string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return "", errClosed
+	}
+	if w.file == nil {
+		// A previous rotation failed to open a new file.
+		if err := w.open(); err != nil {
+			return "", err
+		}
+	}
+
+	n := int64(len(record)) + 1
+	if w.cfg.MaxSize > 0 && w.size > 0 && w.size+n > w.cfg.MaxSize {
+		if backup, err = w.rotate(); err != nil {
+			return backup, err
+		}
+	}
+
+	buf := make([]byte, 0, n)
+	buf = append(append(buf, record...), '\n')
+	written, err := w.file.Write(buf)
+	w.size += int64(written)
+	return backup, err
+}
+
+// Sync commits the current contents of the file to stable storage.
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return errClosed
+	}
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close syncs and closes the file. Subsequent writes will return an error.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Sync()
+	if cErr := w.file.Close(); err == nil {
+		err = cErr
+	}
+	w.file = nil
+	return err
+}
+
+// rotate closes the current file, moves it to a backup location, and opens a
+// new file in its place. The path of the backup is returned. If the file
+// cannot be moved, the original file is reopened so writes can continue. The
+// caller must hold w.mu.
+func (w *Writer) rotate() (string, error) {
+	// Open files cannot be renamed on all platforms, close it first.
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return "", err
+	}
+
+	dir, prefix, ext := w.nameParts()
+	backup := filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, now().UTC().Format(backupTimeFormat), ext))
+	if err := os.Rename(w.cfg.Path, backup); err != nil {
+		if oErr := w.open(); oErr != nil {
+			return "", fmt.Errorf("%w; reopen %s: %v", err, w.cfg.Path, oErr)
+		}
+		return "", err
+	}
+	return backup, w.open()
+}
+
+// processBackup compresses the rotated file at backup, if configured to do
+// so, and removes the backups exceeding the configured maximum.
+func (w *Writer) processBackup(backup string) error {
+	w.backupMu.Lock()
+	defer w.backupMu.Unlock()
+
+	if w.cfg.Compress {
+		if err := compress(backup); err != nil {
+			return err
+		}
+	}
+	return w.prune()
+}
+
+// nameParts returns the directory, base name without extension, and
+// extension of the configured path.
+func (w *Writer) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(w.cfg.Path)
+	base := filepath.Base(w.cfg.Path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext), ext
+}
+
+// prune removes the oldest backups exceeding the configured maximum.
+func (w *Writer) prune() error {
+	if w.cfg.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := w.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > w.cfg.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backups returns the paths of all rotated files, oldest first.
+func (w *Writer) backups() ([]string, error) {
+	dir, prefix, ext := w.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix+"-") {
+			continue
+		}
+		ts := strings.TrimPrefix(name, prefix+"-")
+		ts = strings.TrimSuffix(strings.TrimSuffix(ts, ".gz"), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err != nil {
+			continue
+		}
+		out = append(out, filepath.Join(dir, name))
+	}
+	// The timestamp format sorts lexically in chronological order.
+	sort.Strings(out)
+	return out, nil
+}
+
+// compress replaces the file at path with a gzip compressed copy at path
+// with a ".gz" suffix.
+func compress(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := dst.Close(); err == nil {
+			err = cErr
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
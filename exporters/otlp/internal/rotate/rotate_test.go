@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotate
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeClock(t *testing.T) {
+	current := time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC)
+	orig := now
+	now = func() time.Time {
+		current = current.Add(time.Second)
+		return current
+	}
+	t.Cleanup(func() { now = orig })
+}
+
+func readFile(t *testing.T, path string) string {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestWriterAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "out.jsonl")
+	w, err := New(Config{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("a")))
+	require.NoError(t, w.Close())
+
+	// Reopening appends to the existing file.
+	w, err = New(Config{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("b")))
+	require.NoError(t, w.Sync())
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "a\nb\n", readFile(t, path))
+	assert.ErrorIs(t, w.WriteLine([]byte("c")), errClosed)
+	assert.NoError(t, w.Close(), "multiple close")
+}
+
+func TestWriterEmptyPath(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
+
+func TestWriterRotates(t *testing.T) {
+	fakeClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.jsonl")
+	w, err := New(Config{Path: path, MaxSize: 8, MaxBackups: 2})
+	require.NoError(t, err)
+
+	for _, r := range []string{"one", "two", "three", "four", "five"} {
+		require.NoError(t, w.WriteLine([]byte(r)))
+	}
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "five\n", readFile(t, path))
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2, "oldest backups not pruned")
+	assert.Equal(t, filepath.Join(dir, "out-20221201T000002.000000000.jsonl"), backups[0])
+	assert.Equal(t, "three\n", readFile(t, backups[0]))
+	assert.Equal(t, "four\n", readFile(t, backups[1]))
+}
+
+func TestWriterRotateFailure(t *testing.T) {
+	fakeClock(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.jsonl")
+	w, err := New(Config{Path: path, MaxSize: 4})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("one")))
+
+	// A non-empty directory at the backup path makes the rename fail.
+	blocked := filepath.Join(dir, "out-20221201T000001.000000000.jsonl")
+	require.NoError(t, os.MkdirAll(filepath.Join(blocked, "dir"), 0o755))
+	assert.Error(t, w.WriteLine([]byte("two")))
+	assert.Equal(t, "one\n", readFile(t, path))
+
+	// The Writer is still usable and the next rotation succeeds.
+	require.NoError(t, w.WriteLine([]byte("two")))
+	require.NoError(t, w.Close())
+	assert.Equal(t, "two\n", readFile(t, path))
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "one\n", readFile(t, backups[0]))
+}
+
+func TestWriterLargeRecord(t *testing.T) {
+	fakeClock(t)
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	w, err := New(Config{Path: path, MaxSize: 2})
+	require.NoError(t, err)
+
+	// Records larger than the max size are written whole to an empty file.
+	require.NoError(t, w.WriteLine([]byte("large")))
+	require.NoError(t, w.Close())
+	assert.Equal(t, "large\n", readFile(t, path))
+	backups, err := w.backups()
+	require.NoError(t, err)
+	assert.Len(t, backups, 0)
+}
+
+func TestWriterCompress(t *testing.T) {
+	fakeClock(t)
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	w, err := New(Config{Path: path, MaxSize: 4, Compress: true})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("one")))
+	require.NoError(t, w.WriteLine([]byte("two")))
+	require.NoError(t, w.Close())
+
+	backups, err := w.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, ".gz", filepath.Ext(backups[0]))
+
+	f, err := os.Open(backups[0])
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	b, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "one\n", string(b))
+}
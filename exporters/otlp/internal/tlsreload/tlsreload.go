@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsreload provides TLS configuration for the OTLP exporters that
+// is reloaded from certificate files when they change.
+package tlsreload // import "go.opentelemetry.io/otel/exporters/otlp/internal/tlsreload"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Files are the paths of the PEM encoded files used to create a TLS
+// configuration. Empty paths are not used.
+type Files struct {
+	// CAFile is the path of the certificate authorities used to verify
+	// servers.
+	CAFile string
+	// CertFile and KeyFile are the paths of the client certificate and key
+	// used for mutual TLS. Both need to be set for either to be used.
+	CertFile string
+	KeyFile  string
+}
+
+// Empty returns if f contains no usable file paths.
+func (f Files) Empty() bool {
+	return f.CAFile == "" && (f.CertFile == "" || f.KeyFile == "")
+}
+
+// Reloader creates TLS configurations from Files. The files are re-read each
+// time a configuration is created and the configuration is updated if their
+// contents have changed.
+//
+// The CA file and the client certificate and key are loaded independently.
+// Failures to read or parse them are sent to the global error handler, and
+// the last valid contents continue to be used. A file that has never been
+// loaded is not used in the configuration until it can be loaded.
+type Reloader struct {
+	files    Files
+	readFile func(string) ([]byte, error)
+
+	mu      sync.Mutex
+	caRaw   []byte
+	rootCAs *x509.CertPool
+	certRaw []byte
+	keyRaw  []byte
+	cert    *tls.Certificate
+}
+
+// New returns a Reloader for files that uses readFile to read them.
+func New(files Files, readFile func(string) ([]byte, error)) *Reloader {
+	if files.CertFile == "" || files.KeyFile == "" {
+		files.CertFile, files.KeyFile = "", ""
+	}
+	r := &Reloader{files: files, readFile: readFile}
+	r.reload()
+	return r
+}
+
+// Config returns a new TLS configuration using the current contents of the
+// files.
+//
+// The returned configuration does not change when the files do. A new
+// configuration should be used for each new connection.
+func (r *Reloader) Config() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reload()
+
+	cfg := &tls.Config{RootCAs: r.rootCAs}
+	if r.cert != nil {
+		cfg.Certificates = []tls.Certificate{*r.cert}
+	}
+	return cfg
+}
+
+// reload updates r with the contents of the files if they changed. The
+// caller must hold the r.mu lock.
+func (r *Reloader) reload() {
+	if r.files.CAFile != "" {
+		if err := r.reloadCA(); err != nil {
+			otel.Handle(err)
+		}
+	}
+	if r.files.CertFile != "" {
+		if err := r.reloadCert(); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// reloadCA updates the root CAs of r if the CA file changed.
+func (r *Reloader) reloadCA() error {
+	b, err := r.readFile(r.files.CAFile)
+	if err != nil {
+		return fmt.Errorf("read tls ca cert file %s: %w", r.files.CAFile, err)
+	}
+	if bytes.Equal(b, r.caRaw) {
+		return nil
+	}
+	cp := x509.NewCertPool()
+	if !cp.AppendCertsFromPEM(b) {
+		return fmt.Errorf("failed to append certificate from %s to the cert pool", r.files.CAFile)
+	}
+	r.caRaw, r.rootCAs = b, cp
+	return nil
+}
+
+// reloadCert updates the client certificate of r if the certificate or key
+// file changed.
+func (r *Reloader) reloadCert() error {
+	c, err := r.readFile(r.files.CertFile)
+	if err != nil {
+		return fmt.Errorf("read tls client cert %s: %w", r.files.CertFile, err)
+	}
+	k, err := r.readFile(r.files.KeyFile)
+	if err != nil {
+		return fmt.Errorf("read tls client key %s: %w", r.files.KeyFile, err)
+	}
+	if bytes.Equal(c, r.certRaw) && bytes.Equal(k, r.keyRaw) {
+		return nil
+	}
+	crt, err := tls.X509KeyPair(c, k)
+	if err != nil {
+		return fmt.Errorf("create tls client key pair: %w", err)
+	}
+	r.certRaw, r.keyRaw, r.cert = c, k, &crt
+	return nil
+}
+
+// DialTLSContext returns a function that dials a TLS connection with
+// dialer. A new configuration from r is used for each connection.
+//
+// The returned function is intended to be used as the DialTLSContext field
+// of an http.Transport. The connection negotiates HTTP/2 if the server
+// supports it.
+func (r *Reloader) DialTLSContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		cfg := r.Config()
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		cfg.ServerName = host
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
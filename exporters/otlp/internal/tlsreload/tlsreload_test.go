@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM encoded certificate and key signed by ca.
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestFilesEmpty(t *testing.T) {
+	assert.True(t, Files{}.Empty())
+	assert.True(t, Files{CertFile: "cert"}.Empty())
+	assert.False(t, Files{CAFile: "ca"}.Empty())
+	assert.False(t, Files{CertFile: "cert", KeyFile: "key"}.Empty())
+}
+
+// captureErrors returns the errors sent to the global error handler during
+// the test.
+func captureErrors(t *testing.T) *[]error {
+	t.Helper()
+	errs := new([]error)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		*errs = append(*errs, err)
+	}))
+	return errs
+}
+
+func TestNewErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.pem")
+	writeFile(t, invalid, []byte("invalid certificate file."))
+
+	errs := captureErrors(t)
+	New(Files{CAFile: filepath.Join(dir, "missing.pem")}, os.ReadFile)
+	New(Files{CAFile: invalid}, os.ReadFile)
+	New(Files{CertFile: invalid, KeyFile: invalid}, os.ReadFile)
+	assert.Len(t, *errs, 3)
+}
+
+func TestNewPartialFailure(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	ca := newTestCA(t, "ca")
+	c, k := ca.issue(t, 1, x509.ExtKeyUsageClientAuth)
+	writeFile(t, caFile, []byte("invalid"))
+	writeFile(t, certFile, c)
+	writeFile(t, keyFile, k)
+
+	// The invalid CA file does not prevent the client certificate from being
+	// used.
+	errs := captureErrors(t)
+	r := New(Files{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, os.ReadFile)
+	require.Len(t, *errs, 1)
+	assert.ErrorContains(t, (*errs)[0], caFile)
+	cfg := r.Config()
+	assert.Nil(t, cfg.RootCAs)
+	assert.Len(t, cfg.Certificates, 1)
+
+	// The CA file is used once it is valid.
+	writeFile(t, caFile, ca.pem)
+	cfg = r.Config()
+	assert.NotNil(t, cfg.RootCAs)
+	assert.Len(t, cfg.Certificates, 1)
+
+	// An invalid client key does not prevent the CA file from being used.
+	writeFile(t, keyFile, []byte("invalid"))
+	*errs = nil
+	r = New(Files{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, os.ReadFile)
+	require.Len(t, *errs, 1)
+	cfg = r.Config()
+	assert.NotNil(t, cfg.RootCAs)
+	assert.Len(t, cfg.Certificates, 0)
+}
+
+func TestReloaderConfig(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	ca := newTestCA(t, "ca")
+	writeFile(t, caFile, ca.pem)
+	c, k := ca.issue(t, 1, x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, c)
+	writeFile(t, keyFile, k)
+
+	r := New(Files{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, os.ReadFile)
+
+	cfg := r.Config()
+	require.NotNil(t, cfg.RootCAs)
+	require.Len(t, cfg.Certificates, 1)
+	first := cfg.Certificates[0]
+
+	c, k = ca.issue(t, 2, x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, c)
+	writeFile(t, keyFile, k)
+	cfg = r.Config()
+	require.Len(t, cfg.Certificates, 1)
+	assert.NotEqual(t, first.Certificate, cfg.Certificates[0].Certificate, "certificate not reloaded")
+
+	// Invalid updates keep the last valid configuration.
+	writeFile(t, certFile, []byte("invalid"))
+	writeFile(t, caFile, []byte("invalid"))
+	prev := cfg
+	cfg = r.Config()
+	assert.Equal(t, prev.Certificates, cfg.Certificates)
+	assert.Same(t, prev.RootCAs, cfg.RootCAs)
+}
+
+func TestReloaderDialTLSContext(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	serverCA, clientCA := newTestCA(t, "server-ca"), newTestCA(t, "client-ca")
+	srvCert, srvKey := serverCA.issue(t, 1, x509.ExtKeyUsageServerAuth)
+	srvPair, err := tls.X509KeyPair(srvCert, srvKey)
+	require.NoError(t, err)
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(clientCA.cert)
+
+	serials := make(chan int64, 10)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serials <- r.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{srvPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	writeFile(t, caFile, serverCA.pem)
+	c, k := clientCA.issue(t, 1, x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, c)
+	writeFile(t, keyFile, k)
+
+	r := New(Files{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, os.ReadFile)
+
+	get := func() error {
+		client := &http.Client{Transport: &http.Transport{
+			DialTLSContext:    r.DialTLSContext(&net.Dialer{}),
+			ForceAttemptHTTP2: true,
+		}}
+		defer client.CloseIdleConnections()
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get())
+	assert.Equal(t, int64(1), <-serials)
+
+	// Rotated client certificates are used for new connections.
+	c, k = clientCA.issue(t, 2, x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, c)
+	writeFile(t, keyFile, k)
+	require.NoError(t, get())
+	assert.Equal(t, int64(2), <-serials)
+
+	// An updated CA bundle is used to verify the server.
+	writeFile(t, caFile, newTestCA(t, "other-ca").pem)
+	assert.Error(t, get())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = r.DialTLSContext(&net.Dialer{})(ctx, "tcp", srv.Listener.Addr().String())
+	assert.Error(t, err)
+}
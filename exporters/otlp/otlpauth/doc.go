@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpauth provides authentication helpers for the OTLP exporters.
+//
+// The HeaderProvider function adapts a TokenSource into a header provider that
+// can be passed to the WithHeaderProvider option of the otlptracegrpc,
+// otlptracehttp, otlpmetricgrpc, and otlpmetrichttp packages so every export
+// request is sent with a current bearer token. ReuseTokenSource caches tokens
+// and refreshes them before they expire.
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
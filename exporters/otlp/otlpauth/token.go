@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpauth // import "go.opentelemetry.io/otel/exporters/otlp/otlpauth"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultEarlyExpiry is the duration before a token expires that
+// ReuseTokenSource will refresh it if no other duration is provided.
+const DefaultEarlyExpiry = 10 * time.Second
+
+// now returns the current time. It is a variable so tests can override it.
+var now = time.Now
+
+var errEmptyToken = errors.New("otlpauth: empty access token")
+
+// Token is a credential used to authenticate export requests.
+type Token struct {
+	// AccessToken is the value of the credential.
+	AccessToken string
+
+	// TokenType is the authorization scheme of the credential. "Bearer" is
+	// used if it is empty.
+	TokenType string
+
+	// Expiry is the time the token expires. A zero value means the token
+	// never expires.
+	Expiry time.Time
+}
+
+// expired returns if t expires within earlyExpiry of the current time.
+func (t *Token) expired(earlyExpiry time.Duration) bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return !now().Add(earlyExpiry).Before(t.Expiry)
+}
+
+// TokenSource returns tokens used to authenticate export requests.
+type TokenSource interface {
+	// Token returns a token or an error. The passed context is the context
+	// of the export request being authenticated.
+	Token(context.Context) (*Token, error)
+}
+
+// TokenSourceFunc is a function that implements the TokenSource interface.
+type TokenSourceFunc func(context.Context) (*Token, error)
+
+// Token returns the token returned by calling f.
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// ReuseTokenSource returns a TokenSource that returns the last token
+// retrieved from src until it is within earlyExpiry of expiring. Once a token
+// is that close to expiring, a new token is retrieved from src. If that
+// retrieval fails the previous token continues to be used until it actually
+// expires.
+//
+// If earlyExpiry is less than or equal to zero, DefaultEarlyExpiry is used.
+//
+// The returned TokenSource is safe for concurrent use.
+func ReuseTokenSource(src TokenSource, earlyExpiry time.Duration) TokenSource {
+	if earlyExpiry <= 0 {
+		earlyExpiry = DefaultEarlyExpiry
+	}
+	return &reuseTokenSource{src: src, earlyExpiry: earlyExpiry}
+}
+
+type reuseTokenSource struct {
+	src         TokenSource
+	earlyExpiry time.Duration
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token returns the cached token if it is still valid, otherwise a new token
+// is retrieved.
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && !s.token.expired(s.earlyExpiry) {
+		return s.token, nil
+	}
+
+	t, err := s.src.Token(ctx)
+	if err != nil || t == nil || t.AccessToken == "" {
+		if err == nil {
+			err = errEmptyToken
+		}
+		// Keep using a token that is about to expire, but has not yet.
+		if s.token != nil && !s.token.expired(0) {
+			return s.token, nil
+		}
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+// HeaderProvider returns a header provider that sets the Authorization header
+// of an export request to a token retrieved from src. It can be passed to the
+// WithHeaderProvider option of the OTLP exporters.
+//
+// An error is returned from the provider, aborting the export, if src returns
+// an error or an empty token.
+func HeaderProvider(src TokenSource) func(context.Context) (map[string]string, error) {
+	return func(ctx context.Context) (map[string]string, error) {
+		t, err := src.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil || t.AccessToken == "" {
+			return nil, errEmptyToken
+		}
+		typ := t.TokenType
+		if typ == "" {
+			typ = "Bearer"
+		}
+		return map[string]string{"Authorization": typ + " " + t.AccessToken}, nil
+	}
+}
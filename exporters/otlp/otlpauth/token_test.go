@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpauth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeNow(t *testing.T, current *time.Time) {
+	orig := now
+	now = func() time.Time { return *current }
+	t.Cleanup(func() { now = orig })
+}
+
+// countingSource returns a new token, valid for a minute, every call.
+type countingSource struct {
+	calls int
+	err   error
+}
+
+func (s *countingSource) Token(context.Context) (*Token, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.calls++
+	return &Token{
+		AccessToken: strconv.Itoa(s.calls),
+		Expiry:      now().Add(time.Minute),
+	}, nil
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	current := time.Unix(0, 0)
+	fakeNow(t, &current)
+
+	src := &countingSource{}
+	ts := ReuseTokenSource(src, 10*time.Second)
+	ctx := context.Background()
+
+	tok, err := ts.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", tok.AccessToken)
+
+	current = current.Add(49 * time.Second)
+	tok, err = ts.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", tok.AccessToken, "token refreshed too early")
+
+	current = current.Add(time.Second)
+	tok, err = ts.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2", tok.AccessToken, "token not refreshed before expiry")
+}
+
+func TestReuseTokenSourceRefreshError(t *testing.T) {
+	current := time.Unix(0, 0)
+	fakeNow(t, &current)
+
+	src := &countingSource{}
+	ts := ReuseTokenSource(src, 0)
+	ctx := context.Background()
+
+	_, err := ts.Token(ctx)
+	require.NoError(t, err)
+
+	// Failed refresh within the early expiry window uses the valid token.
+	src.err = assert.AnError
+	current = current.Add(time.Minute - DefaultEarlyExpiry)
+	tok, err := ts.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", tok.AccessToken)
+
+	// Once expired, the error is returned.
+	current = current.Add(DefaultEarlyExpiry)
+	_, err = ts.Token(ctx)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestReuseTokenSourceNoExpiry(t *testing.T) {
+	var calls int
+	ts := ReuseTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		calls++
+		return &Token{AccessToken: "static"}, nil
+	}), time.Second)
+
+	for i := 0; i < 3; i++ {
+		_, err := ts.Token(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestHeaderProvider(t *testing.T) {
+	ctx := context.Background()
+	p := HeaderProvider(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{AccessToken: "abc"}, nil
+	}))
+	h, err := p(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, h)
+
+	p = HeaderProvider(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{AccessToken: "abc", TokenType: "Basic"}, nil
+	}))
+	h, err = p(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Basic abc"}, h)
+
+	p = HeaderProvider(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return nil, assert.AnError
+	}))
+	_, err = p(ctx)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	p = HeaderProvider(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{}, nil
+	}))
+	_, err = p(ctx)
+	assert.ErrorIs(t, err, errEmptyToken)
+}
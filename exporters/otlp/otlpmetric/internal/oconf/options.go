@@ -15,6 +15,7 @@
 package oconf // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"time"
@@ -23,10 +24,16 @@ import (
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/encoding"
+	// Register the gzip compressor with gRPC. The zstd and snappy
+	// compressors are registered by importing the packages in
+	// go.opentelemetry.io/otel/exporters/otlp/grpcencoding.
+	_ "google.golang.org/grpc/encoding/gzip"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tlsreload"
+	"go.opentelemetry.io/otel/exporters/retry"
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
@@ -58,6 +65,24 @@ type (
 		Timeout     time.Duration
 		URLPath     string
 
+		// CompressionLevel is the level payloads are compressed at. Zero
+		// means the default level of the compression.
+		CompressionLevel int
+
+		// TLSReloader, if set, provides the TLS configuration used for
+		// each new HTTP connection. TLSCfg holds its initial configuration.
+		TLSReloader *tlsreload.Reloader
+
+		// HeaderProvider returns headers added to each export request.
+		HeaderProvider func(context.Context) (map[string]string, error)
+
+		// MaxRequestSize is the maximum size in bytes of an encoded export
+		// request. Larger requests are split. Zero means no limit.
+		MaxRequestSize int
+
+		// HTTP configurations
+		Marshaler Marshaler
+
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
 
@@ -69,7 +94,7 @@ type (
 		// Signal specific configurations
 		Metrics SignalConfig
 
-		RetryConfig retry.Config
+		RetryPolicy retry.Policy
 
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
@@ -92,7 +117,7 @@ func NewHTTPConfig(opts ...HTTPOption) Config {
 			TemporalitySelector: metric.DefaultTemporalitySelector,
 			AggregationSelector: metric.DefaultAggregationSelector,
 		},
-		RetryConfig: retry.DefaultConfig,
+		RetryPolicy: retry.DefaultPolicy,
 	}
 	cfg = ApplyHTTPEnvConfigs(cfg)
 	for _, opt := range opts {
@@ -115,7 +140,7 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 			TemporalitySelector: metric.DefaultTemporalitySelector,
 			AggregationSelector: metric.DefaultAggregationSelector,
 		},
-		RetryConfig: retry.DefaultConfig,
+		RetryPolicy: retry.DefaultPolicy,
 		DialOptions: []grpc.DialOption{grpc.WithUserAgent(internal.GetUserAgentHeader())},
 	}
 	cfg = ApplyGRPCEnvConfigs(cfg)
@@ -137,8 +162,14 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Metrics.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Metrics.Compression == GzipCompression {
-		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	if name := cfg.Metrics.Compression.Name(); name != "" {
+		if encoding.GetCompressor(name) == nil {
+			// gRPC fails every request using a compressor that is not
+			// registered, send the requests uncompressed instead.
+			otel.Handle(fmt.Errorf("gRPC compressor %q is not registered, import go.opentelemetry.io/otel/exporters/otlp/grpcencoding/%s to register it; sending requests uncompressed", name, name))
+		} else {
+			cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(name)))
+		}
 	}
 	if len(cfg.DialOptions) != 0 {
 		cfg.DialOptions = append(cfg.DialOptions, cfg.DialOptions...)
@@ -274,6 +305,20 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithCompressionLevel(level int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.CompressionLevel = level
+		return cfg
+	})
+}
+
+func WithMarshaler(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.URLPath = urlPath
@@ -281,9 +326,9 @@ func WithURLPath(urlPath string) GenericOption {
 	})
 }
 
-func WithRetry(rc retry.Config) GenericOption {
+func WithRetry(p retry.Policy) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
-		cfg.RetryConfig = rc
+		cfg.RetryPolicy = p
 		return cfg
 	})
 }
@@ -291,6 +336,7 @@ func WithRetry(rc retry.Config) GenericOption {
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Metrics.TLSCfg = tlsCfg.Clone()
+		cfg.Metrics.TLSReloader = nil
 		return cfg
 	}, func(cfg Config) Config {
 		cfg.Metrics.GRPCCredentials = credentials.NewTLS(tlsCfg)
@@ -298,6 +344,19 @@ func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	})
 }
 
+// withTLSReloader configures the client to use the current TLS
+// configuration of r for each new connection.
+func withTLSReloader(r *tlsreload.Reloader) GenericOption {
+	return newSplitOption(func(cfg Config) Config {
+		cfg.Metrics.TLSCfg = r.Config()
+		cfg.Metrics.TLSReloader = r
+		return cfg
+	}, func(cfg Config) Config {
+		cfg.Metrics.GRPCCredentials = &reloadingCredentials{reloader: r}
+		return cfg
+	})
+}
+
 func WithInsecure() GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.Insecure = true
@@ -319,6 +378,20 @@ func WithHeaders(headers map[string]string) GenericOption {
 	})
 }
 
+func WithHeaderProvider(fn func(context.Context) (map[string]string, error)) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.HeaderProvider = fn
+		return cfg
+	})
+}
+
+func WithMaxRequestSize(size int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Metrics.MaxRequestSize = size
+		return cfg
+	})
+}
+
 func WithTimeout(duration time.Duration) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Metrics.Timeout = duration
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/exporters/otlp/internal/envconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
@@ -265,6 +266,83 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+				"key_path":  []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				if grpcOption {
+					require.NotNil(t, c.Metrics.GRPCCredentials)
+					assert.Equal(t, "tls", c.Metrics.GRPCCredentials.Info().SecurityProtocol)
+				} else {
+					require.NotNil(t, c.Metrics.TLSReloader, "environment certificates are not reloaded")
+					assert.Len(t, c.Metrics.TLSCfg.Certificates, 1)
+				}
+			},
+		},
+		{
+			name: "Test Environment Invalid Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "invalid_cert",
+			},
+			fileReader: fileReader{
+				"invalid_cert": []byte("invalid certificate file."),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				if !grpcOption {
+					// The file is retried when new connections are made.
+					require.NotNil(t, c.Metrics.TLSReloader)
+					assert.Nil(t, c.Metrics.TLSCfg.RootCAs)
+				}
+			},
+		},
+		{
+			name: "Test Environment Invalid Certificate Valid Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "invalid_cert",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"invalid_cert": []byte("invalid certificate file."),
+				"cert_path":    []byte(WeakCertificate),
+				"key_path":     []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				if !grpcOption {
+					// The invalid CA file does not drop the client certificate.
+					require.NotNil(t, c.Metrics.TLSReloader)
+					assert.Nil(t, c.Metrics.TLSCfg.RootCAs)
+					assert.Len(t, c.Metrics.TLSCfg.Certificates, 1)
+				}
+			},
+		},
+		{
+			name: "Test With Certificate Overrides Environment Certificate",
+			opts: []oconf.GenericOption{
+				oconf.WithTLSClientConfig(tlsCert),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "cert_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				if !grpcOption {
+					assert.Nil(t, c.Metrics.TLSReloader)
+					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
+					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Metrics.TLSCfg.RootCAs.Subjects())
+				}
+			},
+		},
 		// Headers tests
 		{
 			name: "Test With Headers",
@@ -344,6 +422,88 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.ZstdCompression, c.Metrics.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":         "zstd",
+				"OTEL_EXPORTER_OTLP_METRICS_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.SnappyCompression, c.Metrics.Compression)
+			},
+		},
+		{
+			name: "Test With Compression Level",
+			opts: []oconf.GenericOption{
+				oconf.WithCompression(oconf.ZstdCompression),
+				oconf.WithCompressionLevel(19),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.ZstdCompression, c.Metrics.Compression)
+				assert.Equal(t, 19, c.Metrics.CompressionLevel)
+			},
+		},
+
+		// Protocol Tests
+		{
+			name: "Test With Marshaler",
+			opts: []oconf.GenericOption{
+				oconf.WithMarshaler(oconf.MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalJSON, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         "http/json",
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Unknown Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []oconf.GenericOption{
+				oconf.WithMarshaler(oconf.MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *oconf.Config, grpcOption bool) {
+				assert.Equal(t, oconf.MarshalProto, c.Metrics.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
@@ -14,6 +14,8 @@
 
 package oconf // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
 
+import "go.opentelemetry.io/otel/exporters/otlp/internal/compress"
+
 import "time"
 
 const (
@@ -37,6 +39,37 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy.
+	SnappyCompression
+)
+
+// Name returns the encoding name of c, or an empty string for
+// NoCompression.
+func (c Compression) Name() string {
+	switch c {
+	case GzipCompression:
+		return compress.Gzip
+	case ZstdCompression:
+		return compress.Zstd
+	case SnappyCompression:
+		return compress.Snappy
+	default:
+		return ""
+	}
+}
+
+// Marshaler describes the kind of message format sent to the collector.
+type Marshaler int
+
+const (
+	// MarshalProto tells the driver to send using the protobuf binary format.
+	MarshalProto Marshaler = iota
+	// MarshalJSON tells the driver to send using json format.
+	MarshalJSON
 )
 
 // RetrySettings defines configuration for retrying batches in case of export failure
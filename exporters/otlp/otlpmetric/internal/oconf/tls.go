@@ -15,10 +15,16 @@
 package oconf // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"net"
 	"os"
+
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tlsreload"
 )
 
 // ReadTLSConfigFromFile reads a PEM certificate file and creates
@@ -44,3 +50,40 @@ func CreateTLSConfig(certBytes []byte) (*tls.Config, error) {
 		RootCAs: cp,
 	}, nil
 }
+
+// reloadingCredentials are gRPC TLS transport credentials that use the
+// current configuration of a Reloader for each new connection.
+type reloadingCredentials struct {
+	reloader   *tlsreload.Reloader
+	serverName string
+}
+
+var _ credentials.TransportCredentials = (*reloadingCredentials)(nil)
+
+func (c *reloadingCredentials) current() credentials.TransportCredentials {
+	cfg := c.reloader.Config()
+	cfg.ServerName = c.serverName
+	return credentials.NewTLS(cfg)
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.current().ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("server handshake is not supported")
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{ServerName: c.serverName}).Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	cp := *c
+	return &cp
+}
+
+func (c *reloadingCredentials) OverrideServerName(serverNameOverride string) error {
+	c.serverName = serverNameOverride
+	return nil
+}
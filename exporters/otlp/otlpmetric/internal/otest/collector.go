@@ -16,7 +16,6 @@ package otest // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/inte
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -38,8 +37,11 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
 	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
@@ -281,13 +283,13 @@ func (c *HTTPCollector) Headers() map[string][]string {
 }
 
 func (c *HTTPCollector) handler(w http.ResponseWriter, r *http.Request) {
-	c.respond(w, c.record(r))
+	c.respond(w, r.Header.Get("Content-Type"), c.record(r))
 }
 
 func (c *HTTPCollector) record(r *http.Request) ExportResult {
-	// Currently only supports protobuf.
-	if v := r.Header.Get("Content-Type"); v != "application/x-protobuf" {
-		err := fmt.Errorf("content-type not supported: %s", v)
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/x-protobuf" && contentType != "application/json" {
+		err := fmt.Errorf("content-type not supported: %s", contentType)
 		return ExportResult{Err: err}
 	}
 
@@ -296,7 +298,14 @@ func (c *HTTPCollector) record(r *http.Request) ExportResult {
 		return ExportResult{Err: err}
 	}
 	pbRequest := &collpb.ExportMetricsServiceRequest{}
-	err = proto.Unmarshal(body, pbRequest)
+	if contentType == "application/json" {
+		body, err = internal.JSONIDsToBase64(body)
+		if err == nil {
+			err = protojson.Unmarshal(body, pbRequest)
+		}
+	} else {
+		err = proto.Unmarshal(body, pbRequest)
+	}
 	if err != nil {
 		return ExportResult{
 			Err: &HTTPResponseError{
@@ -323,18 +332,17 @@ func (c *HTTPCollector) record(r *http.Request) ExportResult {
 
 func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 	var reader io.ReadCloser
-	switch r.Header.Get("Content-Encoding") {
-	case "gzip":
-		reader, err = gzip.NewReader(r.Body)
+	switch enc := r.Header.Get("Content-Encoding"); enc {
+	case "":
+		reader = r.Body
+	default:
+		reader, err = compress.NewReader(enc, r.Body)
 		if err != nil {
-			_ = reader.Close()
 			return nil, &HTTPResponseError{
 				Err:    err,
 				Status: http.StatusInternalServerError,
 			}
 		}
-	default:
-		reader = r.Body
 	}
 
 	defer func() {
@@ -356,7 +364,7 @@ func (c *HTTPCollector) readBody(r *http.Request) (body []byte, err error) {
 	return body, err
 }
 
-func (c *HTTPCollector) respond(w http.ResponseWriter, resp ExportResult) {
+func (c *HTTPCollector) respond(w http.ResponseWriter, contentType string, resp ExportResult) {
 	if resp.Err != nil {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -376,6 +384,21 @@ func (c *HTTPCollector) respond(w http.ResponseWriter, resp ExportResult) {
 		return
 	}
 
+	if contentType == "application/json" {
+		response := resp.Response
+		if response == nil {
+			response = &collpb.ExportMetricsServiceResponse{}
+		}
+		r, err := protojson.Marshal(response)
+		if err != nil {
+			panic(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(r)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/x-protobuf")
 	w.WriteHeader(http.StatusOK)
 	if resp.Response == nil {
@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package split splits OTLP metric data into chunks that do not exceed a
+// maximum encoded request size.
+package split // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/split"
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// Sizer measures the size of messages in the encoding used to send export
+// requests.
+type Sizer struct {
+	// Size returns the number of bytes m is encoded as.
+	Size func(m proto.Message) int
+	// Overhead is the maximum number of bytes, in addition to the size of
+	// the message itself, used to encode a message as an element of a
+	// repeated field of its parent.
+	Overhead int
+}
+
+// Protobuf is the Sizer of the protobuf binary encoding.
+var Protobuf = Sizer{
+	Size: proto.Size,
+	// The tag and length of an embedded message field use 1 byte and up to
+	// 5 bytes respectively.
+	Overhead: 6,
+}
+
+// JSON returns the Sizer of a JSON encoding where marshal encodes messages.
+// Each message is encoded to be measured, this is significantly more
+// expensive than measuring the protobuf encoding.
+func JSON(marshal func(proto.Message) ([]byte, error)) Sizer {
+	return Sizer{
+		Size: func(m proto.Message) int {
+			b, err := marshal(m)
+			if err != nil {
+				// The error is returned when the request is encoded.
+				return 0
+			}
+			return len(b)
+		},
+		// A separating comma and, for the first element, the name of the
+		// longest field ("resourceMetrics") and the array brackets.
+		Overhead: len(`,"resourceMetrics":[]`),
+	}
+}
+
+// ResourceMetrics splits rm into chunks where each chunk, when encoded as an
+// ExportMetricsServiceRequest, is estimated to be no larger than maxSize
+// bytes as measured by sizer.
+//
+// Resource, instrumentation scope, and metric grouping is preserved: data
+// points in a chunk are nested in copies of the ResourceMetrics,
+// ScopeMetrics, and Metric they were in. A single data point larger than
+// maxSize is returned in a chunk of its own.
+//
+// If maxSize is less than or equal to zero, or all the data fits in one
+// chunk, rm is returned as the only chunk.
+func ResourceMetrics(rm *mpb.ResourceMetrics, maxSize int, sizer Sizer) []*mpb.ResourceMetrics {
+	fieldOverhead := sizer.Overhead
+	if rm == nil || maxSize <= 0 || sizer.Size(rm)+fieldOverhead <= maxSize {
+		return []*mpb.ResourceMetrics{rm}
+	}
+
+	rmShell := sizer.Size(&mpb.ResourceMetrics{Resource: rm.Resource, SchemaUrl: rm.SchemaUrl}) + fieldOverhead
+	var (
+		chunks []*mpb.ResourceMetrics
+
+		// The chunk currently being built, its estimated size, and the
+		// ScopeMetrics data points are being added to in it.
+		cur   *mpb.ResourceMetrics
+		size  int
+		curSM *mpb.ScopeMetrics
+		// The index of the first data point of the current metric in the
+		// current chunk, or -1 if the metric is not yet in the chunk.
+		first int
+	)
+	flush := func() {
+		if cur != nil {
+			chunks = append(chunks, cur)
+		}
+		cur, size, curSM, first = nil, 0, nil, -1
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		smShell := sizer.Size(&mpb.ScopeMetrics{Scope: sm.Scope, SchemaUrl: sm.SchemaUrl}) + fieldOverhead
+		curSM = nil
+		for _, m := range sm.Metrics {
+			pts := newPoints(m, sizer)
+			mShell := sizer.Size(pts.subset(0, 0)) + fieldOverhead
+			first = -1
+			for i := 0; i < pts.n; i++ {
+				cost := pts.size(i) + fieldOverhead
+				if first < 0 {
+					cost += mShell
+				}
+				if curSM == nil {
+					cost += smShell
+				}
+				if cur != nil && size+cost > maxSize {
+					flush()
+					cost = pts.size(i) + fieldOverhead + mShell + smShell
+				}
+				if cur == nil {
+					cur = &mpb.ResourceMetrics{Resource: rm.Resource, SchemaUrl: rm.SchemaUrl}
+					size = rmShell
+				}
+				if curSM == nil {
+					curSM = &mpb.ScopeMetrics{Scope: sm.Scope, SchemaUrl: sm.SchemaUrl}
+					cur.ScopeMetrics = append(cur.ScopeMetrics, curSM)
+				}
+				if first < 0 {
+					first = i
+					curSM.Metrics = append(curSM.Metrics, nil)
+				}
+				// Replace the metric in the chunk with one holding all the
+				// data points added to it so far.
+				curSM.Metrics[len(curSM.Metrics)-1] = pts.subset(first, i+1)
+				size += cost
+			}
+		}
+	}
+	flush()
+	return chunks
+}
+
+// Export splits rm using ResourceMetrics and calls export for each chunk in order.
+//
+// All chunks are exported even if exporting one of them fails, unless ctx
+// is done. The first error returned from export is returned, annotated with
+// the number of failed requests if there was more than one request.
+func Export(ctx context.Context, rm *mpb.ResourceMetrics, maxSize int, sizer Sizer, export func(context.Context, *mpb.ResourceMetrics) error) error {
+	chunks := ResourceMetrics(rm, maxSize, sizer)
+	if len(chunks) == 1 {
+		return export(ctx, chunks[0])
+	}
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for i, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			// The remaining chunks are not exported.
+			failed += len(chunks) - i
+			if firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+		if err := export(ctx, chunk); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr == nil {
+		return nil
+	}
+	return fmt.Errorf("%d of %d export requests failed: %w", failed, len(chunks), firstErr)
+}
+
+// points provides access to the data points of a Metric regardless of its
+// data type.
+type points struct {
+	n      int
+	size   func(i int) int
+	subset func(lo, hi int) *mpb.Metric
+}
+
+func newPoints(m *mpb.Metric, sizer Sizer) points {
+	meta := func() *mpb.Metric {
+		return &mpb.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+	}
+	var p points
+	switch d := m.Data.(type) {
+	case *mpb.Metric_Gauge:
+		dps := d.Gauge.DataPoints
+		p.n, p.size = len(dps), func(i int) int { return sizer.Size(dps[i]) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			out := meta()
+			out.Data = &mpb.Metric_Gauge{Gauge: &mpb.Gauge{DataPoints: dps[lo:hi]}}
+			return out
+		}
+	case *mpb.Metric_Sum:
+		dps := d.Sum.DataPoints
+		p.n, p.size = len(dps), func(i int) int { return sizer.Size(dps[i]) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			out := meta()
+			out.Data = &mpb.Metric_Sum{Sum: &mpb.Sum{
+				DataPoints:             dps[lo:hi],
+				AggregationTemporality: d.Sum.AggregationTemporality,
+				IsMonotonic:            d.Sum.IsMonotonic,
+			}}
+			return out
+		}
+	case *mpb.Metric_Histogram:
+		dps := d.Histogram.DataPoints
+		p.n, p.size = len(dps), func(i int) int { return sizer.Size(dps[i]) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			out := meta()
+			out.Data = &mpb.Metric_Histogram{Histogram: &mpb.Histogram{
+				DataPoints:             dps[lo:hi],
+				AggregationTemporality: d.Histogram.AggregationTemporality,
+			}}
+			return out
+		}
+	case *mpb.Metric_ExponentialHistogram:
+		dps := d.ExponentialHistogram.DataPoints
+		p.n, p.size = len(dps), func(i int) int { return sizer.Size(dps[i]) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			out := meta()
+			out.Data = &mpb.Metric_ExponentialHistogram{ExponentialHistogram: &mpb.ExponentialHistogram{
+				DataPoints:             dps[lo:hi],
+				AggregationTemporality: d.ExponentialHistogram.AggregationTemporality,
+			}}
+			return out
+		}
+	case *mpb.Metric_Summary:
+		dps := d.Summary.DataPoints
+		p.n, p.size = len(dps), func(i int) int { return sizer.Size(dps[i]) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			out := meta()
+			out.Data = &mpb.Metric_Summary{Summary: &mpb.Summary{DataPoints: dps[lo:hi]}}
+			return out
+		}
+	default:
+		// Metrics without known data are kept whole.
+		p.n, p.size = 1, func(int) int { return sizer.Size(m) }
+		p.subset = func(lo, hi int) *mpb.Metric {
+			if lo == hi {
+				return meta()
+			}
+			return m
+		}
+	}
+	return p
+}
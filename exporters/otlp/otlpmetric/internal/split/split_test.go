@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package split
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	colmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+func kv(k, v string) *cpb.KeyValue {
+	return &cpb.KeyValue{Key: k, Value: &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: v}}}
+}
+
+func sumMetric(name string, n int) *mpb.Metric {
+	dps := make([]*mpb.NumberDataPoint, n)
+	for i := range dps {
+		dps[i] = &mpb.NumberDataPoint{
+			Attributes:   []*cpb.KeyValue{kv("key", "a fairly long attribute value to pad the point")},
+			TimeUnixNano: uint64(i),
+			Value:        &mpb.NumberDataPoint_AsInt{AsInt: int64(i)},
+		}
+	}
+	return &mpb.Metric{
+		Name: name,
+		Unit: "1",
+		Data: &mpb.Metric_Sum{Sum: &mpb.Sum{
+			DataPoints:             dps,
+			AggregationTemporality: mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}},
+	}
+}
+
+func histMetric(name string, n int) *mpb.Metric {
+	dps := make([]*mpb.HistogramDataPoint, n)
+	for i := range dps {
+		dps[i] = &mpb.HistogramDataPoint{
+			Attributes:     []*cpb.KeyValue{kv("key", "a fairly long attribute value to pad the point")},
+			Count:          uint64(i),
+			ExplicitBounds: []float64{1, 5, 10},
+			BucketCounts:   []uint64{0, 1, 2, 3},
+		}
+	}
+	return &mpb.Metric{
+		Name: name,
+		Data: &mpb.Metric_Histogram{Histogram: &mpb.Histogram{
+			DataPoints:             dps,
+			AggregationTemporality: mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+		}},
+	}
+}
+
+func testRM() *mpb.ResourceMetrics {
+	return &mpb.ResourceMetrics{
+		Resource:  &rpb.Resource{Attributes: []*cpb.KeyValue{kv("service.name", "test")}},
+		SchemaUrl: "https://opentelemetry.io/schemas/1.17.0",
+		ScopeMetrics: []*mpb.ScopeMetrics{
+			{
+				Scope:   &cpb.InstrumentationScope{Name: "a"},
+				Metrics: []*mpb.Metric{sumMetric("a.sum", 20), histMetric("a.hist", 10)},
+			},
+			{
+				Scope:   &cpb.InstrumentationScope{Name: "b"},
+				Metrics: []*mpb.Metric{sumMetric("b.sum", 15)},
+			},
+		},
+	}
+}
+
+func TestResourceMetricsNoSplit(t *testing.T) {
+	rm := testRM()
+	assert.Equal(t, []*mpb.ResourceMetrics{rm}, ResourceMetrics(rm, 0, Protobuf))
+	assert.Equal(t, []*mpb.ResourceMetrics{rm}, ResourceMetrics(rm, 1<<20, Protobuf))
+	assert.Equal(t, []*mpb.ResourceMetrics{nil}, ResourceMetrics(nil, 10, Protobuf))
+}
+
+func TestResourceMetricsSplit(t *testing.T) {
+	rm := testRM()
+	const maxSize = 1000
+	chunks := ResourceMetrics(rm, maxSize, Protobuf)
+	require.Greater(t, len(chunks), 1)
+
+	type key struct{ scope, metric string }
+	got := make(map[key]int)
+	for _, chunk := range chunks {
+		req := &colmpb.ExportMetricsServiceRequest{ResourceMetrics: []*mpb.ResourceMetrics{chunk}}
+		assert.LessOrEqual(t, proto.Size(req), maxSize)
+		assert.True(t, proto.Equal(rm.Resource, chunk.Resource))
+		assert.Equal(t, rm.SchemaUrl, chunk.SchemaUrl)
+		for _, sm := range chunk.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				assert.Equal(t, sm.Scope.Name, m.Name[:1], "metric moved to another scope")
+				switch d := m.Data.(type) {
+				case *mpb.Metric_Sum:
+					assert.True(t, d.Sum.IsMonotonic)
+					assert.Equal(t, mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, d.Sum.AggregationTemporality)
+					got[key{sm.Scope.Name, m.Name}] += len(d.Sum.DataPoints)
+				case *mpb.Metric_Histogram:
+					assert.Equal(t, mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, d.Histogram.AggregationTemporality)
+					got[key{sm.Scope.Name, m.Name}] += len(d.Histogram.DataPoints)
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[key]int{
+		{"a", "a.sum"}:  20,
+		{"a", "a.hist"}: 10,
+		{"b", "b.sum"}:  15,
+	}, got)
+}
+
+func countPoints(chunks []*mpb.ResourceMetrics) int {
+	var n int
+	for _, chunk := range chunks {
+		for _, sm := range chunk.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				n += len(m.GetSum().GetDataPoints()) + len(m.GetHistogram().GetDataPoints())
+			}
+		}
+	}
+	return n
+}
+
+func TestResourceMetricsSplitJSON(t *testing.T) {
+	rm := testRM()
+	const maxSize = 2000
+	sizer := JSON(protojson.Marshal)
+	chunks := ResourceMetrics(rm, maxSize, sizer)
+
+	// The JSON encoding is larger than the protobuf one, measuring the
+	// protobuf encoding would produce fewer, oversized chunks.
+	require.Greater(t, len(chunks), len(ResourceMetrics(rm, maxSize, Protobuf)))
+	for _, chunk := range chunks {
+		b, err := protojson.Marshal(&colmpb.ExportMetricsServiceRequest{ResourceMetrics: []*mpb.ResourceMetrics{chunk}})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(b), maxSize)
+	}
+	assert.Equal(t, 45, countPoints(chunks))
+}
+
+func TestResourceMetricsOversizedPoint(t *testing.T) {
+	rm := &mpb.ResourceMetrics{ScopeMetrics: []*mpb.ScopeMetrics{{
+		Metrics: []*mpb.Metric{sumMetric("sum", 3)},
+	}}}
+	chunks := ResourceMetrics(rm, 10, Protobuf)
+	require.Len(t, chunks, 3, "each data point should be in its own chunk")
+	for _, chunk := range chunks {
+		require.Len(t, chunk.ScopeMetrics, 1)
+		require.Len(t, chunk.ScopeMetrics[0].Metrics, 1)
+		assert.Len(t, chunk.ScopeMetrics[0].Metrics[0].GetSum().DataPoints, 1)
+	}
+}
+
+func TestExport(t *testing.T) {
+	rm := &mpb.ResourceMetrics{ScopeMetrics: []*mpb.ScopeMetrics{{
+		Metrics: []*mpb.Metric{sumMetric("sum", 3)},
+	}}}
+	var calls int
+	err := Export(context.Background(), rm, 10, Protobuf, func(context.Context, *mpb.ResourceMetrics) error {
+		calls++
+		if calls == 2 {
+			return assert.AnError
+		}
+		return nil
+	})
+	assert.Equal(t, 3, calls, "all chunks should be exported")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.EqualError(t, err, "1 of 3 export requests failed: "+assert.AnError.Error())
+
+	// An unsplit request returns the export error unchanged.
+	err = Export(context.Background(), rm, 0, Protobuf, func(context.Context, *mpb.ResourceMetrics) error {
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/rotate"
+	ominternal "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// New returns an OpenTelemetry metric Exporter. The Exporter can be used with
+// a PeriodicReader to write OpenTelemetry metric data to a file in the OTLP
+// JSON file format.
+//
+// The file is opened when New is called and closed when the Exporter is shut
+// down.
+func New(_ context.Context, opts ...Option) (metric.Exporter, error) {
+	c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ominternal.New(c), nil
+}
+
+type client struct {
+	writer *rotate.Writer
+
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+// newClient creates a new file metric client.
+func newClient(opts ...Option) (ominternal.Client, error) {
+	cfg := newConfig(opts)
+	w, err := rotate.New(rotate.Config{
+		Path:       cfg.path,
+		MaxSize:    cfg.maxSize,
+		MaxBackups: cfg.maxBackups,
+		Compress:   cfg.compression == GzipCompression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		writer:              w,
+		temporalitySelector: cfg.temporalitySelector,
+		aggregationSelector: cfg.aggregationSelector,
+	}, nil
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (c *client) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return c.temporalitySelector(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (c *client) Aggregation(k metric.InstrumentKind) aggregation.Aggregation {
+	return c.aggregationSelector(k)
+}
+
+// UploadMetrics writes protoMetrics to the file as a single line.
+func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
+	// The otlpmetric.Exporter synchronizes access to client methods, and
+	// ensures this is not called after the Exporter is shutdown. Only thing
+	// to do here is write data.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// The OTLP/JSON mapping requires enum values be encoded as integers and
+	// trace and span IDs be hex encoded.
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(&colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
+	})
+	if err != nil {
+		return err
+	}
+	if b, err = internal.JSONIDsToHex(b); err != nil {
+		return err
+	}
+	return c.writer.WriteLine(b)
+}
+
+// ForceFlush commits the written metric data to stable storage.
+func (c *client) ForceFlush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.writer.Sync()
+}
+
+// Shutdown closes the file metric data is written to.
+func (c *client) Shutdown(ctx context.Context) error {
+	if err := c.writer.Close(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+)
+
+func readRequests(t *testing.T, path string) []*colmetricpb.ExportMetricsServiceRequest {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var reqs []*colmetricpb.ExportMetricsServiceRequest
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		b, err := internal.JSONIDsToBase64(scanner.Bytes())
+		require.NoError(t, err)
+		req := new(colmetricpb.ExportMetricsServiceRequest)
+		require.NoError(t, protojson.Unmarshal(b, req))
+		reqs = append(reqs, req)
+	}
+	require.NoError(t, scanner.Err())
+	return reqs
+}
+
+func TestExporterWritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(path))
+	require.NoError(t, err)
+
+	r := metric.NewPeriodicReader(exp)
+	mp := metric.NewMeterProvider(metric.WithReader(r))
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	ctr.Add(ctx, 3)
+	require.NoError(t, r.ForceFlush(ctx))
+	ctr.Add(ctx, 2)
+	require.NoError(t, mp.Shutdown(ctx))
+
+	reqs := readRequests(t, path)
+	require.Len(t, reqs, 2)
+	for i, want := range []int64{3, 5} {
+		require.Len(t, reqs[i].ResourceMetrics, 1)
+		sm := reqs[i].ResourceMetrics[0].ScopeMetrics
+		require.Len(t, sm, 1)
+		require.Len(t, sm[0].Metrics, 1)
+		m := sm[0].Metrics[0]
+		assert.Equal(t, "requests", m.Name)
+		dps := m.GetSum().DataPoints
+		require.Len(t, dps, 1)
+		assert.Equal(t, want, dps[0].GetAsInt())
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// Enums are encoded as integers.
+	assert.Contains(t, string(b), `"aggregationTemporality":2`)
+}
+
+func TestExporterRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "metrics.jsonl")
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(path), WithMaxSize(1), WithMaxBackups(2), WithCompression(GzipCompression))
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+
+	assert.Len(t, readRequests(t, path), 1)
+	backups, err := filepath.Glob(filepath.Join(dir, "metrics-*.jsonl.gz"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestExporterShutdown(t *testing.T) {
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(filepath.Join(t.TempDir(), "metrics.jsonl")))
+	require.NoError(t, err)
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.ErrorContains(t, exp.Export(ctx, metricdata.ResourceMetrics{}), "exporter is shutdown")
+}
+
+func TestNewError(t *testing.T) {
+	// A directory cannot be opened as the output file.
+	_, err := New(context.Background(), WithPath(t.TempDir()))
+	assert.Error(t, err)
+}
+
+func TestConfigSelectors(t *testing.T) {
+	cfg := newConfig(nil)
+	assert.Equal(t, DefaultPath, cfg.path)
+	assert.Equal(t, metricdata.CumulativeTemporality, cfg.temporalitySelector(metric.InstrumentKindCounter))
+
+	cfg = newConfig([]Option{
+		WithPath(""),
+		WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}),
+	})
+	assert.Equal(t, DefaultPath, cfg.path, "empty path should be ignored")
+	assert.Equal(t, metricdata.DeltaTemporality, cfg.temporalitySelector(metric.InstrumentKindCounter))
+}
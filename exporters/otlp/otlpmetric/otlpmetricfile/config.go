@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
+
+import (
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// DefaultPath is the default path of the file metric data is written to.
+const DefaultPath = "metrics.jsonl"
+
+// Compression describes the compression used for rotated files.
+type Compression int
+
+const (
+	// NoCompression tells the Exporter to leave rotated files uncompressed.
+	NoCompression Compression = iota
+	// GzipCompression tells the Exporter to compress rotated files with gzip.
+	GzipCompression
+)
+
+type config struct {
+	path        string
+	maxSize     int64
+	maxBackups  int
+	compression Compression
+
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		path:                DefaultPath,
+		temporalitySelector: metric.DefaultTemporalitySelector,
+		aggregationSelector: metric.DefaultAggregationSelector,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option applies an option to the Exporter.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithPath sets the path of the file metric data is written to. Any missing
+// parent directories are created.
+//
+// By default, if this option is not passed, DefaultPath will be used.
+func WithPath(path string) Option {
+	return optionFunc(func(cfg config) config {
+		if path != "" {
+			cfg.path = path
+		}
+		return cfg
+	})
+}
+
+// WithMaxSize sets the size in bytes the file can grow to before it is
+// rotated. When rotated, the file is renamed to include the time of rotation
+// and a new file is created at the configured path.
+//
+// By default, if this option is not passed, or a size less than or equal to
+// zero is passed, the file is never rotated.
+func WithMaxSize(size int64) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxSize = size
+		return cfg
+	})
+}
+
+// WithMaxBackups sets the maximum number of rotated files to retain. The
+// oldest rotated files are removed first.
+//
+// By default, if this option is not passed, or a value less than or equal to
+// zero is passed, all rotated files are retained.
+func WithMaxBackups(n int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxBackups = n
+		return cfg
+	})
+}
+
+// WithCompression sets the compression used for rotated files. The file
+// currently being written to is never compressed so it can be tailed.
+//
+// By default, if this option is not passed, rotated files are not
+// compressed.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.compression = compression
+		return cfg
+	})
+}
+
+// WithTemporalitySelector sets the TemporalitySelector the client will use to
+// determine the Temporality of an instrument based on its kind. If this option
+// is not used, the client will use the DefaultTemporalitySelector from the
+// go.opentelemetry.io/otel/sdk/metric package.
+func WithTemporalitySelector(selector metric.TemporalitySelector) Option {
+	return optionFunc(func(cfg config) config {
+		if selector != nil {
+			cfg.temporalitySelector = selector
+		}
+		return cfg
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the client will use to
+// determine the aggregation to use for an instrument based on its kind. If
+// this option is not used, the reader will use the DefaultAggregationSelector
+// from the go.opentelemetry.io/otel/sdk/metric package, or the aggregation
+// explicitly passed for a view matching an instrument.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return optionFunc(func(cfg config) config {
+		if selector != nil {
+			cfg.aggregationSelector = selector
+		}
+		return cfg
+	})
+}
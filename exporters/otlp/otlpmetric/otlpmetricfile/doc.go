@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpmetricfile provides an otlpmetric.Exporter that writes metric
+// data to a file in the OTLP JSON file format.
+//
+// Each export is written as a single ExportMetricsServiceRequest encoded with
+// the OTLP/JSON mapping followed by a newline (JSON Lines). Files written by
+// this exporter can be replayed by an OpenTelemetry Collector using its OTLP
+// JSON file receiver.
+package otlpmetricfile // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricfile"
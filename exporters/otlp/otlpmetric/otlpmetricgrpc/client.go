@@ -16,9 +16,9 @@ package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpme
 
 import (
 	"context"
+	"fmt"
 	"time"
 
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -26,9 +26,12 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	ominternal "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/split"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/exporters/retry/retrygrpc"
+	"go.opentelemetry.io/otel/internal/observ"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -53,9 +56,12 @@ func New(ctx context.Context, options ...Option) (metric.Exporter, error) {
 }
 
 type client struct {
-	metadata      metadata.MD
-	exportTimeout time.Duration
-	requestFunc   retry.RequestFunc
+	metadata       metadata.MD
+	headerProvider func(context.Context) (map[string]string, error)
+	exportTimeout  time.Duration
+	maxReqSize     int
+	requestFunc    retry.RequestFunc
+	observ         observ.Exporter
 
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
@@ -74,9 +80,12 @@ func newClient(ctx context.Context, options ...Option) (ominternal.Client, error
 	cfg := oconf.NewGRPCConfig(asGRPCOptions(options)...)
 
 	c := &client{
-		exportTimeout: cfg.Metrics.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
-		conn:          cfg.GRPCConn,
+		headerProvider: cfg.Metrics.HeaderProvider,
+		exportTimeout:  cfg.Metrics.Timeout,
+		maxReqSize:     cfg.Metrics.MaxRequestSize,
+		requestFunc:    cfg.RetryPolicy.RequestFunc(retrygrpc.Evaluate(cfg.RetryPolicy)),
+		observ:         observ.GetProvider().Exporter("go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc", "data_points"),
+		conn:           cfg.GRPCConn,
 
 		temporalitySelector: cfg.Metrics.TemporalitySelector,
 		aggregationSelector: cfg.Metrics.AggregationSelector,
@@ -144,10 +153,12 @@ func (c *client) Shutdown(ctx context.Context) error {
 	return err
 }
 
-// UploadMetrics sends protoMetrics to connected endpoint.
+// UploadMetrics sends protoMetrics to connected endpoint. If protoMetrics is
+// larger than the configured maximum request size it is split and sent using
+// multiple requests.
 //
 // Retryable errors from the server will be handled according to any
-// RetryConfig the client was created with.
+// retry policy the client was created with.
 func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	// The otlpmetric.Exporter synchronizes access to client methods, and
 	// ensures this is not called after the Exporter is shutdown. Only thing
@@ -160,10 +171,25 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 	default:
 	}
 
+	return split.Export(ctx, protoMetrics, c.maxReqSize, split.Protobuf, c.export)
+}
+
+// export sends protoMetrics in a single request.
+func (c *client) export(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	var attempt int
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		if attempt > 0 {
+			c.observ.Retry(iCtx)
+		}
+		attempt++
+
+		iCtx, err := c.withProvidedHeaders(iCtx)
+		if err != nil {
+			return err
+		}
 		resp, err := c.msc.Export(iCtx, &colmetricpb.ExportMetricsServiceRequest{
 			ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 		})
@@ -171,6 +197,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedDataPoints()
 			if n != 0 || msg != "" {
+				c.observ.Rejected(iCtx, n)
 				err := internal.MetricPartialSuccessError(n, msg)
 				otel.Handle(err)
 			}
@@ -182,6 +209,8 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 		return err
 	})
+	c.observ.Request(ctx, err)
+	return err
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -209,32 +238,23 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 	return ctx, cancel
 }
 
-// retryable returns if err identifies a request that can be retried and a
-// duration to wait for if an explicit throttle time is included in err.
-func retryable(err error) (bool, time.Duration) {
-	s := status.Convert(err)
-	switch s.Code() {
-	case codes.Canceled,
-		codes.DeadlineExceeded,
-		codes.ResourceExhausted,
-		codes.Aborted,
-		codes.OutOfRange,
-		codes.Unavailable,
-		codes.DataLoss:
-		return true, throttleDelay(s)
+// withProvidedHeaders returns a copy of ctx with the headers returned by the
+// client's header provider, if any, added to its outgoing metadata.
+func (c *client) withProvidedHeaders(ctx context.Context) (context.Context, error) {
+	if c.headerProvider == nil {
+		return ctx, nil
 	}
-
-	// Not a retry-able error.
-	return false, 0
-}
-
-// throttleDelay returns a duration to wait for if an explicit throttle time
-// is included in the response status.
-func throttleDelay(s *status.Status) time.Duration {
-	for _, detail := range s.Details() {
-		if t, ok := detail.(*errdetails.RetryInfo); ok {
-			return t.RetryDelay.AsDuration()
-		}
+	h, err := c.headerProvider(ctx)
+	if err != nil {
+		return ctx, fmt.Errorf("header provider: %w", err)
+	}
+	if len(h) == 0 {
+		return ctx, nil
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	for k, v := range h {
+		md.Set(k, v)
 	}
-	return 0
+	return metadata.NewOutgoingContext(ctx, md), nil
 }
@@ -21,114 +21,20 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/durationpb"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	ominternal "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otest"
+	"go.opentelemetry.io/otel/internal/observ/observtest"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	collpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 )
 
-func TestThrottleDuration(t *testing.T) {
-	c := codes.ResourceExhausted
-	testcases := []struct {
-		status   *status.Status
-		expected time.Duration
-	}{
-		{
-			status:   status.New(c, "NoRetryInfo"),
-			expected: 0,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "SingleRetryInfo").WithDetails(
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(15 * time.Millisecond),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 15 * time.Millisecond,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "ErrorInfo").WithDetails(
-					&errdetails.ErrorInfo{Reason: "no throttle detail"},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 0,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "ErrorAndRetryInfo").WithDetails(
-					&errdetails.ErrorInfo{Reason: "with throttle detail"},
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(13 * time.Minute),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 13 * time.Minute,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "DoubleRetryInfo").WithDetails(
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(13 * time.Minute),
-					},
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(15 * time.Minute),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 13 * time.Minute,
-		},
-	}
-
-	for _, tc := range testcases {
-		t.Run(tc.status.Message(), func(t *testing.T) {
-			require.Equal(t, tc.expected, throttleDelay(tc.status))
-		})
-	}
-}
-
-func TestRetryable(t *testing.T) {
-	retryableCodes := map[codes.Code]bool{
-		codes.OK:                 false,
-		codes.Canceled:           true,
-		codes.Unknown:            false,
-		codes.InvalidArgument:    false,
-		codes.DeadlineExceeded:   true,
-		codes.NotFound:           false,
-		codes.AlreadyExists:      false,
-		codes.PermissionDenied:   false,
-		codes.ResourceExhausted:  true,
-		codes.FailedPrecondition: false,
-		codes.Aborted:            true,
-		codes.OutOfRange:         true,
-		codes.Unimplemented:      false,
-		codes.Internal:           false,
-		codes.Unavailable:        true,
-		codes.DataLoss:           true,
-		codes.Unauthenticated:    false,
-	}
-
-	for c, want := range retryableCodes {
-		got, _ := retryable(status.Error(c, ""))
-		assert.Equalf(t, want, got, "evaluate(%s)", c)
-	}
-}
-
 func TestClient(t *testing.T) {
 	factory := func(rCh <-chan otest.ExportResult) (ominternal.Client, otest.Collector) {
 		coll, err := otest.NewGRPCCollector("", rCh)
@@ -175,6 +81,73 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 
+	t.Run("WithHeaderProvider", func(t *testing.T) {
+		key := "my-custom-header"
+		exp, coll := factoryFunc(nil, WithHeaders(map[string]string{key: "static"}), WithHeaderProvider(
+			func(context.Context) (map[string]string, error) {
+				return map[string]string{key: "dynamic"}, nil
+			},
+		))
+		ctx := context.Background()
+		t.Cleanup(func() { coll.Shutdown() })
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		// Ensure everything is flushed.
+		require.NoError(t, exp.Shutdown(ctx))
+
+		got := coll.Headers()
+		require.Contains(t, got, key)
+		assert.Equal(t, []string{"dynamic"}, got[key])
+	})
+
+	t.Run("WithMaxRequestSize", func(t *testing.T) {
+		exp, coll := factoryFunc(nil, WithMaxRequestSize(500))
+		ctx := context.Background()
+		t.Cleanup(func() { coll.Shutdown() })
+
+		dps := make([]metricdata.DataPoint[int64], 20)
+		for i := range dps {
+			dps[i] = metricdata.DataPoint[int64]{
+				Attributes: attribute.NewSet(attribute.Int("index", i)),
+				Value:      int64(i),
+			}
+		}
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: "sum",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints:  dps,
+					},
+				}},
+			}},
+		}))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		got := coll.Collect().Dump()
+		require.Greater(t, len(got), 1, "request not split")
+		var n int
+		for _, rm := range got {
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+			n += len(rm.ScopeMetrics[0].Metrics[0].GetSum().DataPoints)
+		}
+		assert.Equal(t, len(dps), n)
+	})
+
+	t.Run("WithHeaderProviderError", func(t *testing.T) {
+		exp, coll := factoryFunc(nil, WithHeaderProvider(
+			func(context.Context) (map[string]string, error) {
+				return nil, assert.AnError
+			},
+		))
+		ctx := context.Background()
+		t.Cleanup(func() { coll.Shutdown() })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.ErrorIs(t, exp.Export(ctx, metricdata.ResourceMetrics{}), assert.AnError)
+		assert.Len(t, coll.Collect().Dump(), 0)
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan otest.ExportResult)
@@ -204,4 +177,35 @@ func TestConfig(t *testing.T) {
 		got := coll.Headers()
 		assert.Contains(t, got[key][0], customerUserAgent)
 	})
+
+	t.Run("Observ", func(t *testing.T) {
+		r := observtest.Install(t)
+		rCh := make(chan otest.ExportResult, 2)
+		rCh <- otest.ExportResult{Err: status.Error(codes.Unavailable, "unavailable")}
+		rCh <- otest.ExportResult{Response: &collpb.ExportMetricsServiceResponse{
+			PartialSuccess: &collpb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: 3,
+			},
+		}}
+		exp, coll := factoryFunc(
+			rCh,
+			WithRetry(RetryConfig{
+				Enabled:         true,
+				InitialInterval: time.Nanosecond,
+				MaxInterval:     time.Nanosecond,
+			}),
+		)
+		t.Cleanup(coll.Shutdown)
+		ctx := context.Background()
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		assert.Equal(t, [][2]string{{"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc", "data_points"}}, r.Exporters())
+		assert.Equal(t, observtest.Counts{
+			Requests: 1,
+			Retries:  1,
+			Rejected: 3,
+		}, r.Counts())
+	})
 }
@@ -15,6 +15,7 @@
 package otlpmetricgrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -22,11 +23,18 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"go.opentelemetry.io/otel/exporters/retry"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
+// HeaderProvider returns headers to send with an export request. It is
+// called with the context of the export for every attempt to send the
+// request, including retries, allowing short-lived credentials or values
+// taken from the context to be used. If an error is returned, the export is
+// aborted and the error is returned from the export.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
 // Option applies a configuration option to the Exporter.
 type Option interface {
 	applyGRPCOption(oconf.Config) oconf.Config
@@ -45,7 +53,31 @@ func asGRPCOptions(opts []Option) []oconf.GRPCOption {
 //
 // This configuration does not define any network retry strategy. That is
 // entirely handled by the gRPC ClientConn.
-type RetryConfig retry.Config
+type RetryConfig struct {
+	// Enabled indicates whether to not retry sending batches in case of
+	// export failure.
+	Enabled bool
+	// InitialInterval the time to wait after the first failure before
+	// retrying.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on backoff interval. Once this value is
+	// reached the delay between consecutive retries will always be
+	// `MaxInterval`.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request/batch.  Once this value is reached, the data
+	// is discarded.
+	MaxElapsedTime time.Duration
+}
+
+func (c RetryConfig) policy() retry.Policy {
+	return retry.Policy{
+		Enabled:         c.Enabled,
+		InitialInterval: c.InitialInterval,
+		MaxInterval:     c.MaxInterval,
+		MaxElapsedTime:  c.MaxElapsedTime,
+	}
+}
 
 type wrappedOption struct {
 	oconf.GRPCOption
@@ -100,8 +132,13 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) oconf.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return oconf.GzipCompression
+	case "zstd":
+		return oconf.ZstdCompression
+	case "snappy":
+		return oconf.SnappyCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -110,17 +147,21 @@ func compressorToCompression(compressor string) oconf.Compression {
 
 // WithCompressor sets the compressor the gRPC client uses.
 //
-// It is the responsibility of the caller to ensure that the compressor set
-// has been registered with google.golang.org/grpc/encoding (see
-// encoding.RegisterCompressor for more information). For example, to register
-// the gzip compressor import the package:
+// The supported compressors are "gzip", "zstd", and "snappy". Any other
+// compressor results in no compression being used.
 //
-//	import _ "google.golang.org/grpc/encoding/gzip"
+// The "zstd" and "snappy" compressors need to be registered with gRPC by
+// importing go.opentelemetry.io/otel/exporters/otlp/grpcencoding/zstd or
+// go.opentelemetry.io/otel/exporters/otlp/grpcencoding/snappy. If the
+// selected compressor is not registered when the client is created, an error
+// is passed to the global error handler and requests are sent uncompressed.
+// Payloads are compressed at the default level of the compressor, gRPC
+// compressors do not support compression levels.
 //
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -146,13 +187,24 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{oconf.WithHeaders(headers)}
 }
 
+// WithHeaderProvider sets a HeaderProvider that is called to get headers
+// added to the gRPC metadata of each export request. Headers returned by
+// the provider take precedence over the headers set with WithHeaders.
+//
+// By default, if this option is not passed, no dynamic headers will be set.
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return wrappedOption{oconf.WithHeaderProvider(provider)}
+}
+
 // WithTLSCredentials sets the gRPC connection to use creds.
 //
 // If the OTEL_EXPORTER_OTLP_CERTIFICATE or
 // OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE environment variable is set, and
 // this option is not passed, that variable value will be used. The value will
 // be parsed the filepath of the TLS certificate chain to use. If both are
-// set, OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE will take precedence.
+// set, OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE will take precedence. The
+// certificate files configured with environment variables are reloaded when
+// they change.
 //
 // By default, if an environment variable is not set, and this option is not
 // passed, no TLS credentials will be used.
@@ -203,6 +255,20 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the protobuf
+// encoded export request sent to the endpoint. A collection of metrics that encodes
+// larger than size is split into multiple requests. Resource and
+// instrumentation scope grouping is preserved in each request, and each
+// request is retried independently.
+//
+// A single data point larger than size cannot be split and is sent in a
+// request of its own.
+//
+// If unset or less than or equal to zero, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
 // WithTimeout sets the max amount of time an Exporter will attempt an export.
 //
 // This takes precedence over any retry settings defined by WithRetry. Once
@@ -235,7 +301,19 @@ func WithTimeout(duration time.Duration) Option {
 // 5 seconds after receiving a retryable error and increase exponentially
 // after each error for no more than a total time of 1 minute.
 func WithRetry(settings RetryConfig) Option {
-	return wrappedOption{oconf.WithRetry(retry.Config(settings))}
+	return wrappedOption{oconf.WithRetry(settings.policy())}
+}
+
+// WithRetryPolicy sets the retry policy for transient errors returned by the
+// target endpoint when exporting metric data. In addition to the settings of
+// WithRetry, the policy can limit the number of attempts, change how backoff
+// intervals are randomized, select which gRPC codes are retried, and stop
+// sending requests to an endpoint that keeps failing with a circuit breaker.
+//
+// This option and WithRetry both set the retry policy, the last one passed is
+// used.
+func WithRetryPolicy(p retry.Policy) Option {
+	return wrappedOption{oconf.WithRetry(p)}
 }
 
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
@@ -7,25 +7,31 @@ retract v0.32.2 // Contains unresolvable dependencies.
 require (
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/otel v1.11.2
-	go.opentelemetry.io/otel/exporters/otlp/internal/retry v1.11.2
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric v0.34.0
+	go.opentelemetry.io/otel/exporters/retry v1.11.2
 	go.opentelemetry.io/otel/metric v0.34.0
 	go.opentelemetry.io/otel/sdk/metric v0.34.0
 	go.opentelemetry.io/proto/otlp v0.19.0
-	google.golang.org/genproto v0.0.0-20211118181313-81c1377c94b1
 	google.golang.org/grpc v1.51.0
-	google.golang.org/protobuf v1.28.1
 )
 
 require (
-	github.com/cenkalti/backoff/v4 v4.2.0 // indirect
+	go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform v0.34.0 // indirect
+	google.golang.org/genproto v0.0.0-20211118181313-81c1377c94b1 // indirect
+	google.golang.org/protobuf v1.28.1 // indirect
+)
+
+require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.7.0 // indirect
+	github.com/klauspost/compress v1.15.15 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/internal/compress v1.11.2 // indirect
+	go.opentelemetry.io/otel/exporters/retry/retrygrpc v1.11.2
 	go.opentelemetry.io/otel/sdk v1.11.2 // indirect
 	go.opentelemetry.io/otel/trace v1.11.2 // indirect
 	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
@@ -46,4 +52,10 @@ replace go.opentelemetry.io/otel/metric => ../../../../metric
 
 replace go.opentelemetry.io/otel/trace => ../../../../trace
 
-replace go.opentelemetry.io/otel/exporters/otlp/internal/retry => ../../internal/retry
+replace go.opentelemetry.io/otel/exporters/retry => ../../../retry
+
+replace go.opentelemetry.io/otel/exporters/otlp/internal/compress => ../../internal/compress
+
+replace go.opentelemetry.io/otel/exporters/retry/retrygrpc => ../../../retry/retrygrpc
+
+replace go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform => ../../../internal/otlpmetric/transform
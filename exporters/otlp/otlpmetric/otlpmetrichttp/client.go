@@ -16,24 +16,25 @@ package otlpmetrichttp // import "go.opentelemetry.io/otel/exporters/otlp/otlpme
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
-	"strconv"
-	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
 	ominternal "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/split"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/internal/observ"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -55,24 +56,32 @@ func New(_ context.Context, opts ...Option) (metric.Exporter, error) {
 type client struct {
 	// req is cloned for every upload the client makes.
 	req         *http.Request
-	compression Compression
+	compressor  *compress.Compressor
+	marshaler   oconf.Marshaler
 	requestFunc retry.RequestFunc
+	retryPolicy retry.Policy
+	observ      observ.Exporter
 	httpClient  *http.Client
 
+	headerProvider func(context.Context) (map[string]string, error)
+	maxReqSize     int
+
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
 }
 
+var ourDialer = &net.Dialer{
+	Timeout:   30 * time.Second,
+	KeepAlive: 30 * time.Second,
+}
+
 // Keep it in sync with golang's DefaultTransport from net/http! We
 // have our own copy to avoid handling a situation where the
 // DefaultTransport is overwritten with some different implementation
 // of http.RoundTripper or it's modified by another package.
 var ourTransport = &http.Transport{
-	Proxy: http.ProxyFromEnvironment,
-	DialContext: (&net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-	}).DialContext,
+	Proxy:                 http.ProxyFromEnvironment,
+	DialContext:           ourDialer.DialContext,
 	ForceAttemptHTTP2:     true,
 	MaxIdleConns:          100,
 	IdleConnTimeout:       90 * time.Second,
@@ -91,6 +100,11 @@ func newClient(opts ...Option) (ominternal.Client, error) {
 	if cfg.Metrics.TLSCfg != nil {
 		transport := ourTransport.Clone()
 		transport.TLSClientConfig = cfg.Metrics.TLSCfg
+		if cfg.Metrics.TLSReloader != nil {
+			// Use the current certificates for each new connection. Proxied
+			// connections use TLSClientConfig.
+			transport.DialTLSContext = cfg.Metrics.TLSReloader.DialTLSContext(ourDialer)
+		}
 		httpClient.Transport = transport
 	}
 
@@ -115,14 +129,24 @@ func newClient(opts ...Option) (ominternal.Client, error) {
 			req.Header.Set(k, v)
 		}
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	if cfg.Metrics.Marshaler == oconf.MarshalJSON {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-protobuf")
+	}
 
 	return &client{
-		compression: Compression(cfg.Metrics.Compression),
+		compressor:  newCompressor(cfg.Metrics.Compression.Name(), cfg.Metrics.CompressionLevel),
+		marshaler:   cfg.Metrics.Marshaler,
 		req:         req,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
+		requestFunc: cfg.RetryPolicy.RequestFunc(retry.EvaluateHTTP),
+		retryPolicy: cfg.RetryPolicy,
+		observ:      observ.GetProvider().Exporter("go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp", "data_points"),
 		httpClient:  httpClient,
 
+		headerProvider: cfg.Metrics.HeaderProvider,
+		maxReqSize:     cfg.Metrics.MaxRequestSize,
+
 		temporalitySelector: cfg.Metrics.TemporalitySelector,
 		aggregationSelector: cfg.Metrics.AggregationSelector,
 	}, nil
@@ -152,19 +176,30 @@ func (c *client) Shutdown(ctx context.Context) error {
 	return ctx.Err()
 }
 
-// UploadMetrics sends protoMetrics to the connected endpoint.
+// UploadMetrics sends protoMetrics to the connected endpoint. If
+// protoMetrics is larger than the configured maximum request size it is split
+// and sent using multiple requests. The size of requests is measured in the
+// encoding they are sent with, before compression.
 //
 // Retryable errors from the server will be handled according to any
-// RetryConfig the client was created with.
+// retry policy the client was created with.
 func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	// The otlpmetric.Exporter synchronizes access to client methods, and
 	// ensures this is not called after the Exporter is shutdown. Only thing
 	// to do here is send data.
+	sizer := split.Protobuf
+	if c.marshaler == oconf.MarshalJSON {
+		sizer = split.JSON(c.marshal)
+	}
+	return split.Export(ctx, protoMetrics, c.maxReqSize, sizer, c.export)
+}
 
+// export sends protoMetrics in a single request.
+func (c *client) export(ctx context.Context, protoMetrics *metricpb.ResourceMetrics) error {
 	pbRequest := &colmetricpb.ExportMetricsServiceRequest{
 		ResourceMetrics: []*metricpb.ResourceMetrics{protoMetrics},
 	}
-	body, err := proto.Marshal(pbRequest)
+	body, err := c.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -173,7 +208,13 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		return err
 	}
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	var attempt int
+	err = c.requestFunc(ctx, func(iCtx context.Context) error {
+		if attempt > 0 {
+			c.observ.Retry(iCtx)
+		}
+		attempt++
+
 		select {
 		case <-iCtx.Done():
 			return iCtx.Err()
@@ -181,14 +222,17 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 
 		request.reset(iCtx)
+		if err := c.setProvidedHeaders(request.Request); err != nil {
+			return err
+		}
 		resp, err := c.httpClient.Do(request.Request)
 		if err != nil {
-			return err
+			return retry.TransportError(err)
 		}
 
 		var rErr error
-		switch resp.StatusCode {
-		case http.StatusOK:
+		switch {
+		case resp.StatusCode == http.StatusOK:
 			// Success, do not retry.
 
 			// Read the partial success message, if any.
@@ -199,7 +243,7 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 
 			if respData.Len() != 0 {
 				var respProto colmetricpb.ExportMetricsServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
+				if err := c.unmarshal(respData.Bytes(), &respProto); err != nil {
 					return err
 				}
 
@@ -207,24 +251,23 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 					msg := respProto.PartialSuccess.GetErrorMessage()
 					n := respProto.PartialSuccess.GetRejectedDataPoints()
 					if n != 0 || msg != "" {
+						c.observ.Rejected(iCtx, n)
 						err := internal.MetricPartialSuccessError(n, msg)
 						otel.Handle(err)
 					}
 				}
 			}
 			return nil
-		case http.StatusTooManyRequests,
-			http.StatusServiceUnavailable:
-			// Retry-able failure.
-			rErr = newResponseError(resp.Header)
+		default:
+			rErr = fmt.Errorf("failed to send metrics to %s: %s", request.URL, resp.Status)
+			rErr = c.retryPolicy.HTTPResponseError(resp, rErr)
 
-			// Going to retry, drain the body to reuse the connection.
+			// Drain the body to reuse the connection if the request is
+			// retried.
 			if _, err := io.Copy(io.Discard, resp.Body); err != nil {
 				_ = resp.Body.Close()
 				return err
 			}
-		default:
-			rErr = fmt.Errorf("failed to send metrics to %s: %s", request.URL, resp.Status)
 		}
 
 		if err := resp.Body.Close(); err != nil {
@@ -232,45 +275,82 @@ func (c *client) UploadMetrics(ctx context.Context, protoMetrics *metricpb.Resou
 		}
 		return rErr
 	})
+	c.observ.Request(ctx, err)
+	return err
+}
+
+// setProvidedHeaders sets the headers returned by the client's header
+// provider, if any, on r.
+func (c *client) setProvidedHeaders(r *http.Request) error {
+	if c.headerProvider == nil {
+		return nil
+	}
+	h, err := c.headerProvider(r.Context())
+	if err != nil {
+		return fmt.Errorf("header provider: %w", err)
+	}
+	for k, v := range h {
+		r.Header.Set(k, v)
+	}
+	return nil
+}
+
+// marshal encodes m using the protocol the client is configured with.
+func (c *client) marshal(m proto.Message) ([]byte, error) {
+	if c.marshaler != oconf.MarshalJSON {
+		return proto.Marshal(m)
+	}
+	// The OTLP/JSON mapping requires enum values be encoded as integers and
+	// trace and span IDs be hex encoded.
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return internal.JSONIDsToHex(b)
+}
+
+// unmarshal decodes b into m using the protocol the client is configured
+// with.
+func (c *client) unmarshal(b []byte, m proto.Message) error {
+	if c.marshaler != oconf.MarshalJSON {
+		return proto.Unmarshal(b, m)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
 }
 
-var gzPool = sync.Pool{
-	New: func() interface{} {
-		w := gzip.NewWriter(io.Discard)
-		return w
-	},
+// newCompressor returns a Compressor for the encoding name at level, or nil
+// if name is empty. If level is invalid for the encoding, the default level is
+// used.
+func newCompressor(name string, level int) *compress.Compressor {
+	if name == "" {
+		return nil
+	}
+	c, err := compress.New(name, level)
+	if err != nil {
+		otel.Handle(fmt.Errorf("%w, using the default compression level", err))
+		c, _ = compress.New(name, compress.DefaultLevel)
+	}
+	return c
 }
 
 func (c *client) newRequest(ctx context.Context, body []byte) (request, error) {
 	r := c.req.Clone(ctx)
 	req := request{Request: r}
-
-	switch c.compression {
-	case NoCompression:
+	if c.compressor == nil {
 		r.ContentLength = (int64)(len(body))
 		req.bodyReader = bodyReader(body)
-	case GzipCompression:
-		// Ensure the content length is not used.
-		r.ContentLength = -1
-		r.Header.Set("Content-Encoding", "gzip")
-
-		gz := gzPool.Get().(*gzip.Writer)
-		defer gzPool.Put(gz)
+		return req, nil
+	}
 
-		var b bytes.Buffer
-		gz.Reset(&b)
+	// Ensure the content length is not used.
+	r.ContentLength = -1
+	r.Header.Set("Content-Encoding", c.compressor.Name())
 
-		if _, err := gz.Write(body); err != nil {
-			return req, err
-		}
-		// Close needs to be called to ensure body if fully written.
-		if err := gz.Close(); err != nil {
-			return req, err
-		}
-
-		req.bodyReader = bodyReader(b.Bytes())
+	var b bytes.Buffer
+	if err := c.compressor.Compress(&b, body); err != nil {
+		return req, err
 	}
-
+	req.bodyReader = bodyReader(b.Bytes())
 	return req, nil
 }
 
@@ -294,39 +374,3 @@ func (r *request) reset(ctx context.Context) {
 	r.Body = r.bodyReader()
 	r.Request = r.Request.WithContext(ctx)
 }
-
-// retryableError represents a request failure that can be retried.
-type retryableError struct {
-	throttle int64
-}
-
-// newResponseError returns a retryableError and will extract any explicit
-// throttle delay contained in headers.
-func newResponseError(header http.Header) error {
-	var rErr retryableError
-	if v := header.Get("Retry-After"); v != "" {
-		if t, err := strconv.ParseInt(v, 10, 64); err == nil {
-			rErr.throttle = t
-		}
-	}
-	return rErr
-}
-
-func (e retryableError) Error() string {
-	return "retry-able request failure"
-}
-
-// evaluate returns if err is retry-able. If it is and it includes an explicit
-// throttling delay, that delay is also returned.
-func evaluate(err error) (bool, time.Duration) {
-	if err == nil {
-		return false, 0
-	}
-
-	rErr, ok := err.(retryableError)
-	if !ok {
-		return false, 0
-	}
-
-	return true, time.Duration(rErr.throttle)
-}
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel/attribute"
 	ominternal "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/otest"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -34,17 +35,20 @@ import (
 )
 
 func TestClient(t *testing.T) {
-	factory := func(rCh <-chan otest.ExportResult) (ominternal.Client, otest.Collector) {
-		coll, err := otest.NewHTTPCollector("", rCh)
-		require.NoError(t, err)
+	factory := func(opts ...Option) func(<-chan otest.ExportResult) (ominternal.Client, otest.Collector) {
+		return func(rCh <-chan otest.ExportResult) (ominternal.Client, otest.Collector) {
+			coll, err := otest.NewHTTPCollector("", rCh)
+			require.NoError(t, err)
 
-		addr := coll.Addr().String()
-		client, err := newClient(WithEndpoint(addr), WithInsecure())
-		require.NoError(t, err)
-		return client, coll
+			addr := coll.Addr().String()
+			client, err := newClient(append([]Option{WithEndpoint(addr), WithInsecure()}, opts...)...)
+			require.NoError(t, err)
+			return client, coll
+		}
 	}
 
-	t.Run("Integration", otest.RunClientTests(factory))
+	t.Run("Integration", otest.RunClientTests(factory()))
+	t.Run("IntegrationJSON", otest.RunClientTests(factory(WithProtocol(JSONProtocol))))
 }
 
 func TestConfig(t *testing.T) {
@@ -80,6 +84,73 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, got[key], []string{headers[key]})
 	})
 
+	t.Run("WithHeaderProvider", func(t *testing.T) {
+		key := "my-custom-header"
+		exp, coll := factoryFunc("", nil, WithHeaders(map[string]string{key: "static"}), WithHeaderProvider(
+			func(context.Context) (map[string]string, error) {
+				return map[string]string{key: "dynamic"}, nil
+			},
+		))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		// Ensure everything is flushed.
+		require.NoError(t, exp.Shutdown(ctx))
+
+		got := coll.Headers()
+		require.Contains(t, got, http.CanonicalHeaderKey(key))
+		assert.Equal(t, []string{"dynamic"}, got[http.CanonicalHeaderKey(key)])
+	})
+
+	t.Run("WithMaxRequestSize", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithMaxRequestSize(500))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+
+		dps := make([]metricdata.DataPoint[int64], 20)
+		for i := range dps {
+			dps[i] = metricdata.DataPoint[int64]{
+				Attributes: attribute.NewSet(attribute.Int("index", i)),
+				Value:      int64(i),
+			}
+		}
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: "sum",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.CumulativeTemporality,
+						DataPoints:  dps,
+					},
+				}},
+			}},
+		}))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		got := coll.Collect().Dump()
+		require.Greater(t, len(got), 1, "request not split")
+		var n int
+		for _, rm := range got {
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+			n += len(rm.ScopeMetrics[0].Metrics[0].GetSum().DataPoints)
+		}
+		assert.Equal(t, len(dps), n)
+	})
+
+	t.Run("WithHeaderProviderError", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithHeaderProvider(
+			func(context.Context) (map[string]string, error) {
+				return nil, assert.AnError
+			},
+		))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.ErrorIs(t, exp.Export(ctx, metricdata.ResourceMetrics{}), assert.AnError)
+		assert.Len(t, coll.Collect().Dump(), 0)
+	})
+
 	t.Run("WithTimeout", func(t *testing.T) {
 		// Do not send on rCh so the Collector never responds to the client.
 		rCh := make(chan otest.ExportResult)
@@ -107,6 +178,37 @@ func TestConfig(t *testing.T) {
 		assert.Len(t, coll.Collect().Dump(), 1)
 	})
 
+	t.Run("WithCompressionZstd", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(ZstdCompression), WithCompressionLevel(3))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+		assert.Equal(t, []string{"zstd"}, coll.Headers()["Content-Encoding"])
+	})
+
+	t.Run("WithCompressionSnappy", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithCompression(SnappyCompression))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+		assert.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		assert.Len(t, coll.Collect().Dump(), 1)
+		assert.Equal(t, []string{"snappy"}, coll.Headers()["Content-Encoding"])
+	})
+
+	t.Run("WithProtocolJSON", func(t *testing.T) {
+		exp, coll := factoryFunc("", nil, WithProtocol(JSONProtocol))
+		ctx := context.Background()
+		t.Cleanup(func() { require.NoError(t, coll.Shutdown(ctx)) })
+		require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+		require.NoError(t, exp.Shutdown(ctx))
+
+		assert.Len(t, coll.Collect().Dump(), 1)
+		assert.Equal(t, []string{"application/json"}, coll.Headers()["Content-Type"])
+	})
+
 	t.Run("WithRetry", func(t *testing.T) {
 		emptyErr := errors.New("")
 		rCh := make(chan otest.ExportResult, 3)
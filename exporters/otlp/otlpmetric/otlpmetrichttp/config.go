@@ -15,11 +15,12 @@
 package otlpmetrichttp // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 
 import (
+	"context"
 	"crypto/tls"
 	"time"
 
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/internal/oconf"
+	"go.opentelemetry.io/otel/exporters/retry"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -34,8 +35,33 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(oconf.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(oconf.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy, using the framed format.
+	SnappyCompression = Compression(oconf.SnappyCompression)
 )
 
+// Protocol describes the encoding of the payloads sent to the collector.
+type Protocol oconf.Marshaler
+
+const (
+	// ProtobufProtocol tells the driver to send payloads encoded using the
+	// protobuf binary format ("http/protobuf").
+	ProtobufProtocol = Protocol(oconf.MarshalProto)
+	// JSONProtocol tells the driver to send payloads encoded using the
+	// OTLP/JSON format ("http/json").
+	JSONProtocol = Protocol(oconf.MarshalJSON)
+)
+
+// HeaderProvider returns headers to send with an export request. It is
+// called with the context of the export for every attempt to send the
+// request, including retries, allowing short-lived credentials or values
+// taken from the context to be used. If an error is returned, the export is
+// aborted and the error is returned from the export.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
 // Option applies an option to the Exporter.
 type Option interface {
 	applyHTTPOption(oconf.Config) oconf.Config
@@ -51,7 +77,31 @@ func asHTTPOptions(opts []Option) []oconf.HTTPOption {
 
 // RetryConfig defines configuration for retrying the export of metric data
 // that failed.
-type RetryConfig retry.Config
+type RetryConfig struct {
+	// Enabled indicates whether to not retry sending batches in case of
+	// export failure.
+	Enabled bool
+	// InitialInterval the time to wait after the first failure before
+	// retrying.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on backoff interval. Once this value is
+	// reached the delay between consecutive retries will always be
+	// `MaxInterval`.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request/batch.  Once this value is reached, the data
+	// is discarded.
+	MaxElapsedTime time.Duration
+}
+
+func (c RetryConfig) policy() retry.Policy {
+	return retry.Policy{
+		Enabled:         c.Enabled,
+		InitialInterval: c.InitialInterval,
+		MaxInterval:     c.MaxInterval,
+		MaxElapsedTime:  c.MaxElapsedTime,
+	}
+}
 
 type wrappedOption struct {
 	oconf.HTTPOption
@@ -82,7 +132,7 @@ func WithEndpoint(endpoint string) Option {
 // If the OTEL_EXPORTER_OTLP_COMPRESSION or
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION environment variable is set, and
 // this option is not passed, that variable value will be used. That value can
-// be either "none" or "gzip". If both are set,
+// be "none", "gzip", "zstd", or "snappy". If both are set,
 // OTEL_EXPORTER_OTLP_METRICS_COMPRESSION will take precedence.
 //
 // By default, if an environment variable is not set, and this option is not
@@ -91,6 +141,41 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{oconf.WithCompression(oconf.Compression(compression))}
 }
 
+// WithCompressionLevel sets the level the compression set with
+// WithCompression compresses payloads at. The meaning of level depends on the
+// compression:
+//
+//   - GzipCompression: 1 (best speed) through 9 (best compression), or -2 for
+//     Huffman only compression.
+//   - ZstdCompression: 1 (fastest) through 22 (best compression). Levels are
+//     mapped to the closest level the encoder supports.
+//   - SnappyCompression: ignored, snappy has no compression levels.
+//
+// If level is invalid for the compression, the default level is used and an
+// error is sent to the global ErrorHandler.
+//
+// By default, if this option is not passed, or level is zero, the default
+// level of the compression is used.
+//
+// The gRPC exporters have no equivalent option, they always compress at the
+// default level.
+func WithCompressionLevel(level int) Option {
+	return wrappedOption{oconf.WithCompressionLevel(level)}
+}
+
+// WithProtocol sets the encoding the Exporter will use for the HTTP body.
+//
+// If the OTEL_EXPORTER_OTLP_PROTOCOL or OTEL_EXPORTER_OTLP_METRICS_PROTOCOL
+// environment variable is set to "http/protobuf" or "http/json", and this
+// option is not passed, that variable value will be used. If both are set,
+// OTEL_EXPORTER_OTLP_METRICS_PROTOCOL will take precedence.
+//
+// By default, if an environment variable is not set, and this option is not
+// passed, ProtobufProtocol will be used.
+func WithProtocol(protocol Protocol) Option {
+	return wrappedOption{oconf.WithMarshaler(oconf.Marshaler(protocol))}
+}
+
 // WithURLPath sets the URL path the Exporter will send requests to.
 //
 // If the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
@@ -111,7 +196,12 @@ func WithURLPath(urlPath string) Option {
 // OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE environment variable is set, and
 // this option is not passed, that variable value will be used. The value will
 // be parsed the filepath of the TLS certificate chain to use. If both are
-// set, OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE will take precedence.
+// set, OTEL_EXPORTER_OTLP_METRICS_CERTIFICATE will take precedence. The
+// certificate files configured with environment variables are reloaded when
+// they change.
+//
+// Client certificates that are rotated can be provided with the
+// GetClientCertificate field of tlsCfg.
 //
 // By default, if an environment variable is not set, and this option is not
 // passed, the system default configuration is used.
@@ -149,6 +239,31 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{oconf.WithHeaders(headers)}
 }
 
+// WithHeaderProvider sets a HeaderProvider that is called to get headers
+// added to the HTTP headers of each export request. Headers returned by
+// the provider take precedence over the headers set with WithHeaders.
+//
+// By default, if this option is not passed, no dynamic headers will be set.
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return wrappedOption{oconf.WithHeaderProvider(provider)}
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the body of export
+// requests sent to the endpoint before it is compressed. The size is
+// measured in the encoding set with WithProtocol. Measuring the size of the
+// JSONProtocol encoding requires each data point to be encoded an extra
+// time. A collection of metrics that encodes larger than size is split into
+// multiple requests. Resource and instrumentation scope grouping is
+// preserved in each request, and each request is retried independently.
+//
+// A single data point larger than size cannot be split and is sent in a
+// request of its own.
+//
+// If unset or less than or equal to zero, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{oconf.WithMaxRequestSize(size)}
+}
+
 // WithTimeout sets the max amount of time an Exporter will attempt an export.
 //
 // This takes precedence over any retry settings defined by WithRetry. Once
@@ -178,7 +293,19 @@ func WithTimeout(duration time.Duration) Option {
 // 5 seconds after receiving a retryable error and increase exponentially
 // after each error for no more than a total time of 1 minute.
 func WithRetry(rc RetryConfig) Option {
-	return wrappedOption{oconf.WithRetry(retry.Config(rc))}
+	return wrappedOption{oconf.WithRetry(rc.policy())}
+}
+
+// WithRetryPolicy sets the retry policy for transient errors returned by the
+// target endpoint when exporting metric data. In addition to the settings of
+// WithRetry, the policy can limit the number of attempts, change how backoff
+// intervals are randomized, select which HTTP status codes are retried, and stop
+// sending requests to an endpoint that keeps failing with a circuit breaker.
+//
+// This option and WithRetry both set the retry policy, the last one passed is
+// used.
+func WithRetryPolicy(p retry.Policy) Option {
+	return wrappedOption{oconf.WithRetry(p)}
 }
 
 // WithTemporalitySelector sets the TemporalitySelector the client will use to
@@ -13,6 +13,6 @@
 // limitations under the License.
 
 // Package otlpmetrichttp provides an otlpmetric.Exporter that communicates
-// with an OTLP receiving endpoint using protobuf encoded metric data over
-// HTTP.
+// with an OTLP receiving endpoint using protobuf or JSON encoded metric data
+// over HTTP.
 package otlpmetrichttp // import "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
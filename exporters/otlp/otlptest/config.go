@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// Response describes how a collector responds to an export request.
+//
+// The zero value is a successful response.
+type Response struct {
+	// HTTPStatus is the status code the HTTPCollector responds with. If
+	// zero, http.StatusOK is used. It is ignored by the GRPCCollector.
+	HTTPStatus int
+
+	// GRPCCode is the status code the GRPCCollector responds with. It is
+	// ignored by the HTTPCollector.
+	GRPCCode codes.Code
+
+	// RetryAfter is the delay the client is asked to wait before retrying
+	// the request. The HTTPCollector sends it as a Retry-After header in
+	// seconds and the GRPCCollector as RetryInfo status details. It is only
+	// sent with a failed response.
+	RetryAfter time.Duration
+
+	// Delay is how long the collector waits before responding. The
+	// collector stops waiting if the request is canceled.
+	Delay time.Duration
+
+	// PartialSuccess, if not nil, is sent with a successful response.
+	PartialSuccess *PartialSuccess
+}
+
+// PartialSuccess describes telemetry the collector reports as rejected in a
+// successful response.
+type PartialSuccess struct {
+	// Rejected is the number of spans or data points rejected.
+	Rejected int64
+	// ErrorMessage describes why the telemetry was rejected.
+	ErrorMessage string
+}
+
+func (r Response) failed(grpc bool) bool {
+	if grpc {
+		return r.GRPCCode != codes.OK
+	}
+	return r.HTTPStatus != 0 && (r.HTTPStatus < 200 || r.HTTPStatus > 299)
+}
+
+func (r Response) tracesResponse() *ctpb.ExportTraceServiceResponse {
+	resp := &ctpb.ExportTraceServiceResponse{}
+	if r.PartialSuccess != nil {
+		resp.PartialSuccess = &ctpb.ExportTracePartialSuccess{
+			RejectedSpans: r.PartialSuccess.Rejected,
+			ErrorMessage:  r.PartialSuccess.ErrorMessage,
+		}
+	}
+	return resp
+}
+
+func (r Response) metricsResponse() *cmpb.ExportMetricsServiceResponse {
+	resp := &cmpb.ExportMetricsServiceResponse{}
+	if r.PartialSuccess != nil {
+		resp.PartialSuccess = &cmpb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: r.PartialSuccess.Rejected,
+			ErrorMessage:       r.PartialSuccess.ErrorMessage,
+		}
+	}
+	return resp
+}
+
+// Option configures a collector.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(c config) config {
+	return fn(c)
+}
+
+type config struct {
+	endpoint  string
+	responses []Response
+}
+
+func newConfig(options []Option) config {
+	var c config
+	for _, o := range options {
+		c = o.apply(c)
+	}
+	if c.endpoint == "" {
+		c.endpoint = "localhost:0"
+	}
+	return c
+}
+
+// WithEndpoint sets the address, in the form "host:port", the collector
+// listens at. If this option is not provided, the collector listens on the
+// localhost interface at a port chosen by the operating system.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(c config) config {
+		c.endpoint = endpoint
+		return c
+	})
+}
+
+// WithResponses sets the responses the collector sends, in order, to the
+// export requests it receives. Once all responses have been sent, every
+// following request receives a successful response.
+//
+// Data from a request receiving a failed response is not stored.
+func WithResponses(responses ...Response) Option {
+	return optionFunc(func(c config) config {
+		c.responses = append(c.responses, responses...)
+		return c
+	})
+}
+
+// responder hands out the configured responses in order.
+type responder struct {
+	mu        sync.Mutex
+	responses []Response
+}
+
+func (r *responder) next() Response {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.responses) == 0 {
+		return Response{}
+	}
+	resp := r.responses[0]
+	r.responses = r.responses[1:]
+	return resp
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlptest provides in-process OTLP collectors that can be used to
+// test code exporting telemetry with the OpenTelemetry Protocol.
+//
+// The GRPCCollector and HTTPCollector receive both trace and metric export
+// requests and store the received data so it can be inspected with the
+// accessors of Storage. Both collectors can be configured with a sequence of
+// Responses to simulate failures of an OTLP endpoint: error status codes,
+// partial successes, requested retry delays, and slow responses.
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	// Register all the compressors the exporters support.
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	_ "go.opentelemetry.io/otel/exporters/otlp/grpcencoding/snappy"
+	_ "go.opentelemetry.io/otel/exporters/otlp/grpcencoding/zstd"
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+// GRPCCollector is an OTLP gRPC server that collects the traces and metrics
+// it receives.
+type GRPCCollector struct {
+	*Storage
+
+	responder *responder
+	listener  net.Listener
+	srv       *grpc.Server
+}
+
+// NewGRPCCollector returns a *GRPCCollector that is serving the OTLP trace
+// and metric services. It accepts requests compressed with any of the
+// compressions the OTLP exporters support.
+func NewGRPCCollector(options ...Option) (*GRPCCollector, error) {
+	cfg := newConfig(options)
+	c := &GRPCCollector{
+		Storage:   newStorage(),
+		responder: &responder{responses: cfg.responses},
+	}
+
+	var err error
+	c.listener, err = net.Listen("tcp", cfg.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c.srv = grpc.NewServer()
+	ctpb.RegisterTraceServiceServer(c.srv, &grpcTraceService{c: c})
+	cmpb.RegisterMetricsServiceServer(c.srv, &grpcMetricsService{c: c})
+	go func() { _ = c.srv.Serve(c.listener) }()
+
+	return c, nil
+}
+
+// Endpoint returns the address, in the form "host:port", c is listening at.
+func (c *GRPCCollector) Endpoint() string {
+	return c.listener.Addr().String()
+}
+
+// Shutdown stops the gRPC server closing all open connections and listeners
+// immediately.
+func (c *GRPCCollector) Shutdown() {
+	c.srv.Stop()
+}
+
+// respond records the request and returns the response configured for it.
+// The returned error is non-nil if the response is a failure, in which case
+// the request data must not be stored.
+func (c *GRPCCollector) respond(ctx context.Context) (Response, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c.addRequest(md)
+
+	resp := c.responder.next()
+	if err := wait(ctx, resp.Delay); err != nil {
+		return resp, status.FromContextError(err).Err()
+	}
+	if !resp.failed(true) {
+		return resp, nil
+	}
+
+	st := status.New(resp.GRPCCode, "otlptest: injected failure")
+	if resp.RetryAfter > 0 {
+		var err error
+		st, err = st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(resp.RetryAfter),
+		})
+		if err != nil {
+			return resp, status.Error(resp.GRPCCode, err.Error())
+		}
+	}
+	return resp, st.Err()
+}
+
+type grpcTraceService struct {
+	ctpb.UnimplementedTraceServiceServer
+
+	c *GRPCCollector
+}
+
+func (s *grpcTraceService) Export(ctx context.Context, req *ctpb.ExportTraceServiceRequest) (*ctpb.ExportTraceServiceResponse, error) {
+	resp, err := s.c.respond(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.c.addTraces(req)
+	return resp.tracesResponse(), nil
+}
+
+type grpcMetricsService struct {
+	cmpb.UnimplementedMetricsServiceServer
+
+	c *GRPCCollector
+}
+
+func (s *grpcMetricsService) Export(ctx context.Context, req *cmpb.ExportMetricsServiceRequest) (*cmpb.ExportMetricsServiceResponse, error) {
+	resp, err := s.c.respond(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.c.addMetrics(req)
+	return resp.metricsResponse(), nil
+}
+
+// wait blocks for d or until ctx is done, in which case the context error is
+// returned.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+func dialGRPC(t *testing.T, c *GRPCCollector) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.Dial(c.Endpoint(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	return conn
+}
+
+func TestGRPCCollector(t *testing.T) {
+	c, err := NewGRPCCollector()
+	require.NoError(t, err)
+	defer c.Shutdown()
+	conn := dialGRPC(t, c)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "key", "value")
+	_, err = ctpb.NewTraceServiceClient(conn).Export(ctx, tracesRequest(1, 2))
+	require.NoError(t, err)
+	_, err = cmpb.NewMetricsServiceClient(conn).Export(ctx, metricsRequest())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, c.Requests())
+	assert.Equal(t, []string{"value", "value"}, c.Headers().Values("key"))
+	assert.Len(t, c.Spans(), 2)
+	assert.Len(t, c.NumberDataPoints("counter"), 1)
+}
+
+func TestGRPCCollectorResponses(t *testing.T) {
+	c, err := NewGRPCCollector(WithResponses(
+		Response{GRPCCode: codes.Unavailable, RetryAfter: time.Second},
+		Response{PartialSuccess: &PartialSuccess{Rejected: 1, ErrorMessage: "rejected"}},
+	))
+	require.NoError(t, err)
+	defer c.Shutdown()
+	client := ctpb.NewTraceServiceClient(dialGRPC(t, c))
+	ctx := context.Background()
+
+	_, err = client.Export(ctx, tracesRequest(1))
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, info.RetryDelay.AsDuration())
+	assert.Empty(t, c.Spans(), "failed request data stored")
+
+	resp, err := client.Export(ctx, tracesRequest(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PartialSuccess.RejectedSpans)
+	assert.Equal(t, "rejected", resp.PartialSuccess.ErrorMessage)
+
+	resp, err = client.Export(ctx, tracesRequest(1))
+	require.NoError(t, err)
+	assert.Nil(t, resp.PartialSuccess)
+
+	assert.Equal(t, 3, c.Requests())
+	assert.Len(t, c.Spans(), 2)
+}
+
+func TestGRPCCollectorDelay(t *testing.T) {
+	c, err := NewGRPCCollector(WithResponses(Response{Delay: time.Hour}))
+	require.NoError(t, err)
+	defer c.Shutdown()
+	client := cmpb.NewMetricsServiceClient(dialGRPC(t, c))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.Export(ctx, metricsRequest())
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Empty(t, c.ResourceMetrics())
+}
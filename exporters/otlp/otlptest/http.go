@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+const (
+	// TracesPath is the URL path the HTTPCollector receives traces at.
+	TracesPath = "/v1/traces"
+	// MetricsPath is the URL path the HTTPCollector receives metrics at.
+	MetricsPath = "/v1/metrics"
+)
+
+// HTTPCollector is an OTLP HTTP server that collects the traces and metrics
+// it receives. Requests encoded as protobuf or JSON, and compressed with any
+// of the compressions supported by the OTLP HTTP exporters, are accepted.
+type HTTPCollector struct {
+	*Storage
+
+	responder *responder
+	listener  net.Listener
+	srv       *http.Server
+}
+
+// NewHTTPCollector returns a *HTTPCollector that is serving OTLP traces at
+// TracesPath and metrics at MetricsPath.
+func NewHTTPCollector(options ...Option) (*HTTPCollector, error) {
+	cfg := newConfig(options)
+	c := &HTTPCollector{
+		Storage:   newStorage(),
+		responder: &responder{responses: cfg.responses},
+	}
+
+	var err error
+	c.listener, err = net.Listen("tcp", cfg.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(TracesPath, func(w http.ResponseWriter, r *http.Request) {
+		req := &ctpb.ExportTraceServiceRequest{}
+		resp, ok := c.handle(w, r, req)
+		if ok {
+			c.addTraces(req)
+			writeMessage(w, r, resp.tracesResponse())
+		}
+	})
+	mux.HandleFunc(MetricsPath, func(w http.ResponseWriter, r *http.Request) {
+		req := &cmpb.ExportMetricsServiceRequest{}
+		resp, ok := c.handle(w, r, req)
+		if ok {
+			c.addMetrics(req)
+			writeMessage(w, r, resp.metricsResponse())
+		}
+	})
+	c.srv = &http.Server{Handler: mux}
+	go func() { _ = c.srv.Serve(c.listener) }()
+
+	return c, nil
+}
+
+// Endpoint returns the address, in the form "host:port", c is listening at.
+func (c *HTTPCollector) Endpoint() string {
+	return c.listener.Addr().String()
+}
+
+// Shutdown shuts down the HTTP server closing all listeners and waiting for
+// active connections to become idle, or ctx to be done.
+func (c *HTTPCollector) Shutdown(ctx context.Context) error {
+	return c.srv.Shutdown(ctx)
+}
+
+// handle records the request and decodes its body into msg. It returns the
+// Response configured for the request and true if the request was
+// successful. Otherwise, the failure has already been written to w.
+func (c *HTTPCollector) handle(w http.ResponseWriter, r *http.Request, msg proto.Message) (Response, bool) {
+	c.addRequest(r.Header)
+
+	resp := c.responder.next()
+	if err := wait(r.Context(), resp.Delay); err != nil {
+		return resp, false
+	}
+	if resp.failed(false) {
+		if resp.RetryAfter > 0 {
+			// Round up so a fractional delay is not sent as zero.
+			secs := int64((resp.RetryAfter + 999_999_999) / 1_000_000_000)
+			w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+		}
+		http.Error(w, "otlptest: injected failure", resp.HTTPStatus)
+		return resp, false
+	}
+
+	if err := readMessage(r, msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return resp, false
+	}
+	return resp, true
+}
+
+func readMessage(r *http.Request, msg proto.Message) (err error) {
+	reader := r.Body
+	if enc := r.Header.Get("Content-Encoding"); enc != "" {
+		reader, err = compress.NewReader(enc, r.Body)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cErr := reader.Close(); err == nil {
+				err = cErr
+			}
+		}()
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	switch ct := r.Header.Get("Content-Type"); ct {
+	case "application/x-protobuf":
+		return proto.Unmarshal(body, msg)
+	case "application/json":
+		body, err = internal.JSONIDsToBase64(body)
+		if err != nil {
+			return err
+		}
+		return protojson.Unmarshal(body, msg)
+	default:
+		return fmt.Errorf("unsupported content-type: %q", ct)
+	}
+}
+
+func writeMessage(w http.ResponseWriter, r *http.Request, msg proto.Message) {
+	var (
+		body []byte
+		err  error
+	)
+	ct := r.Header.Get("Content-Type")
+	if ct == "application/json" {
+		body, err = protojson.Marshal(msg)
+	} else {
+		ct = "application/x-protobuf"
+		body, err = proto.Marshal(msg)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ct)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
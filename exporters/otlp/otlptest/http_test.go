@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+func post(t *testing.T, c *HTTPCollector, path, contentType, encoding string, msg proto.Message) *http.Response {
+	t.Helper()
+	var (
+		body []byte
+		err  error
+	)
+	if contentType == "application/json" {
+		body, err = protojson.Marshal(msg)
+		require.NoError(t, err)
+		// OTLP/JSON encodes trace and span IDs as hex strings.
+		body, err = internal.JSONIDsToHex(body)
+	} else {
+		body, err = proto.Marshal(msg)
+	}
+	require.NoError(t, err)
+	if encoding == compress.Gzip || encoding == compress.Zstd || encoding == compress.Snappy {
+		comp, err := compress.New(encoding, compress.DefaultLevel)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, comp.Compress(&buf, body))
+		body = buf.Bytes()
+	}
+
+	url := fmt.Sprintf("http://%s%s", c.Endpoint(), path)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Encoding", encoding)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, resp.Body.Close()) })
+	return resp
+}
+
+func newHTTPCollector(t *testing.T, options ...Option) *HTTPCollector {
+	t.Helper()
+	c, err := NewHTTPCollector(options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, c.Shutdown(context.Background())) })
+	return c
+}
+
+func TestHTTPCollector(t *testing.T) {
+	c := newHTTPCollector(t)
+
+	for _, ct := range []string{"application/x-protobuf", "application/json"} {
+		for _, enc := range []string{"", compress.Gzip, compress.Zstd, compress.Snappy} {
+			resp := post(t, c, TracesPath, ct, enc, tracesRequest(1))
+			assert.Equalf(t, http.StatusOK, resp.StatusCode, "%s %s", ct, enc)
+			assert.Equal(t, ct, resp.Header.Get("Content-Type"))
+		}
+	}
+	resp := post(t, c, MetricsPath, "application/x-protobuf", "", metricsRequest())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 9, c.Requests())
+	assert.Len(t, c.Spans(), 8)
+	assert.Len(t, c.NumberDataPoints("counter"), 1)
+	for _, s := range c.Spans() {
+		assert.Equal(t, traceID(1), s.TraceId)
+	}
+}
+
+func TestHTTPCollectorResponses(t *testing.T) {
+	c := newHTTPCollector(t, WithResponses(
+		Response{HTTPStatus: http.StatusServiceUnavailable, RetryAfter: 1500 * time.Millisecond},
+		Response{PartialSuccess: &PartialSuccess{Rejected: 2, ErrorMessage: "rejected"}},
+	))
+
+	resp := post(t, c, TracesPath, "application/x-protobuf", "", tracesRequest(1))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Empty(t, c.Spans(), "failed request data stored")
+
+	resp = post(t, c, TracesPath, "application/x-protobuf", "", tracesRequest(1))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var got ctpb.ExportTraceServiceResponse
+	require.NoError(t, proto.Unmarshal(body, &got))
+	assert.Equal(t, int64(2), got.PartialSuccess.RejectedSpans)
+	assert.Equal(t, "rejected", got.PartialSuccess.ErrorMessage)
+
+	assert.Equal(t, 2, c.Requests())
+	assert.Len(t, c.Spans(), 1)
+}
+
+func TestHTTPCollectorInvalidRequest(t *testing.T) {
+	c := newHTTPCollector(t)
+
+	resp := post(t, c, TracesPath, "text/plain", "", tracesRequest(1))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp = post(t, c, TracesPath, "application/x-protobuf", "br", tracesRequest(1))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, c.Spans())
+}
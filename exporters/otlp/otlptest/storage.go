@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"net/http"
+	"sync"
+
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tpb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Storage holds the telemetry and headers received by a collector. It is
+// safe for concurrent use.
+type Storage struct {
+	mu              sync.Mutex
+	requests        int
+	headers         http.Header
+	resourceSpans   []*tpb.ResourceSpans
+	resourceMetrics []*mpb.ResourceMetrics
+}
+
+func newStorage() *Storage {
+	return &Storage{headers: http.Header{}}
+}
+
+func (s *Storage) addRequest(h map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	for k, vals := range h {
+		for _, v := range vals {
+			s.headers.Add(k, v)
+		}
+	}
+}
+
+func (s *Storage) addTraces(req *ctpb.ExportTraceServiceRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resourceSpans = append(s.resourceSpans, req.ResourceSpans...)
+}
+
+func (s *Storage) addMetrics(req *cmpb.ExportMetricsServiceRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resourceMetrics = append(s.resourceMetrics, req.ResourceMetrics...)
+}
+
+// Requests returns the number of export requests received, including the
+// ones that received a failed response.
+func (s *Storage) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Headers returns the headers, or gRPC metadata, received with all export
+// requests.
+func (s *Storage) Headers() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers.Clone()
+}
+
+// ResourceSpans returns all ResourceSpans received.
+func (s *Storage) ResourceSpans() []*tpb.ResourceSpans {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*tpb.ResourceSpans(nil), s.resourceSpans...)
+}
+
+// Spans returns all spans received.
+func (s *Storage) Spans() []*tpb.Span {
+	var spans []*tpb.Span
+	for _, rs := range s.ResourceSpans() {
+		for _, ss := range rs.ScopeSpans {
+			spans = append(spans, ss.Spans...)
+		}
+	}
+	return spans
+}
+
+// ResourceMetrics returns all ResourceMetrics received.
+func (s *Storage) ResourceMetrics() []*mpb.ResourceMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*mpb.ResourceMetrics(nil), s.resourceMetrics...)
+}
+
+// Metrics returns all metrics received with the given name. If name is
+// empty, all metrics received are returned.
+func (s *Storage) Metrics(name string) []*mpb.Metric {
+	var metrics []*mpb.Metric
+	for _, rm := range s.ResourceMetrics() {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if name == "" || m.Name == name {
+					metrics = append(metrics, m)
+				}
+			}
+		}
+	}
+	return metrics
+}
+
+// NumberDataPoints returns the data points of all gauges and sums received
+// with the given name.
+func (s *Storage) NumberDataPoints(name string) []*mpb.NumberDataPoint {
+	var dps []*mpb.NumberDataPoint
+	for _, m := range s.Metrics(name) {
+		switch d := m.Data.(type) {
+		case *mpb.Metric_Gauge:
+			dps = append(dps, d.Gauge.DataPoints...)
+		case *mpb.Metric_Sum:
+			dps = append(dps, d.Sum.DataPoints...)
+		}
+	}
+	return dps
+}
+
+// HistogramDataPoints returns the data points of all histograms received
+// with the given name.
+func (s *Storage) HistogramDataPoints(name string) []*mpb.HistogramDataPoint {
+	var dps []*mpb.HistogramDataPoint
+	for _, m := range s.Metrics(name) {
+		if d, ok := m.Data.(*mpb.Metric_Histogram); ok {
+			dps = append(dps, d.Histogram.DataPoints...)
+		}
+	}
+	return dps
+}
+
+// ExponentialHistogramDataPoints returns the data points of all exponential
+// histograms received with the given name.
+func (s *Storage) ExponentialHistogramDataPoints(name string) []*mpb.ExponentialHistogramDataPoint {
+	var dps []*mpb.ExponentialHistogramDataPoint
+	for _, m := range s.Metrics(name) {
+		if d, ok := m.Data.(*mpb.Metric_ExponentialHistogram); ok {
+			dps = append(dps, d.ExponentialHistogram.DataPoints...)
+		}
+	}
+	return dps
+}
+
+// SummaryDataPoints returns the data points of all summaries received with
+// the given name.
+func (s *Storage) SummaryDataPoints(name string) []*mpb.SummaryDataPoint {
+	var dps []*mpb.SummaryDataPoint
+	for _, m := range s.Metrics(name) {
+		if d, ok := m.Data.(*mpb.Metric_Summary); ok {
+			dps = append(dps, d.Summary.DataPoints...)
+		}
+	}
+	return dps
+}
+
+// Reset removes all stored telemetry and headers, and resets the number of
+// requests received.
+func (s *Storage) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = 0
+	s.headers = http.Header{}
+	s.resourceSpans = nil
+	s.resourceMetrics = nil
+}
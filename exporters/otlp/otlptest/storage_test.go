@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	ctpb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tpb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func traceID(n byte) []byte {
+	return []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, n}
+}
+
+func tracesRequest(ids ...byte) *ctpb.ExportTraceServiceRequest {
+	ss := &tpb.ScopeSpans{}
+	for _, id := range ids {
+		ss.Spans = append(ss.Spans, &tpb.Span{
+			TraceId: traceID(id),
+			SpanId:  []byte{0, 0, 0, 0, 0, 0, 0, id},
+			Name:    "span",
+		})
+	}
+	return &ctpb.ExportTraceServiceRequest{
+		ResourceSpans: []*tpb.ResourceSpans{{ScopeSpans: []*tpb.ScopeSpans{ss}}},
+	}
+}
+
+func metricsRequest() *cmpb.ExportMetricsServiceRequest {
+	return &cmpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{{
+			ScopeMetrics: []*mpb.ScopeMetrics{{
+				Metrics: []*mpb.Metric{{
+					Name: "counter",
+					Data: &mpb.Metric_Sum{Sum: &mpb.Sum{
+						DataPoints: []*mpb.NumberDataPoint{{
+							Value: &mpb.NumberDataPoint_AsInt{AsInt: 1},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestStorageDataPoints(t *testing.T) {
+	s := newStorage()
+	s.addMetrics(&cmpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{{
+			ScopeMetrics: []*mpb.ScopeMetrics{{
+				Metrics: []*mpb.Metric{
+					{Name: "gauge", Data: &mpb.Metric_Gauge{Gauge: &mpb.Gauge{
+						DataPoints: []*mpb.NumberDataPoint{{}, {}},
+					}}},
+					{Name: "histogram", Data: &mpb.Metric_Histogram{Histogram: &mpb.Histogram{
+						DataPoints: []*mpb.HistogramDataPoint{{}},
+					}}},
+					{Name: "exponential", Data: &mpb.Metric_ExponentialHistogram{ExponentialHistogram: &mpb.ExponentialHistogram{
+						DataPoints: []*mpb.ExponentialHistogramDataPoint{{}},
+					}}},
+					{Name: "summary", Data: &mpb.Metric_Summary{Summary: &mpb.Summary{
+						DataPoints: []*mpb.SummaryDataPoint{{}},
+					}}},
+				},
+			}},
+		}},
+	})
+	s.addMetrics(metricsRequest())
+
+	assert.Len(t, s.Metrics(""), 5)
+	assert.Len(t, s.Metrics("gauge"), 1)
+	assert.Len(t, s.NumberDataPoints("gauge"), 2)
+	assert.Len(t, s.NumberDataPoints("counter"), 1)
+	assert.Len(t, s.NumberDataPoints("histogram"), 0)
+	assert.Len(t, s.HistogramDataPoints("histogram"), 1)
+	assert.Len(t, s.ExponentialHistogramDataPoints("exponential"), 1)
+	assert.Len(t, s.SummaryDataPoints("summary"), 1)
+}
+
+func TestStorageReset(t *testing.T) {
+	s := newStorage()
+	s.addRequest(map[string][]string{"key": {"value"}})
+	s.addTraces(tracesRequest(1))
+	s.addMetrics(metricsRequest())
+
+	s.Reset()
+	assert.Zero(t, s.Requests())
+	assert.Empty(t, s.Headers())
+	assert.Empty(t, s.ResourceSpans())
+	assert.Empty(t, s.ResourceMetrics())
+}
@@ -15,6 +15,7 @@
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"time"
@@ -23,10 +24,16 @@ import (
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/encoding"
+	// Register the gzip compressor with gRPC. The zstd and snappy
+	// compressors are registered by importing the packages in
+	// go.opentelemetry.io/otel/exporters/otlp/grpcencoding.
+	_ "google.golang.org/grpc/encoding/gzip"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tlsreload"
+	"go.opentelemetry.io/otel/exporters/retry"
 )
 
 const (
@@ -48,6 +55,24 @@ type (
 		Timeout     time.Duration
 		URLPath     string
 
+		// CompressionLevel is the level payloads are compressed at. Zero
+		// means the default level of the compression.
+		CompressionLevel int
+
+		// TLSReloader, if set, provides the TLS configuration used for
+		// each new HTTP connection. TLSCfg holds its initial configuration.
+		TLSReloader *tlsreload.Reloader
+
+		// HeaderProvider returns headers added to each export request.
+		HeaderProvider func(context.Context) (map[string]string, error)
+
+		// MaxRequestSize is the maximum size in bytes of an encoded export
+		// request. Larger requests are split. Zero means no limit.
+		MaxRequestSize int
+
+		// HTTP configurations
+		Marshaler Marshaler
+
 		// gRPC configurations
 		GRPCCredentials credentials.TransportCredentials
 	}
@@ -56,7 +81,7 @@ type (
 		// Signal specific configurations
 		Traces SignalConfig
 
-		RetryConfig retry.Config
+		RetryPolicy retry.Policy
 
 		// gRPC configurations
 		ReconnectionPeriod time.Duration
@@ -76,7 +101,7 @@ func NewHTTPConfig(opts ...HTTPOption) Config {
 			Compression: NoCompression,
 			Timeout:     DefaultTimeout,
 		},
-		RetryConfig: retry.DefaultConfig,
+		RetryPolicy: retry.DefaultPolicy,
 	}
 	cfg = ApplyHTTPEnvConfigs(cfg)
 	for _, opt := range opts {
@@ -96,7 +121,7 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 			Compression: NoCompression,
 			Timeout:     DefaultTimeout,
 		},
-		RetryConfig: retry.DefaultConfig,
+		RetryPolicy: retry.DefaultPolicy,
 		DialOptions: []grpc.DialOption{grpc.WithUserAgent(internal.GetUserAgentHeader())},
 	}
 	cfg = ApplyGRPCEnvConfigs(cfg)
@@ -118,8 +143,14 @@ func NewGRPCConfig(opts ...GRPCOption) Config {
 		cfg.Traces.GRPCCredentials = creds
 		cfg.DialOptions = append(cfg.DialOptions, grpc.WithTransportCredentials(creds))
 	}
-	if cfg.Traces.Compression == GzipCompression {
-		cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	if name := cfg.Traces.Compression.Name(); name != "" {
+		if encoding.GetCompressor(name) == nil {
+			// gRPC fails every request using a compressor that is not
+			// registered, send the requests uncompressed instead.
+			otel.Handle(fmt.Errorf("gRPC compressor %q is not registered, import go.opentelemetry.io/otel/exporters/otlp/grpcencoding/%s to register it; sending requests uncompressed", name, name))
+		} else {
+			cfg.DialOptions = append(cfg.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(name)))
+		}
 	}
 	if len(cfg.DialOptions) != 0 {
 		cfg.DialOptions = append(cfg.DialOptions, cfg.DialOptions...)
@@ -255,6 +286,20 @@ func WithCompression(compression Compression) GenericOption {
 	})
 }
 
+func WithCompressionLevel(level int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.CompressionLevel = level
+		return cfg
+	})
+}
+
+func WithMarshaler(m Marshaler) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.Marshaler = m
+		return cfg
+	})
+}
+
 func WithURLPath(urlPath string) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.URLPath = urlPath
@@ -262,9 +307,9 @@ func WithURLPath(urlPath string) GenericOption {
 	})
 }
 
-func WithRetry(rc retry.Config) GenericOption {
+func WithRetry(p retry.Policy) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
-		cfg.RetryConfig = rc
+		cfg.RetryPolicy = p
 		return cfg
 	})
 }
@@ -272,6 +317,7 @@ func WithRetry(rc retry.Config) GenericOption {
 func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	return newSplitOption(func(cfg Config) Config {
 		cfg.Traces.TLSCfg = tlsCfg.Clone()
+		cfg.Traces.TLSReloader = nil
 		return cfg
 	}, func(cfg Config) Config {
 		cfg.Traces.GRPCCredentials = credentials.NewTLS(tlsCfg)
@@ -279,6 +325,19 @@ func WithTLSClientConfig(tlsCfg *tls.Config) GenericOption {
 	})
 }
 
+// withTLSReloader configures the client to use the current TLS
+// configuration of r for each new connection.
+func withTLSReloader(r *tlsreload.Reloader) GenericOption {
+	return newSplitOption(func(cfg Config) Config {
+		cfg.Traces.TLSCfg = r.Config()
+		cfg.Traces.TLSReloader = r
+		return cfg
+	}, func(cfg Config) Config {
+		cfg.Traces.GRPCCredentials = &reloadingCredentials{reloader: r}
+		return cfg
+	})
+}
+
 func WithInsecure() GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Insecure = true
@@ -300,6 +359,20 @@ func WithHeaders(headers map[string]string) GenericOption {
 	})
 }
 
+func WithHeaderProvider(fn func(context.Context) (map[string]string, error)) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.HeaderProvider = fn
+		return cfg
+	})
+}
+
+func WithMaxRequestSize(size int) GenericOption {
+	return newGenericOption(func(cfg Config) Config {
+		cfg.Traces.MaxRequestSize = size
+		return cfg
+	})
+}
+
 func WithTimeout(duration time.Duration) GenericOption {
 	return newGenericOption(func(cfg Config) Config {
 		cfg.Traces.Timeout = duration
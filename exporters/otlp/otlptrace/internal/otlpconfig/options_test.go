@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/exporters/otlp/internal/envconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
@@ -262,6 +263,83 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+				"key_path":  []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if grpcOption {
+					require.NotNil(t, c.Traces.GRPCCredentials)
+					assert.Equal(t, "tls", c.Traces.GRPCCredentials.Info().SecurityProtocol)
+				} else {
+					require.NotNil(t, c.Traces.TLSReloader, "environment certificates are not reloaded")
+					assert.Len(t, c.Traces.TLSCfg.Certificates, 1)
+				}
+			},
+		},
+		{
+			name: "Test Environment Invalid Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "invalid_cert",
+			},
+			fileReader: fileReader{
+				"invalid_cert": []byte("invalid certificate file."),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if !grpcOption {
+					// The file is retried when new connections are made.
+					require.NotNil(t, c.Traces.TLSReloader)
+					assert.Nil(t, c.Traces.TLSCfg.RootCAs)
+				}
+			},
+		},
+		{
+			name: "Test Environment Invalid Certificate Valid Client Certificate",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE":        "invalid_cert",
+				"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE": "cert_path",
+				"OTEL_EXPORTER_OTLP_CLIENT_KEY":         "key_path",
+			},
+			fileReader: fileReader{
+				"invalid_cert": []byte("invalid certificate file."),
+				"cert_path":    []byte(WeakCertificate),
+				"key_path":     []byte(WeakPrivateKey),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if !grpcOption {
+					// The invalid CA file does not drop the client certificate.
+					require.NotNil(t, c.Traces.TLSReloader)
+					assert.Nil(t, c.Traces.TLSCfg.RootCAs)
+					assert.Len(t, c.Traces.TLSCfg.Certificates, 1)
+				}
+			},
+		},
+		{
+			name: "Test With Certificate Overrides Environment Certificate",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithTLSClientConfig(tlsCert),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_CERTIFICATE": "cert_path",
+			},
+			fileReader: fileReader{
+				"cert_path": []byte(WeakCertificate),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				if !grpcOption {
+					assert.Nil(t, c.Traces.TLSReloader)
+					// nolint:staticcheck // ignoring tlsCert.RootCAs.Subjects is deprecated ERR because cert does not come from SystemCertPool.
+					assert.Equal(t, tlsCert.RootCAs.Subjects(), c.Traces.TLSCfg.RootCAs.Subjects())
+				}
+			},
+		},
 		// Headers tests
 		{
 			name: "Test With Headers",
@@ -339,6 +417,88 @@ func TestConfigs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Test Environment Zstd Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.ZstdCompression, c.Traces.Compression)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Snappy Compression",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_COMPRESSION":        "zstd",
+				"OTEL_EXPORTER_OTLP_TRACES_COMPRESSION": "snappy",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.SnappyCompression, c.Traces.Compression)
+			},
+		},
+		{
+			name: "Test With Compression Level",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithCompression(otlpconfig.ZstdCompression),
+				otlpconfig.WithCompressionLevel(19),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.ZstdCompression, c.Traces.Compression)
+				assert.Equal(t, 19, c.Traces.CompressionLevel)
+			},
+		},
+
+		// Protocol Tests
+		{
+			name: "Test With Marshaler",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMarshaler(otlpconfig.MarshalJSON),
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalJSON, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Signal Specific Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Environment Unknown Protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+		{
+			name: "Test Mixed Environment and With Marshaler",
+			opts: []otlpconfig.GenericOption{
+				otlpconfig.WithMarshaler(otlpconfig.MarshalProto),
+			},
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json",
+			},
+			asserts: func(t *testing.T, c *otlpconfig.Config, grpcOption bool) {
+				assert.Equal(t, otlpconfig.MarshalProto, c.Traces.Marshaler)
+			},
+		},
+
 		// Timeout Tests
 		{
 			name: "Test With Timeout",
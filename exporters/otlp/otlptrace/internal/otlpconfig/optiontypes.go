@@ -14,6 +14,8 @@
 
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 
+import "go.opentelemetry.io/otel/exporters/otlp/internal/compress"
+
 const (
 	// DefaultCollectorGRPCPort is the default gRPC port of the collector.
 	DefaultCollectorGRPCPort uint16 = 4317
@@ -35,8 +37,29 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy.
+	SnappyCompression
 )
 
+// Name returns the encoding name of c, or an empty string for
+// NoCompression.
+func (c Compression) Name() string {
+	switch c {
+	case GzipCompression:
+		return compress.Gzip
+	case ZstdCompression:
+		return compress.Zstd
+	case SnappyCompression:
+		return compress.Snappy
+	default:
+		return ""
+	}
+}
+
 // Marshaler describes the kind of message format sent to the collector.
 type Marshaler int
 
@@ -15,9 +15,15 @@
 package otlpconfig // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tlsreload"
 )
 
 // CreateTLSConfig creates a tls.Config from a raw certificate bytes
@@ -32,3 +38,40 @@ func CreateTLSConfig(certBytes []byte) (*tls.Config, error) {
 		RootCAs: cp,
 	}, nil
 }
+
+// reloadingCredentials are gRPC TLS transport credentials that use the
+// current configuration of a Reloader for each new connection.
+type reloadingCredentials struct {
+	reloader   *tlsreload.Reloader
+	serverName string
+}
+
+var _ credentials.TransportCredentials = (*reloadingCredentials)(nil)
+
+func (c *reloadingCredentials) current() credentials.TransportCredentials {
+	cfg := c.reloader.Config()
+	cfg.ServerName = c.serverName
+	return credentials.NewTLS(cfg)
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.current().ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("server handshake is not supported")
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{ServerName: c.serverName}).Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	cp := *c
+	return &cp
+}
+
+func (c *reloadingCredentials) OverrideServerName(serverNameOverride string) error {
+	c.serverName = serverNameOverride
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracesplit splits OTLP trace data into chunks that do not exceed a
+// maximum encoded request size.
+package tracesplit // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracesplit"
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Sizer measures the size of messages in the encoding used to send export
+// requests.
+type Sizer struct {
+	// Size returns the number of bytes m is encoded as.
+	Size func(m proto.Message) int
+	// Overhead is the maximum number of bytes, in addition to the size of
+	// the message itself, used to encode a message as an element of a
+	// repeated field of its parent.
+	Overhead int
+}
+
+// Protobuf is the Sizer of the protobuf binary encoding.
+var Protobuf = Sizer{
+	Size: proto.Size,
+	// The tag and length of an embedded message field use 1 byte and up to
+	// 5 bytes respectively.
+	Overhead: 6,
+}
+
+// JSON returns the Sizer of a JSON encoding where marshal encodes messages.
+// Each message is encoded to be measured, this is significantly more
+// expensive than measuring the protobuf encoding.
+func JSON(marshal func(proto.Message) ([]byte, error)) Sizer {
+	return Sizer{
+		Size: func(m proto.Message) int {
+			b, err := marshal(m)
+			if err != nil {
+				// The error is returned when the request is encoded.
+				return 0
+			}
+			return len(b)
+		},
+		// A separating comma and, for the first element, the name of the
+		// longest field ("resourceSpans") and the array brackets.
+		Overhead: len(`,"resourceSpans":[]`),
+	}
+}
+
+// ResourceSpans splits rss into chunks where each chunk, when encoded as an
+// ExportTraceServiceRequest, is estimated to be no larger than maxSize bytes
+// as measured by sizer.
+//
+// Resource and instrumentation scope grouping is preserved: spans in a chunk
+// are nested in copies of the ResourceSpans and ScopeSpans they were in. A
+// single span larger than maxSize is returned in a chunk of its own.
+//
+// If maxSize is less than or equal to zero, or all the data fits in one
+// chunk, rss is returned as the only chunk.
+func ResourceSpans(rss []*tracepb.ResourceSpans, maxSize int, sizer Sizer) [][]*tracepb.ResourceSpans {
+	if maxSize <= 0 || len(rss) == 0 {
+		return [][]*tracepb.ResourceSpans{rss}
+	}
+	req := 0
+	for _, rs := range rss {
+		req += sizer.Size(rs) + sizer.Overhead
+	}
+	if req <= maxSize {
+		return [][]*tracepb.ResourceSpans{rss}
+	}
+
+	s := splitter{maxSize: maxSize, sizer: sizer}
+	for _, rs := range rss {
+		s.addResource(rs)
+	}
+	s.flush()
+	return s.chunks
+}
+
+// Export splits rss using ResourceSpans and calls export for each chunk in order.
+//
+// All chunks are exported even if exporting one of them fails, unless ctx
+// is done. The first error returned from export is returned, annotated with
+// the number of failed requests if there was more than one request.
+func Export(ctx context.Context, rss []*tracepb.ResourceSpans, maxSize int, sizer Sizer, export func(context.Context, []*tracepb.ResourceSpans) error) error {
+	chunks := ResourceSpans(rss, maxSize, sizer)
+	if len(chunks) == 1 {
+		return export(ctx, chunks[0])
+	}
+
+	var (
+		failed   int
+		firstErr error
+	)
+	for i, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			// The remaining chunks are not exported.
+			failed += len(chunks) - i
+			if firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+		if err := export(ctx, chunk); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr == nil {
+		return nil
+	}
+	return fmt.Errorf("%d of %d export requests failed: %w", failed, len(chunks), firstErr)
+}
+
+type splitter struct {
+	maxSize int
+	sizer   Sizer
+
+	chunks [][]*tracepb.ResourceSpans
+
+	// The chunk currently being built, its estimated size, and the
+	// ResourceSpans and ScopeSpans spans are being added to in it.
+	cur   []*tracepb.ResourceSpans
+	size  int
+	curRS *tracepb.ResourceSpans
+	curSS *tracepb.ScopeSpans
+}
+
+func (s *splitter) addResource(rs *tracepb.ResourceSpans) {
+	overhead := s.sizer.Overhead
+	rsShell := s.sizer.Size(&tracepb.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}) + overhead
+	s.curRS = nil
+	for _, ss := range rs.ScopeSpans {
+		ssShell := s.sizer.Size(&tracepb.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}) + overhead
+		s.curSS = nil
+		for _, span := range ss.Spans {
+			spanSize := s.sizer.Size(span) + overhead
+			cost := spanSize
+			if s.curSS == nil {
+				cost += ssShell
+			}
+			if s.curRS == nil {
+				cost += rsShell
+			}
+			if len(s.cur) > 0 && s.size+cost > s.maxSize {
+				s.flush()
+				cost = spanSize + ssShell + rsShell
+			}
+			if s.curRS == nil {
+				s.curRS = &tracepb.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+				s.cur = append(s.cur, s.curRS)
+			}
+			if s.curSS == nil {
+				s.curSS = &tracepb.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}
+				s.curRS.ScopeSpans = append(s.curRS.ScopeSpans, s.curSS)
+			}
+			s.curSS.Spans = append(s.curSS.Spans, span)
+			s.size += cost
+		}
+	}
+}
+
+// flush completes the current chunk.
+func (s *splitter) flush() {
+	if len(s.cur) > 0 {
+		s.chunks = append(s.chunks, s.cur)
+	}
+	s.cur, s.size, s.curRS, s.curSS = nil, 0, nil, nil
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracesplit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func resourceSpans(name string, scopes, spans int) *tracepb.ResourceSpans {
+	rs := &tracepb.ResourceSpans{
+		Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{{
+			Key:   "service.name",
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: name}},
+		}}},
+		SchemaUrl: "https://opentelemetry.io/schemas/1.17.0",
+	}
+	for i := 0; i < scopes; i++ {
+		ss := &tracepb.ScopeSpans{Scope: &commonpb.InstrumentationScope{Name: name + "/scope" + string(rune('a'+i))}}
+		for j := 0; j < spans; j++ {
+			ss.Spans = append(ss.Spans, &tracepb.Span{
+				TraceId: make([]byte, 16),
+				SpanId:  make([]byte, 8),
+				Name:    strings.Repeat("x", 100),
+			})
+		}
+		rs.ScopeSpans = append(rs.ScopeSpans, ss)
+	}
+	return rs
+}
+
+func countSpans(chunks [][]*tracepb.ResourceSpans) map[string]int {
+	out := make(map[string]int)
+	for _, chunk := range chunks {
+		for _, rs := range chunk {
+			for _, ss := range rs.ScopeSpans {
+				out[ss.Scope.Name] += len(ss.Spans)
+			}
+		}
+	}
+	return out
+}
+
+func TestResourceSpansNoSplit(t *testing.T) {
+	rss := []*tracepb.ResourceSpans{resourceSpans("a", 2, 3)}
+	assert.Equal(t, [][]*tracepb.ResourceSpans{rss}, ResourceSpans(rss, 0, Protobuf))
+	assert.Equal(t, [][]*tracepb.ResourceSpans{rss}, ResourceSpans(rss, 1<<20, Protobuf))
+}
+
+func TestResourceSpansSplit(t *testing.T) {
+	rss := []*tracepb.ResourceSpans{
+		resourceSpans("a", 2, 10),
+		resourceSpans("b", 1, 10),
+	}
+	const maxSize = 1000
+	chunks := ResourceSpans(rss, maxSize, Protobuf)
+	require.Greater(t, len(chunks), 1)
+
+	for _, chunk := range chunks {
+		req := &coltracepb.ExportTraceServiceRequest{ResourceSpans: chunk}
+		assert.LessOrEqual(t, proto.Size(req), maxSize)
+		for _, rs := range chunk {
+			// Resource and scope information is preserved.
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.17.0", rs.SchemaUrl)
+			assert.NotNil(t, rs.Resource)
+			for _, ss := range rs.ScopeSpans {
+				assert.Equal(t, rs.Resource.Attributes[0].Value.GetStringValue(), strings.Split(ss.Scope.Name, "/")[0])
+			}
+		}
+	}
+	assert.Equal(t, map[string]int{"a/scopea": 10, "a/scopeb": 10, "b/scopea": 10}, countSpans(chunks))
+}
+
+func TestResourceSpansSplitJSON(t *testing.T) {
+	rss := []*tracepb.ResourceSpans{
+		resourceSpans("a", 2, 10),
+		resourceSpans("b", 1, 10),
+	}
+	const maxSize = 2000
+	sizer := JSON(protojson.Marshal)
+	chunks := ResourceSpans(rss, maxSize, sizer)
+
+	// The JSON encoding is larger than the protobuf one, measuring the
+	// protobuf encoding would produce fewer, oversized chunks.
+	require.Greater(t, len(chunks), len(ResourceSpans(rss, maxSize, Protobuf)))
+	for _, chunk := range chunks {
+		b, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: chunk})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(b), maxSize)
+	}
+	assert.Equal(t, map[string]int{"a/scopea": 10, "a/scopeb": 10, "b/scopea": 10}, countSpans(chunks))
+}
+
+func TestResourceSpansOversizedSpan(t *testing.T) {
+	rss := []*tracepb.ResourceSpans{resourceSpans("a", 1, 3)}
+	chunks := ResourceSpans(rss, 10, Protobuf)
+	require.Len(t, chunks, 3, "each span should be in its own chunk")
+	assert.Equal(t, map[string]int{"a/scopea": 3}, countSpans(chunks))
+}
+
+func TestExport(t *testing.T) {
+	rss := []*tracepb.ResourceSpans{resourceSpans("a", 1, 3)}
+	var calls int
+	err := Export(context.Background(), rss, 10, Protobuf, func(context.Context, []*tracepb.ResourceSpans) error {
+		calls++
+		if calls == 2 {
+			return assert.AnError
+		}
+		return nil
+	})
+	assert.Equal(t, 3, calls, "all chunks should be exported")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.EqualError(t, err, "1 of 3 export requests failed: "+assert.AnError.Error())
+
+	// An unsplit request returns the export error unchanged.
+	err = Export(context.Background(), rss, 0, Protobuf, func(context.Context, []*tracepb.ResourceSpans) error {
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = Export(ctx, rss, 10, Protobuf, func(context.Context, []*tracepb.ResourceSpans) error {
+		calls++
+		cancel()
+		return nil
+	})
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "2 of 3 export requests failed: context canceled")
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/rotate"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var (
+	errNotStarted     = errors.New("client not started")
+	errAlreadyStarted = errors.New("client already started")
+)
+
+type client struct {
+	cfg config
+
+	mu     sync.Mutex
+	writer *rotate.Writer
+}
+
+var _ otlptrace.Client = (*client)(nil)
+
+// NewClient creates a new file trace client.
+func NewClient(opts ...Option) otlptrace.Client {
+	return &client{cfg: newConfig(opts)}
+}
+
+// Start opens the file traces are written to. It returns an error if the
+// client is already started.
+func (c *client) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.writer != nil {
+		return errAlreadyStarted
+	}
+	w, err := rotate.New(rotate.Config{
+		Path:       c.cfg.path,
+		MaxSize:    c.cfg.maxSize,
+		MaxBackups: c.cfg.maxBackups,
+		Compress:   c.cfg.compression == GzipCompression,
+	})
+	if err != nil {
+		return err
+	}
+	c.writer = w
+	return nil
+}
+
+// Stop syncs and closes the file traces are written to.
+func (c *client) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.writer == nil {
+		return ctx.Err()
+	}
+	err := c.writer.Close()
+	c.writer = nil
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// UploadTraces writes protoSpans to the file as a single line.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// The OTLP/JSON mapping requires enum values be encoded as integers and
+	// trace and span IDs be hex encoded.
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	})
+	if err != nil {
+		return err
+	}
+	if b, err = internal.JSONIDsToHex(b); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.writer == nil {
+		return errNotStarted
+	}
+	return c.writer.WriteLine(b)
+}
+
+// MarshalLog is the marshaling function used by the logging system to represent this Client.
+func (c *client) MarshalLog() interface{} {
+	return struct {
+		Type string
+		Path string
+	}{
+		Type: "otlptracefile",
+		Path: c.cfg.path,
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracefile
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+)
+
+func readRequests(t *testing.T, path string) []*coltracepb.ExportTraceServiceRequest {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var reqs []*coltracepb.ExportTraceServiceRequest
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		b, err := internal.JSONIDsToBase64(scanner.Bytes())
+		require.NoError(t, err)
+		req := new(coltracepb.ExportTraceServiceRequest)
+		require.NoError(t, protojson.Unmarshal(b, req))
+		reqs = append(reqs, req)
+	}
+	require.NoError(t, scanner.Err())
+	return reqs
+}
+
+func TestExporterWritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(path))
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{Name: "span0"}, {Name: "span1"}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(ctx, spans[:1]))
+	require.NoError(t, exp.ExportSpans(ctx, spans[1:]))
+	require.NoError(t, exp.Shutdown(ctx))
+
+	reqs := readRequests(t, path)
+	require.Len(t, reqs, 2)
+	for i, req := range reqs {
+		require.Len(t, req.ResourceSpans, 1)
+		require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+		require.Len(t, req.ResourceSpans[0].ScopeSpans[0].Spans, 1)
+		assert.Equal(t, spans[i].Name(), req.ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	}
+}
+
+func TestExporterHexIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(path))
+	require.NoError(t, err)
+
+	tp := trace.NewTracerProvider(trace.WithSyncer(exp))
+	_, span := tp.Tracer("test").Start(ctx, "span")
+	span.End()
+	require.NoError(t, tp.Shutdown(ctx))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sc := span.SpanContext()
+	assert.Contains(t, string(b), `"traceId":"`+sc.TraceID().String()+`"`)
+	assert.Contains(t, string(b), `"spanId":"`+sc.SpanID().String()+`"`)
+}
+
+func TestExporterRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.jsonl")
+	ctx := context.Background()
+	exp, err := New(ctx, WithPath(path), WithMaxSize(1), WithMaxBackups(1), WithCompression(GzipCompression))
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ExportSpans(ctx, spans))
+	}
+	require.NoError(t, exp.Shutdown(ctx))
+
+	assert.Len(t, readRequests(t, path), 1)
+	backups, err := filepath.Glob(filepath.Join(dir, "traces-*.jsonl.gz"))
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestClientNotStarted(t *testing.T) {
+	c := NewClient(WithPath(filepath.Join(t.TempDir(), "traces.jsonl")))
+	assert.ErrorIs(t, c.UploadTraces(context.Background(), nil), errNotStarted)
+	assert.NoError(t, c.Stop(context.Background()))
+}
+
+func TestClientStartTwice(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithPath(filepath.Join(t.TempDir(), "traces.jsonl")))
+	require.NoError(t, c.Start(ctx))
+	assert.ErrorIs(t, c.Start(ctx), errAlreadyStarted)
+	require.NoError(t, c.Stop(ctx))
+
+	// The client can be started again once stopped.
+	require.NoError(t, c.Start(ctx))
+	assert.NoError(t, c.Stop(ctx))
+}
+
+func TestClientStartError(t *testing.T) {
+	dir := t.TempDir()
+	// A directory cannot be opened as the output file.
+	_, err := New(context.Background(), WithPath(dir))
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracefile // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracefile"
+
+const (
+	// DefaultPath is the default path of the file traces are written to.
+	DefaultPath = "traces.jsonl"
+)
+
+// Compression describes the compression used for rotated files.
+type Compression int
+
+const (
+	// NoCompression tells the client to leave rotated files uncompressed.
+	NoCompression Compression = iota
+	// GzipCompression tells the client to compress rotated files with gzip.
+	GzipCompression
+)
+
+type config struct {
+	path        string
+	maxSize     int64
+	maxBackups  int
+	compression Compression
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{path: DefaultPath}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option applies an option to the file client.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithPath sets the path of the file traces are written to. Any missing
+// parent directories are created. If this option is not passed, DefaultPath
+// is used.
+func WithPath(path string) Option {
+	return optionFunc(func(cfg config) config {
+		if path != "" {
+			cfg.path = path
+		}
+		return cfg
+	})
+}
+
+// WithMaxSize sets the size in bytes the file can grow to before it is
+// rotated. When rotated, the file is renamed to include the time of rotation
+// and a new file is created at the configured path.
+//
+// If this option is not passed, or a size less than or equal to zero is
+// passed, the file is never rotated.
+func WithMaxSize(size int64) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxSize = size
+		return cfg
+	})
+}
+
+// WithMaxBackups sets the maximum number of rotated files to retain. The
+// oldest rotated files are removed first.
+//
+// If this option is not passed, or a value less than or equal to zero is
+// passed, all rotated files are retained.
+func WithMaxBackups(n int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.maxBackups = n
+		return cfg
+	})
+}
+
+// WithCompression sets the compression used for rotated files. The file
+// currently being written to is never compressed so it can be tailed.
+//
+// If this option is not passed, rotated files are not compressed.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.compression = compression
+		return cfg
+	})
+}
@@ -17,10 +17,10 @@ package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptra
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -28,19 +28,25 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracesplit"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/exporters/retry/retrygrpc"
+	"go.opentelemetry.io/otel/internal/observ"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
 type client struct {
-	endpoint      string
-	dialOpts      []grpc.DialOption
-	metadata      metadata.MD
-	exportTimeout time.Duration
-	requestFunc   retry.RequestFunc
+	endpoint       string
+	dialOpts       []grpc.DialOption
+	metadata       metadata.MD
+	headerProvider func(context.Context) (map[string]string, error)
+	exportTimeout  time.Duration
+	maxReqSize     int
+	requestFunc    retry.RequestFunc
+	observ         observ.Exporter
 
 	// stopCtx is used as a parent context for all exports. Therefore, when it
 	// is canceled with the stopFunc all exports are canceled.
@@ -72,13 +78,16 @@ func newClient(opts ...Option) *client {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := &client{
-		endpoint:      cfg.Traces.Endpoint,
-		exportTimeout: cfg.Traces.Timeout,
-		requestFunc:   cfg.RetryConfig.RequestFunc(retryable),
-		dialOpts:      cfg.DialOptions,
-		stopCtx:       ctx,
-		stopFunc:      cancel,
-		conn:          cfg.GRPCConn,
+		endpoint:       cfg.Traces.Endpoint,
+		headerProvider: cfg.Traces.HeaderProvider,
+		exportTimeout:  cfg.Traces.Timeout,
+		maxReqSize:     cfg.Traces.MaxRequestSize,
+		requestFunc:    cfg.RetryPolicy.RequestFunc(retrygrpc.Evaluate(cfg.RetryPolicy)),
+		observ:         observ.GetProvider().Exporter("go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc", "spans"),
+		dialOpts:       cfg.DialOptions,
+		stopCtx:        ctx,
+		stopFunc:       cancel,
+		conn:           cfg.GRPCConn,
 	}
 
 	if len(cfg.Traces.Headers) > 0 {
@@ -178,10 +187,12 @@ func (c *client) Stop(ctx context.Context) error {
 
 var errShutdown = errors.New("the client is shutdown")
 
-// UploadTraces sends a batch of spans.
+// UploadTraces sends a batch of spans. If the batch is larger than the
+// configured maximum request size it is split and sent using multiple
+// requests.
 //
 // Retryable errors from the server will be handled according to any
-// RetryConfig the client was created with.
+// retry policy the client was created with.
 func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	// Hold a read lock to ensure a shut down initiated after this starts does
 	// not abandon the export. This read lock acquire has less priority than a
@@ -194,10 +205,26 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return errShutdown
 	}
 
+	return tracesplit.Export(ctx, protoSpans, c.maxReqSize, tracesplit.Protobuf, c.export)
+}
+
+// export sends protoSpans in a single request. The caller must hold a read
+// lock on c.tscMu.
+func (c *client) export(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	ctx, cancel := c.exportContext(ctx)
 	defer cancel()
 
-	return c.requestFunc(ctx, func(iCtx context.Context) error {
+	var attempt int
+	err := c.requestFunc(ctx, func(iCtx context.Context) error {
+		if attempt > 0 {
+			c.observ.Retry(iCtx)
+		}
+		attempt++
+
+		iCtx, err := c.withProvidedHeaders(iCtx)
+		if err != nil {
+			return err
+		}
 		resp, err := c.tsc.Export(iCtx, &coltracepb.ExportTraceServiceRequest{
 			ResourceSpans: protoSpans,
 		})
@@ -205,6 +232,7 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			msg := resp.PartialSuccess.GetErrorMessage()
 			n := resp.PartialSuccess.GetRejectedSpans()
 			if n != 0 || msg != "" {
+				c.observ.Rejected(iCtx, n)
 				err := internal.TracePartialSuccessError(n, msg)
 				otel.Handle(err)
 			}
@@ -216,6 +244,8 @@ func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		}
 		return err
 	})
+	c.observ.Request(ctx, err)
+	return err
 }
 
 // exportContext returns a copy of parent with an appropriate deadline and
@@ -253,35 +283,25 @@ func (c *client) exportContext(parent context.Context) (context.Context, context
 	return ctx, cancel
 }
 
-// retryable returns if err identifies a request that can be retried and a
-// duration to wait for if an explicit throttle time is included in err.
-func retryable(err error) (bool, time.Duration) {
-	//func retryable(err error) (bool, time.Duration) {
-	s := status.Convert(err)
-	switch s.Code() {
-	case codes.Canceled,
-		codes.DeadlineExceeded,
-		codes.ResourceExhausted,
-		codes.Aborted,
-		codes.OutOfRange,
-		codes.Unavailable,
-		codes.DataLoss:
-		return true, throttleDelay(s)
+// withProvidedHeaders returns a copy of ctx with the headers returned by the
+// client's header provider, if any, added to its outgoing metadata.
+func (c *client) withProvidedHeaders(ctx context.Context) (context.Context, error) {
+	if c.headerProvider == nil {
+		return ctx, nil
 	}
-
-	// Not a retry-able error.
-	return false, 0
-}
-
-// throttleDelay returns a duration to wait for if an explicit throttle time
-// is included in the response status.
-func throttleDelay(s *status.Status) time.Duration {
-	for _, detail := range s.Details() {
-		if t, ok := detail.(*errdetails.RetryInfo); ok {
-			return t.RetryDelay.AsDuration()
-		}
+	h, err := c.headerProvider(ctx)
+	if err != nil {
+		return ctx, fmt.Errorf("header provider: %w", err)
+	}
+	if len(h) == 0 {
+		return ctx, nil
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	for k, v := range h {
+		md.Set(k, v)
 	}
-	return 0
+	return metadata.NewOutgoingContext(ctx, md), nil
 }
 
 // MarshalLog is the marshaling function used by the logging system to represent this Client.
@@ -35,6 +35,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/retry"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -217,6 +218,59 @@ func TestNewWithHeaders(t *testing.T) {
 	assert.Equal(t, "value1", headers.Get("header1")[0])
 }
 
+type tenantKey struct{}
+
+func TestNewWithHeaderProvider(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithHeaders(map[string]string{"header1": "static", "header2": "value2"}),
+		otlptracegrpc.WithHeaderProvider(func(ctx context.Context) (map[string]string, error) {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return map[string]string{"header1": "dynamic", "tenant": tenant}, nil
+		}))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	require.NoError(t, exp.ExportSpans(context.WithValue(ctx, tenantKey{}, "tenant-a"), roSpans))
+
+	headers := mc.getHeaders()
+	assert.Equal(t, []string{"dynamic"}, headers.Get("header1"))
+	assert.Equal(t, []string{"value2"}, headers.Get("header2"))
+	assert.Equal(t, []string{"tenant-a"}, headers.Get("tenant"))
+}
+
+func TestHeaderProviderErrorAbortsExport(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint,
+		otlptracegrpc.WithHeaderProvider(func(context.Context) (map[string]string, error) {
+			return nil, assert.AnError
+		}))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	assert.ErrorIs(t, exp.ExportSpans(ctx, roSpans), assert.AnError)
+	assert.Len(t, mc.getSpans(), 0)
+}
+
+func TestNewWithMaxRequestSize(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithMaxRequestSize(200))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	spans := make(tracetest.SpanStubs, 10)
+	for i := range spans {
+		spans[i].Name = fmt.Sprintf("span-%d-with-a-name-long-enough-to-fill-requests", i)
+	}
+	require.NoError(t, exp.ExportSpans(ctx, spans.Snapshots()))
+	assert.Greater(t, mc.getRequests(), 1, "request not split")
+	assert.Len(t, mc.getSpans(), len(spans))
+}
+
 func TestExportSpansTimeoutHonored(t *testing.T) {
 	ctx, cancel := contextWithTimeout(context.Background(), t, 1*time.Minute)
 	t.Cleanup(cancel)
@@ -413,6 +467,28 @@ func TestPartialSuccess(t *testing.T) {
 	require.Contains(t, errors[0].Error(), "2 spans rejected")
 }
 
+func TestUnregisteredCompressor(t *testing.T) {
+	mc := runMockCollector(t)
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	errors := []error{}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errors = append(errors, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	// The zstd compressor is not registered in the test binary, the spans
+	// are sent uncompressed.
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithCompressor("zstd"))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Len(t, mc.getSpans(), 1)
+
+	require.Equal(t, 1, len(errors))
+	assert.Contains(t, errors[0].Error(), `gRPC compressor "zstd" is not registered`)
+}
+
 func TestCustomUserAgent(t *testing.T) {
 	customUserAgent := "custom-user-agent"
 	mc := runMockCollector(t)
@@ -427,3 +503,26 @@ func TestCustomUserAgent(t *testing.T) {
 	headers := mc.getHeaders()
 	require.Contains(t, headers.Get("user-agent")[0], customUserAgent)
 }
+
+func TestRetryPolicy(t *testing.T) {
+	mc := runMockCollectorWithConfig(t, &mockConfig{
+		errors: []error{
+			status.Error(codes.Internal, "internal"),
+			status.Error(codes.Internal, "internal"),
+		},
+	})
+	t.Cleanup(func() { require.NoError(t, mc.stop()) })
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint, otlptracegrpc.WithRetryPolicy(retry.Policy{
+		Enabled:           true,
+		InitialInterval:   time.Nanosecond,
+		MaxAttempts:       3,
+		RetryableGRPCCode: func(c uint32) bool { return codes.Code(c) == codes.Internal },
+	}))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(ctx)) })
+
+	require.NoError(t, exp.ExportSpans(ctx, roSpans))
+	assert.Equal(t, 3, mc.getRequests())
+	assert.Len(t, mc.getSpans(), 1)
+}
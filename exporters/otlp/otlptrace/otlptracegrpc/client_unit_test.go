@@ -21,108 +21,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/durationpb"
 )
 
-func TestThrottleDuration(t *testing.T) {
-	c := codes.ResourceExhausted
-	testcases := []struct {
-		status   *status.Status
-		expected time.Duration
-	}{
-		{
-			status:   status.New(c, "no retry info"),
-			expected: 0,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "single retry info").WithDetails(
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(15 * time.Millisecond),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 15 * time.Millisecond,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "error info").WithDetails(
-					&errdetails.ErrorInfo{Reason: "no throttle detail"},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 0,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "error and retry info").WithDetails(
-					&errdetails.ErrorInfo{Reason: "with throttle detail"},
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(13 * time.Minute),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 13 * time.Minute,
-		},
-		{
-			status: func() *status.Status {
-				s, err := status.New(c, "double retry info").WithDetails(
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(13 * time.Minute),
-					},
-					&errdetails.RetryInfo{
-						RetryDelay: durationpb.New(15 * time.Minute),
-					},
-				)
-				require.NoError(t, err)
-				return s
-			}(),
-			expected: 13 * time.Minute,
-		},
-	}
-
-	for _, tc := range testcases {
-		t.Run(tc.status.Message(), func(t *testing.T) {
-			require.Equal(t, tc.expected, throttleDelay(tc.status))
-		})
-	}
-}
-
-func TestRetryable(t *testing.T) {
-	retryableCodes := map[codes.Code]bool{
-		codes.OK:                 false,
-		codes.Canceled:           true,
-		codes.Unknown:            false,
-		codes.InvalidArgument:    false,
-		codes.DeadlineExceeded:   true,
-		codes.NotFound:           false,
-		codes.AlreadyExists:      false,
-		codes.PermissionDenied:   false,
-		codes.ResourceExhausted:  true,
-		codes.FailedPrecondition: false,
-		codes.Aborted:            true,
-		codes.OutOfRange:         true,
-		codes.Unimplemented:      false,
-		codes.Internal:           false,
-		codes.Unavailable:        true,
-		codes.DataLoss:           true,
-		codes.Unauthenticated:    false,
-	}
-
-	for c, want := range retryableCodes {
-		got, _ := retryable(status.Error(c, ""))
-		assert.Equalf(t, want, got, "evaluate(%s)", c)
-	}
-}
-
 func TestUnstartedStop(t *testing.T) {
 	client := NewClient()
 	assert.ErrorIs(t, client.Stop(context.Background()), errAlreadyStopped)
@@ -59,6 +59,12 @@ func (mts *mockTraceService) getHeaders() metadata.MD {
 	return mts.headers
 }
 
+func (mts *mockTraceService) getRequests() int {
+	mts.mu.RLock()
+	defer mts.mu.RUnlock()
+	return mts.requests
+}
+
 func (mts *mockTraceService) getSpans() []*tracepb.Span {
 	mts.mu.RLock()
 	defer mts.mu.RUnlock()
@@ -139,6 +145,10 @@ func (mc *mockCollector) Stop() error {
 	return mc.stop()
 }
 
+func (mc *mockCollector) getRequests() int {
+	return mc.traceSvc.getRequests()
+}
+
 func (mc *mockCollector) getSpans() []*tracepb.Span {
 	return mc.traceSvc.getSpans()
 }
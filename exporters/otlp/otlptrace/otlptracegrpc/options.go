@@ -15,6 +15,7 @@
 package otlptracegrpc // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -22,10 +23,17 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/retry"
 )
 
+// HeaderProvider returns headers to send with an export request. It is
+// called with the context of the export for every attempt to send the
+// request, including retries, allowing short-lived credentials or values
+// taken from the context to be used. If an error is returned, the export is
+// aborted and the error is returned from the export.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
 // Option applies an option to the gRPC driver.
 type Option interface {
 	applyGRPCOption(otlpconfig.Config) otlpconfig.Config
@@ -44,7 +52,31 @@ func asGRPCOptions(opts []Option) []otlpconfig.GRPCOption {
 //
 // This configuration does not define any network retry strategy. That is
 // entirely handled by the gRPC ClientConn.
-type RetryConfig retry.Config
+type RetryConfig struct {
+	// Enabled indicates whether to not retry sending batches in case of
+	// export failure.
+	Enabled bool
+	// InitialInterval the time to wait after the first failure before
+	// retrying.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on backoff interval. Once this value is
+	// reached the delay between consecutive retries will always be
+	// `MaxInterval`.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request/batch.  Once this value is reached, the data
+	// is discarded.
+	MaxElapsedTime time.Duration
+}
+
+func (c RetryConfig) policy() retry.Policy {
+	return retry.Policy{
+		Enabled:         c.Enabled,
+		InitialInterval: c.InitialInterval,
+		MaxInterval:     c.MaxInterval,
+		MaxElapsedTime:  c.MaxElapsedTime,
+	}
+}
 
 type wrappedOption struct {
 	otlpconfig.GRPCOption
@@ -84,8 +116,13 @@ func WithReconnectionPeriod(rp time.Duration) Option {
 }
 
 func compressorToCompression(compressor string) otlpconfig.Compression {
-	if compressor == "gzip" {
+	switch compressor {
+	case "gzip":
 		return otlpconfig.GzipCompression
+	case "zstd":
+		return otlpconfig.ZstdCompression
+	case "snappy":
+		return otlpconfig.SnappyCompression
 	}
 
 	otel.Handle(fmt.Errorf("invalid compression type: '%s', using no compression as default", compressor))
@@ -93,11 +130,16 @@ func compressorToCompression(compressor string) otlpconfig.Compression {
 }
 
 // WithCompressor sets the compressor for the gRPC client to use when sending
-// requests. It is the responsibility of the caller to ensure that the
-// compressor set has been registered with google.golang.org/grpc/encoding.
-// This can be done by encoding.RegisterCompressor. Some compressors
-// auto-register on import, such as gzip, which can be registered by calling
-// `import _ "google.golang.org/grpc/encoding/gzip"`.
+// requests. The supported compressors are "gzip", "zstd", and "snappy". Any
+// other compressor results in no compression being used.
+//
+// The "zstd" and "snappy" compressors need to be registered with gRPC by
+// importing go.opentelemetry.io/otel/exporters/otlp/grpcencoding/zstd or
+// go.opentelemetry.io/otel/exporters/otlp/grpcencoding/snappy. If the
+// selected compressor is not registered when the client is created, an error
+// is passed to the global error handler and requests are sent uncompressed.
+// Payloads are compressed at the default level of the compressor, gRPC
+// compressors do not support compression levels.
 //
 // This option has no effect if WithGRPCConn is used.
 func WithCompressor(compressor string) Option {
@@ -109,12 +151,22 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{otlpconfig.WithHeaders(headers)}
 }
 
+// WithHeaderProvider sets a HeaderProvider that is called to get headers
+// added to the gRPC metadata of each export request. Headers returned by
+// the provider take precedence over the headers set with WithHeaders.
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return wrappedOption{otlpconfig.WithHeaderProvider(provider)}
+}
+
 // WithTLSCredentials allows the connection to use TLS credentials when
 // talking to the server. It takes in grpc.TransportCredentials instead of say
 // a Certificate file or a tls.Certificate, because the retrieving of these
 // credentials can be done in many ways e.g. plain file, in code tls.Config or
 // by certificate rotation, so it is up to the caller to decide what to use.
 //
+// Certificate files configured with environment variables are reloaded when
+// they change if this option is not passed.
+//
 // This option has no effect if WithGRPCConn is used.
 func WithTLSCredentials(creds credentials.TransportCredentials) Option {
 	return wrappedOption{otlpconfig.NewGRPCOption(func(cfg otlpconfig.Config) otlpconfig.Config {
@@ -161,6 +213,20 @@ func WithGRPCConn(conn *grpc.ClientConn) Option {
 	})}
 }
 
+// WithMaxRequestSize sets the maximum size, in bytes, of the protobuf
+// encoded export request sent to the endpoint, before it is compressed. A
+// batch of spans that encodes larger than size is split into multiple
+// requests. Resource and instrumentation scope grouping is preserved in each
+// request, and each request is retried independently.
+//
+// A single span larger than size cannot be split and is sent in a request
+// of its own.
+//
+// If unset or less than or equal to zero, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
 // WithTimeout sets the max amount of time a client will attempt to export a
 // batch of spans. This takes precedence over any retry settings defined with
 // WithRetry, once this time limit has been reached the export is abandoned
@@ -185,5 +251,17 @@ func WithTimeout(duration time.Duration) Option {
 // 5 seconds after receiving a retryable error and increase exponentially
 // after each error for no more than a total time of 1 minute.
 func WithRetry(settings RetryConfig) Option {
-	return wrappedOption{otlpconfig.WithRetry(retry.Config(settings))}
+	return wrappedOption{otlpconfig.WithRetry(settings.policy())}
+}
+
+// WithRetryPolicy sets the retry policy for transient errors returned by the
+// target endpoint when exporting a batch of spans. In addition to the settings of
+// WithRetry, the policy can limit the number of attempts, change how backoff
+// intervals are randomized, select which gRPC codes are retried, and stop
+// sending requests to an endpoint that keeps failing with a circuit breaker.
+//
+// This option and WithRetry both set the retry policy, the last one passed is
+// used.
+func WithRetryPolicy(p retry.Policy) Option {
+	return wrappedOption{otlpconfig.WithRetry(p)}
 }
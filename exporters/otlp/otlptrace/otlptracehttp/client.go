@@ -16,35 +16,38 @@ package otlptracehttp // import "go.opentelemetry.io/otel/exporters/otlp/otlptra
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
-	"strconv"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/internal"
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/tracesplit"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/internal/observ"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
-const contentTypeProto = "application/x-protobuf"
+const (
+	contentTypeProto = "application/x-protobuf"
+	contentTypeJSON  = "application/json"
+)
 
-var gzPool = sync.Pool{
-	New: func() interface{} {
-		w := gzip.NewWriter(io.Discard)
-		return w
-	},
+var ourDialer = &net.Dialer{
+	Timeout:   30 * time.Second,
+	KeepAlive: 30 * time.Second,
 }
 
 // Keep it in sync with golang's DefaultTransport from net/http! We
@@ -52,11 +55,8 @@ var gzPool = sync.Pool{
 // DefaultTransport is overwritten with some different implementation
 // of http.RoundTripper or it's modified by other package.
 var ourTransport = &http.Transport{
-	Proxy: http.ProxyFromEnvironment,
-	DialContext: (&net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-	}).DialContext,
+	Proxy:                 http.ProxyFromEnvironment,
+	DialContext:           ourDialer.DialContext,
 	ForceAttemptHTTP2:     true,
 	MaxIdleConns:          100,
 	IdleConnTimeout:       90 * time.Second,
@@ -69,6 +69,9 @@ type client struct {
 	cfg         otlpconfig.SignalConfig
 	generalCfg  otlpconfig.Config
 	requestFunc retry.RequestFunc
+	retryPolicy retry.Policy
+	observ      observ.Exporter
+	compressor  *compress.Compressor
 	client      *http.Client
 	stopCh      chan struct{}
 	stopOnce    sync.Once
@@ -87,6 +90,11 @@ func NewClient(opts ...Option) otlptrace.Client {
 	if cfg.Traces.TLSCfg != nil {
 		transport := ourTransport.Clone()
 		transport.TLSClientConfig = cfg.Traces.TLSCfg
+		if cfg.Traces.TLSReloader != nil {
+			// Use the current certificates for each new connection. Proxied
+			// connections use TLSClientConfig.
+			transport.DialTLSContext = cfg.Traces.TLSReloader.DialTLSContext(ourDialer)
+		}
 		httpClient.Transport = transport
 	}
 
@@ -95,7 +103,10 @@ func NewClient(opts ...Option) otlptrace.Client {
 		name:        "traces",
 		cfg:         cfg.Traces,
 		generalCfg:  cfg,
-		requestFunc: cfg.RetryConfig.RequestFunc(evaluate),
+		requestFunc: cfg.RetryPolicy.RequestFunc(retry.EvaluateHTTP),
+		retryPolicy: cfg.RetryPolicy,
+		observ:      observ.GetProvider().Exporter("go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp", "spans"),
+		compressor:  newCompressor(cfg.Traces.Compression.Name(), cfg.Traces.CompressionLevel),
 		stopCh:      stopCh,
 		client:      httpClient,
 	}
@@ -126,11 +137,24 @@ func (d *client) Stop(ctx context.Context) error {
 }
 
 // UploadTraces sends a batch of spans to the collector.
+//
+// If the batch is larger than the configured maximum request size it is
+// split and sent using multiple requests. The size of requests is measured
+// in the encoding they are sent with, before compression.
 func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	sizer := tracesplit.Protobuf
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		sizer = tracesplit.JSON(d.marshal)
+	}
+	return tracesplit.Export(ctx, protoSpans, d.cfg.MaxRequestSize, sizer, d.uploadTraces)
+}
+
+// uploadTraces sends protoSpans to the collector in a single request.
+func (d *client) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
 	pbRequest := &coltracepb.ExportTraceServiceRequest{
 		ResourceSpans: protoSpans,
 	}
-	rawRequest, err := proto.Marshal(pbRequest)
+	rawRequest, err := d.marshal(pbRequest)
 	if err != nil {
 		return err
 	}
@@ -143,7 +167,13 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		return err
 	}
 
-	return d.requestFunc(ctx, func(ctx context.Context) error {
+	var attempt int
+	err = d.requestFunc(ctx, func(ctx context.Context) error {
+		if attempt > 0 {
+			d.observ.Retry(ctx)
+		}
+		attempt++
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -151,9 +181,12 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 		}
 
 		request.reset(ctx)
+		if err := d.setProvidedHeaders(request.Request); err != nil {
+			return err
+		}
 		resp, err := d.client.Do(request.Request)
 		if err != nil {
-			return err
+			return retry.TransportError(err)
 		}
 
 		if resp != nil && resp.Body != nil {
@@ -164,8 +197,8 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			}()
 		}
 
-		switch resp.StatusCode {
-		case http.StatusOK:
+		switch {
+		case resp.StatusCode == http.StatusOK:
 			// Success, do not retry.
 			// Read the partial success message, if any.
 			var respData bytes.Buffer
@@ -175,7 +208,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 
 			if respData.Len() != 0 {
 				var respProto coltracepb.ExportTraceServiceResponse
-				if err := proto.Unmarshal(respData.Bytes(), &respProto); err != nil {
+				if err := d.unmarshal(respData.Bytes(), &respProto); err != nil {
 					return err
 				}
 
@@ -183,6 +216,7 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 					msg := respProto.PartialSuccess.GetErrorMessage()
 					n := respProto.PartialSuccess.GetRejectedSpans()
 					if n != 0 || msg != "" {
+						d.observ.Rejected(ctx, n)
 						err := internal.TracePartialSuccessError(n, msg)
 						otel.Handle(err)
 					}
@@ -190,16 +224,18 @@ func (d *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.Resourc
 			}
 			return nil
 
-		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
-			// Retry-able failures.  Drain the body to reuse the connection.
+		default:
+			// Drain the body to reuse the connection if the request is
+			// retried.
 			if _, err := io.Copy(io.Discard, resp.Body); err != nil {
 				otel.Handle(err)
 			}
-			return newResponseError(resp.Header)
-		default:
-			return fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+			err := fmt.Errorf("failed to send %s to %s: %s", d.name, request.URL, resp.Status)
+			return d.retryPolicy.HTTPResponseError(resp, err)
 		}
 	})
+	d.observ.Request(ctx, err)
+	return err
 }
 
 func (d *client) newRequest(body []byte) (request, error) {
@@ -214,36 +250,83 @@ func (d *client) newRequest(body []byte) (request, error) {
 	for k, v := range d.cfg.Headers {
 		r.Header.Set(k, v)
 	}
-	r.Header.Set("Content-Type", contentTypeProto)
+	if d.cfg.Marshaler == otlpconfig.MarshalJSON {
+		r.Header.Set("Content-Type", contentTypeJSON)
+	} else {
+		r.Header.Set("Content-Type", contentTypeProto)
+	}
 
 	req := request{Request: r}
-	switch Compression(d.cfg.Compression) {
-	case NoCompression:
+	if d.compressor == nil {
 		r.ContentLength = (int64)(len(body))
 		req.bodyReader = bodyReader(body)
-	case GzipCompression:
-		// Ensure the content length is not used.
-		r.ContentLength = -1
-		r.Header.Set("Content-Encoding", "gzip")
+		return req, nil
+	}
 
-		gz := gzPool.Get().(*gzip.Writer)
-		defer gzPool.Put(gz)
+	// Ensure the content length is not used.
+	r.ContentLength = -1
+	r.Header.Set("Content-Encoding", d.compressor.Name())
 
-		var b bytes.Buffer
-		gz.Reset(&b)
+	var b bytes.Buffer
+	if err := d.compressor.Compress(&b, body); err != nil {
+		return req, err
+	}
+	req.bodyReader = bodyReader(b.Bytes())
+	return req, nil
+}
 
-		if _, err := gz.Write(body); err != nil {
-			return req, err
-		}
-		// Close needs to be called to ensure body if fully written.
-		if err := gz.Close(); err != nil {
-			return req, err
-		}
+// newCompressor returns a Compressor for the encoding name at level, or nil
+// if name is empty. If level is invalid for the encoding, the default level is
+// used.
+func newCompressor(name string, level int) *compress.Compressor {
+	if name == "" {
+		return nil
+	}
+	c, err := compress.New(name, level)
+	if err != nil {
+		otel.Handle(fmt.Errorf("%w, using the default compression level", err))
+		c, _ = compress.New(name, compress.DefaultLevel)
+	}
+	return c
+}
 
-		req.bodyReader = bodyReader(b.Bytes())
+// setProvidedHeaders sets the headers returned by the client's header
+// provider, if any, on r.
+func (d *client) setProvidedHeaders(r *http.Request) error {
+	if d.cfg.HeaderProvider == nil {
+		return nil
+	}
+	h, err := d.cfg.HeaderProvider(r.Context())
+	if err != nil {
+		return fmt.Errorf("header provider: %w", err)
 	}
+	for k, v := range h {
+		r.Header.Set(k, v)
+	}
+	return nil
+}
 
-	return req, nil
+// marshal encodes m using the protocol the client is configured with.
+func (d *client) marshal(m proto.Message) ([]byte, error) {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return proto.Marshal(m)
+	}
+	// The OTLP/JSON mapping requires enum values be encoded as integers and
+	// trace and span IDs be hex encoded.
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return internal.JSONIDsToHex(b)
+}
+
+// unmarshal decodes b into m using the protocol the client is configured
+// with.
+func (d *client) unmarshal(b []byte, m proto.Message) error {
+	if d.cfg.Marshaler != otlpconfig.MarshalJSON {
+		return proto.Unmarshal(b, m)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
 }
 
 // MarshalLog is the marshaling function used by the logging system to represent this Client.
@@ -280,42 +363,6 @@ func (r *request) reset(ctx context.Context) {
 	r.Request = r.Request.WithContext(ctx)
 }
 
-// retryableError represents a request failure that can be retried.
-type retryableError struct {
-	throttle int64
-}
-
-// newResponseError returns a retryableError and will extract any explicit
-// throttle delay contained in headers.
-func newResponseError(header http.Header) error {
-	var rErr retryableError
-	if s, ok := header["Retry-After"]; ok {
-		if t, err := strconv.ParseInt(s[0], 10, 64); err == nil {
-			rErr.throttle = t
-		}
-	}
-	return rErr
-}
-
-func (e retryableError) Error() string {
-	return "retry-able request failure"
-}
-
-// evaluate returns if err is retry-able. If it is and it includes an explicit
-// throttling delay, that delay is also returned.
-func evaluate(err error) (bool, time.Duration) {
-	if err == nil {
-		return false, 0
-	}
-
-	rErr, ok := err.(retryableError)
-	if !ok {
-		return false, 0
-	}
-
-	return true, time.Duration(rErr.throttle)
-}
-
 func (d *client) getScheme() string {
 	if d.cfg.Insecure {
 		return "http"
@@ -17,8 +17,12 @@ package otlptracehttp_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +33,9 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/internal/observ/observtest"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 )
 
@@ -80,6 +87,26 @@ func TestEndToEnd(t *testing.T) {
 				InjectHTTPStatus: []int{503, 429},
 			},
 		},
+		{
+			name: "with zstd compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.ZstdCompression),
+				otlptracehttp.WithCompressionLevel(19),
+			},
+		},
+		{
+			name: "with snappy compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.SnappyCompression),
+			},
+		},
+		{
+			name: "with invalid compression level",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+				otlptracehttp.WithCompressionLevel(100),
+			},
+		},
 		{
 			name: "retry with gzip compression",
 			opts: []otlptracehttp.Option{
@@ -110,7 +137,7 @@ func TestEndToEnd(t *testing.T) {
 			mcCfg: mockCollectorConfig{
 				InjectHTTPStatus: []int{503},
 				InjectResponseHeader: []map[string]string{
-					{"Retry-After": "10"},
+					{"Retry-After": "1"},
 				},
 			},
 		},
@@ -129,6 +156,19 @@ func TestEndToEnd(t *testing.T) {
 				TracesURLPath: otherTracesPath,
 			},
 		},
+		{
+			name: "with JSON protocol",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithProtocol(otlptracehttp.JSONProtocol),
+			},
+		},
+		{
+			name: "with JSON protocol and gzip compression",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithProtocol(otlptracehttp.JSONProtocol),
+				otlptracehttp.WithCompression(otlptracehttp.GzipCompression),
+			},
+		},
 		{
 			name: "with TLS",
 			opts: nil,
@@ -146,6 +186,18 @@ func TestEndToEnd(t *testing.T) {
 				ExpectedHeaders: testHeaders,
 			},
 		},
+		{
+			name: "with header provider",
+			opts: []otlptracehttp.Option{
+				otlptracehttp.WithHeaders(map[string]string{"Otel-Go-Key-1": "static"}),
+				otlptracehttp.WithHeaderProvider(func(context.Context) (map[string]string, error) {
+					return testHeaders, nil
+				}),
+			},
+			mcCfg: mockCollectorConfig{
+				ExpectedHeaders: testHeaders,
+			},
+		},
 		{
 			name: "with custom user agent",
 			opts: []otlptracehttp.Option{
@@ -250,6 +302,88 @@ func TestNoRetry(t *testing.T) {
 	assert.Empty(t, mc.GetSpans())
 }
 
+func TestEnvironmentCertificateReload(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{WithTLS: true})
+	defer mc.MustStop(t)
+
+	other, err := generateWeakCertificate()
+	require.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, other.Certificate, 0o600))
+	t.Setenv("OTEL_EXPORTER_OTLP_CERTIFICATE", caFile)
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	// The collector certificate is not signed by the configured CA.
+	assert.Error(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Empty(t, mc.GetSpans())
+
+	// Updating the CA file is picked up without creating a new client.
+	require.NoError(t, os.WriteFile(caFile, mc.ServerCertificate(), 0o600))
+	assert.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
+}
+
+func TestMaxRequestSize(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithMaxRequestSize(200),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	spans := make(tracetest.SpanStubs, 10)
+	for i := range spans {
+		spans[i].Name = fmt.Sprintf("span-%d-with-a-name-long-enough-to-fill-requests", i)
+	}
+	require.NoError(t, exporter.ExportSpans(ctx, spans.Snapshots()))
+	assert.Greater(t, mc.Requests(), 1, "request not split")
+	assert.Len(t, mc.GetSpans(), len(spans))
+}
+
+func TestMaxRequestSizeJSON(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
+	defer mc.MustStop(t)
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithProtocol(otlptracehttp.JSONProtocol),
+		otlptracehttp.WithMaxRequestSize(1000),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	// The spans fit in a single request when encoded with protobuf, but not
+	// when encoded with JSON.
+	spans := make(tracetest.SpanStubs, 10)
+	for i := range spans {
+		spans[i].Name = fmt.Sprintf("span-%d-with-a-name-long-enough-to-fill-requests", i)
+	}
+	require.NoError(t, exporter.ExportSpans(ctx, spans.Snapshots()))
+	assert.Greater(t, mc.Requests(), 1, "request not split by JSON size")
+	assert.Len(t, mc.GetSpans(), len(spans))
+}
+
 func TestEmptyData(t *testing.T) {
 	mcCfg := mockCollectorConfig{}
 	mc := runMockCollector(t, mcCfg)
@@ -365,33 +499,163 @@ func TestStopWhileExporting(t *testing.T) {
 }
 
 func TestPartialSuccess(t *testing.T) {
-	mcCfg := mockCollectorConfig{
-		Partial: &coltracepb.ExportTracePartialSuccess{
-			RejectedSpans: 2,
-			ErrorMessage:  "partially successful",
-		},
+	t.Run("Protobuf", testPartialSuccess(otlptracehttp.ProtobufProtocol))
+	t.Run("JSON", testPartialSuccess(otlptracehttp.JSONProtocol))
+}
+
+func testPartialSuccess(protocol otlptracehttp.Protocol) func(*testing.T) {
+	return func(t *testing.T) {
+		mcCfg := mockCollectorConfig{
+			Partial: &coltracepb.ExportTracePartialSuccess{
+				RejectedSpans: 2,
+				ErrorMessage:  "partially successful",
+			},
+		}
+		mc := runMockCollector(t, mcCfg)
+		defer mc.MustStop(t)
+		driver := otlptracehttp.NewClient(
+			otlptracehttp.WithEndpoint(mc.Endpoint()),
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithProtocol(protocol),
+		)
+		ctx := context.Background()
+		exporter, err := otlptrace.New(ctx, driver)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, exporter.Shutdown(context.Background()))
+		}()
+
+		errors := []error{}
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			errors = append(errors, err)
+		}))
+		err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+		assert.NoError(t, err)
+
+		require.Equal(t, 1, len(errors))
+		require.Contains(t, errors[0].Error(), "partially successful")
+		require.Contains(t, errors[0].Error(), "2 spans rejected")
 	}
-	mc := runMockCollector(t, mcCfg)
+}
+
+func TestJSONProtocolEncoding(t *testing.T) {
+	var contentType string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	driver := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(strings.TrimPrefix(srv.URL, "http://")),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithProtocol(otlptracehttp.JSONProtocol),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Contains(t, string(body), `"traceId":"02030405060708090203040506070809"`)
+	assert.Contains(t, string(body), `"spanId":"0304050607080900"`)
+	assert.Contains(t, string(body), `"parentSpanId":"0102030405060708"`)
+	// Enums are encoded as integers.
+	assert.Contains(t, string(body), `"kind":1`)
+	assert.Contains(t, string(body), `"startTimeUnixNano":"`)
+}
+
+func TestHeaderProviderErrorAbortsExport(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{})
 	defer mc.MustStop(t)
 	driver := otlptracehttp.NewClient(
 		otlptracehttp.WithEndpoint(mc.Endpoint()),
 		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithHeaderProvider(func(context.Context) (map[string]string, error) {
+			return nil, assert.AnError
+		}),
 	)
 	ctx := context.Background()
 	exporter, err := otlptrace.New(ctx, driver)
 	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	assert.ErrorIs(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()), assert.AnError)
+	assert.Len(t, mc.GetSpans(), 0)
+}
+
+func TestObserv(t *testing.T) {
+	r := observtest.Install(t)
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{503},
+		Partial: &coltracepb.ExportTracePartialSuccess{
+			RejectedSpans: 2,
+		},
+	})
+	defer mc.MustStop(t)
+
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+			Enabled:         true,
+			InitialInterval: time.Nanosecond,
+			MaxInterval:     time.Nanosecond,
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(context.Background()))
 	}()
 
-	errors := []error{}
-	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
-		errors = append(errors, err)
-	}))
-	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
-	assert.NoError(t, err)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+	require.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
 
-	require.Equal(t, 1, len(errors))
-	require.Contains(t, errors[0].Error(), "partially successful")
-	require.Contains(t, errors[0].Error(), "2 spans rejected")
+	assert.Equal(t, [][2]string{{"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp", "spans"}}, r.Exporters())
+	assert.Equal(t, observtest.Counts{
+		Requests: 1,
+		Retries:  1,
+		Rejected: 2,
+	}, r.Counts())
+}
+
+func TestRetryPolicy(t *testing.T) {
+	mc := runMockCollector(t, mockCollectorConfig{
+		InjectHTTPStatus: []int{
+			http.StatusInternalServerError,
+			http.StatusInternalServerError,
+		},
+	})
+	defer mc.MustStop(t)
+	client := otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(mc.Endpoint()),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetryPolicy(retry.Policy{
+			Enabled:             true,
+			InitialInterval:     time.Nanosecond,
+			MaxAttempts:         2,
+			RetryableHTTPStatus: func(s int) bool { return s == http.StatusInternalServerError },
+		}),
+	)
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	err = exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan())
+	assert.ErrorContains(t, err, "max retry attempts reached")
+	assert.NoError(t, exporter.ExportSpans(ctx, otlptracetest.SingleReadOnlySpan()))
+	assert.Len(t, mc.GetSpans(), 1)
 }
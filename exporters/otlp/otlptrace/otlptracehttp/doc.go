@@ -14,6 +14,6 @@
 
 /*
 Package otlptracehttp a client that sends traces to the collector using HTTP
-with binary protobuf payloads.
+with binary protobuf or OTLP/JSON payloads.
 */
 package otlptracehttp // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
@@ -16,7 +16,6 @@ package otlptracehttp_test
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -28,8 +27,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"go.opentelemetry.io/otel/exporters/otlp/internal"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/compress"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlptracetest"
 	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
@@ -42,6 +44,7 @@ type mockCollector struct {
 
 	spanLock     sync.Mutex
 	spansStorage otlptracetest.SpansStorage
+	requests     int
 
 	injectHTTPStatus     []int
 	injectResponseHeader []map[string]string
@@ -49,8 +52,9 @@ type mockCollector struct {
 	partial              *collectortracepb.ExportTracePartialSuccess
 	delay                <-chan struct{}
 
-	clientTLSConfig *tls.Config
-	expectedHeaders map[string]string
+	clientTLSConfig   *tls.Config
+	serverCertificate []byte
+	expectedHeaders   map[string]string
 }
 
 func (c *mockCollector) Stop() error {
@@ -73,6 +77,13 @@ func (c *mockCollector) GetResourceSpans() []*tracepb.ResourceSpans {
 	return c.spansStorage.GetResourceSpans()
 }
 
+// Requests returns the number of export requests stored.
+func (c *mockCollector) Requests() int {
+	c.spanLock.Lock()
+	defer c.spanLock.Unlock()
+	return c.requests
+}
+
 func (c *mockCollector) Endpoint() string {
 	return c.endpoint
 }
@@ -81,6 +92,12 @@ func (c *mockCollector) ClientTLSConfig() *tls.Config {
 	return c.clientTLSConfig
 }
 
+// ServerCertificate returns the PEM encoded self-signed certificate of the
+// collector when it is run with TLS.
+func (c *mockCollector) ServerCertificate() []byte {
+	return c.serverCertificate
+}
+
 func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	if c.delay != nil {
 		select {
@@ -97,7 +114,7 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	response := collectortracepb.ExportTraceServiceResponse{
 		PartialSuccess: c.partial,
 	}
-	rawResponse, err := proto.Marshal(&response)
+	rawResponse, err := marshalTraceResponse(&response, r.Header.Get("content-type"))
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -121,16 +138,30 @@ func (c *mockCollector) serveTraces(w http.ResponseWriter, r *http.Request) {
 	writeReply(w, rawResponse, 0, c.injectContentType, h)
 	c.spanLock.Lock()
 	defer c.spanLock.Unlock()
+	c.requests++
 	c.spansStorage.AddSpans(request)
 }
 
+func marshalTraceResponse(response *collectortracepb.ExportTraceServiceResponse, contentType string) ([]byte, error) {
+	if contentType == "application/json" {
+		return protojson.Marshal(response)
+	}
+	return proto.Marshal(response)
+}
+
 func unmarshalTraceRequest(rawRequest []byte, contentType string) (*collectortracepb.ExportTraceServiceRequest, error) {
 	request := &collectortracepb.ExportTraceServiceRequest{}
-	if contentType != "application/x-protobuf" {
-		return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf is supported", contentType)
+	switch contentType {
+	case "application/x-protobuf":
+		return request, proto.Unmarshal(rawRequest, request)
+	case "application/json":
+		rawRequest, err := internal.JSONIDsToBase64(rawRequest)
+		if err != nil {
+			return request, err
+		}
+		return request, protojson.Unmarshal(rawRequest, request)
 	}
-	err := proto.Unmarshal(rawRequest, request)
-	return request, err
+	return request, fmt.Errorf("invalid content-type: %s, only application/x-protobuf and application/json are supported", contentType)
 }
 
 func (c *mockCollector) checkHeaders(r *http.Request) bool {
@@ -167,20 +198,20 @@ func (c *mockCollector) getInjectResponseHeader() (h map[string]string) {
 }
 
 func readRequest(r *http.Request) ([]byte, error) {
-	if r.Header.Get("Content-Encoding") == "gzip" {
-		return readGzipBody(r.Body)
+	if enc := r.Header.Get("Content-Encoding"); enc != "" {
+		return readCompressedBody(enc, r.Body)
 	}
 	return io.ReadAll(r.Body)
 }
 
-func readGzipBody(body io.Reader) ([]byte, error) {
+func readCompressedBody(encoding string, body io.Reader) ([]byte, error) {
 	rawRequest := bytes.Buffer{}
-	gunzipper, err := gzip.NewReader(body)
+	decompressor, err := compress.NewReader(encoding, body)
 	if err != nil {
 		return nil, err
 	}
-	defer gunzipper.Close()
-	_, err = io.Copy(&rawRequest, gunzipper)
+	defer decompressor.Close()
+	_, err = io.Copy(&rawRequest, decompressor)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +286,7 @@ func runMockCollector(t *testing.T, cfg mockCollectorConfig) *mockCollector {
 		m.clientTLSConfig = &tls.Config{
 			InsecureSkipVerify: true,
 		}
+		m.serverCertificate = pem.Certificate
 	}
 	go func() {
 		if cfg.WithTLS {
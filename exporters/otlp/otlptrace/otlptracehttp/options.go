@@ -15,11 +15,12 @@
 package otlptracehttp // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 
 import (
+	"context"
 	"crypto/tls"
 	"time"
 
-	"go.opentelemetry.io/otel/exporters/otlp/internal/retry"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/retry"
 )
 
 // Compression describes the compression used for payloads sent to the
@@ -33,8 +34,33 @@ const (
 	// GzipCompression tells the driver to send payloads after
 	// compressing them with gzip.
 	GzipCompression = Compression(otlpconfig.GzipCompression)
+	// ZstdCompression tells the driver to send payloads after
+	// compressing them with zstd.
+	ZstdCompression = Compression(otlpconfig.ZstdCompression)
+	// SnappyCompression tells the driver to send payloads after
+	// compressing them with snappy, using the framed format.
+	SnappyCompression = Compression(otlpconfig.SnappyCompression)
 )
 
+// Protocol describes the encoding of the payloads sent to the collector.
+type Protocol otlpconfig.Marshaler
+
+const (
+	// ProtobufProtocol tells the driver to send payloads encoded using the
+	// protobuf binary format ("http/protobuf").
+	ProtobufProtocol = Protocol(otlpconfig.MarshalProto)
+	// JSONProtocol tells the driver to send payloads encoded using the
+	// OTLP/JSON format ("http/json").
+	JSONProtocol = Protocol(otlpconfig.MarshalJSON)
+)
+
+// HeaderProvider returns headers to send with an export request. It is
+// called with the context of the export for every attempt to send the
+// request, including retries, allowing short-lived credentials or values
+// taken from the context to be used. If an error is returned, the export is
+// aborted and the error is returned from the export.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
 // Option applies an option to the HTTP client.
 type Option interface {
 	applyHTTPOption(otlpconfig.Config) otlpconfig.Config
@@ -50,7 +76,31 @@ func asHTTPOptions(opts []Option) []otlpconfig.HTTPOption {
 
 // RetryConfig defines configuration for retrying batches in case of export
 // failure using an exponential backoff.
-type RetryConfig retry.Config
+type RetryConfig struct {
+	// Enabled indicates whether to not retry sending batches in case of
+	// export failure.
+	Enabled bool
+	// InitialInterval the time to wait after the first failure before
+	// retrying.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on backoff interval. Once this value is
+	// reached the delay between consecutive retries will always be
+	// `MaxInterval`.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request/batch.  Once this value is reached, the data
+	// is discarded.
+	MaxElapsedTime time.Duration
+}
+
+func (c RetryConfig) policy() retry.Policy {
+	return retry.Policy{
+		Enabled:         c.Enabled,
+		InitialInterval: c.InitialInterval,
+		MaxInterval:     c.MaxInterval,
+		MaxElapsedTime:  c.MaxElapsedTime,
+	}
+}
 
 type wrappedOption struct {
 	otlpconfig.HTTPOption
@@ -74,6 +124,35 @@ func WithCompression(compression Compression) Option {
 	return wrappedOption{otlpconfig.WithCompression(otlpconfig.Compression(compression))}
 }
 
+// WithCompressionLevel sets the level the compression set with
+// WithCompression compresses payloads at. The meaning of level depends on the
+// compression:
+//
+//   - GzipCompression: 1 (best speed) through 9 (best compression), or -2 for
+//     Huffman only compression.
+//   - ZstdCompression: 1 (fastest) through 22 (best compression). Levels are
+//     mapped to the closest level the encoder supports.
+//   - SnappyCompression: ignored, snappy has no compression levels.
+//
+// If level is invalid for the compression, the default level is used and an
+// error is sent to the global ErrorHandler.
+//
+// By default, if this option is not passed, or level is zero, the default
+// level of the compression is used.
+//
+// The gRPC exporters have no equivalent option, they always compress at the
+// default level.
+func WithCompressionLevel(level int) Option {
+	return wrappedOption{otlpconfig.WithCompressionLevel(level)}
+}
+
+// WithProtocol tells the driver which encoding to use for the sent data. If
+// unset, the OTEL_EXPORTER_OTLP_PROTOCOL and OTEL_EXPORTER_OTLP_TRACES_PROTOCOL
+// environment variables are used, otherwise ProtobufProtocol is used.
+func WithProtocol(protocol Protocol) Option {
+	return wrappedOption{otlpconfig.WithMarshaler(otlpconfig.Marshaler(protocol))}
+}
+
 // WithURLPath allows one to override the default URL path used
 // for sending traces. If unset, default ("/v1/traces") will be used.
 func WithURLPath(urlPath string) Option {
@@ -83,6 +162,11 @@ func WithURLPath(urlPath string) Option {
 // WithTLSClientConfig can be used to set up a custom TLS
 // configuration for the client used to send payloads to the
 // collector. Use it if you want to use a custom certificate.
+//
+// Client certificates that are rotated can be provided with the
+// GetClientCertificate field of tlsCfg. Certificate files configured with
+// environment variables are reloaded when they change if this option is not
+// passed.
 func WithTLSClientConfig(tlsCfg *tls.Config) Option {
 	return wrappedOption{otlpconfig.WithTLSClientConfig(tlsCfg)}
 }
@@ -100,6 +184,29 @@ func WithHeaders(headers map[string]string) Option {
 	return wrappedOption{otlpconfig.WithHeaders(headers)}
 }
 
+// WithHeaderProvider sets a HeaderProvider that is called to get headers
+// added to the HTTP headers of each export request. Headers returned by
+// the provider take precedence over the headers set with WithHeaders.
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return wrappedOption{otlpconfig.WithHeaderProvider(provider)}
+}
+
+// WithMaxRequestSize sets the maximum size, in bytes, of the body of export
+// requests sent to the endpoint before it is compressed. The size is
+// measured in the encoding set with WithProtocol. Measuring the size of the
+// JSONProtocol encoding requires each span to be encoded an extra time. A
+// batch of spans that encodes larger than size is split into multiple
+// requests. Resource and instrumentation scope grouping is preserved in each
+// request, and each request is retried independently.
+//
+// A single span larger than size cannot be split and is sent in a request
+// of its own.
+//
+// If unset or less than or equal to zero, requests are not split.
+func WithMaxRequestSize(size int) Option {
+	return wrappedOption{otlpconfig.WithMaxRequestSize(size)}
+}
+
 // WithTimeout tells the driver the max waiting time for the backend to process
 // each spans batch.  If unset, the default will be 10 seconds.
 func WithTimeout(duration time.Duration) Option {
@@ -112,5 +219,17 @@ func WithTimeout(duration time.Duration) Option {
 // policy will retry after 5 seconds and increase exponentially after each
 // error for a total of 1 minute.
 func WithRetry(rc RetryConfig) Option {
-	return wrappedOption{otlpconfig.WithRetry(retry.Config(rc))}
+	return wrappedOption{otlpconfig.WithRetry(rc.policy())}
+}
+
+// WithRetryPolicy sets the retry policy for transient errors returned by the
+// target endpoint when exporting traces. In addition to the settings of
+// WithRetry, the policy can limit the number of attempts, change how backoff
+// intervals are randomized, select which HTTP status codes are retried, and stop
+// sending requests to an endpoint that keeps failing with a circuit breaker.
+//
+// This option and WithRetry both set the retry policy, the last one passed is
+// used.
+func WithRetryPolicy(p retry.Policy) Option {
+	return wrappedOption{otlpconfig.WithRetry(p)}
 }
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracemulti // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracemulti"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+var errNoClients = errors.New("no clients provided")
+
+// endpoint is a Client and the health of the endpoint it exports to.
+type endpoint struct {
+	client otlptrace.Client
+
+	mu             sync.Mutex
+	unhealthyUntil time.Time
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !now.Before(e.unhealthyUntil)
+}
+
+func (e *endpoint) setUnhealthyUntil(t time.Time) {
+	e.mu.Lock()
+	e.unhealthyUntil = t
+	e.mu.Unlock()
+}
+
+type client struct {
+	endpoints       []*endpoint
+	strategy        Strategy
+	unhealthyPeriod time.Duration
+
+	// next is the index of the endpoint the next round-robin export starts
+	// with.
+	next uint64
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// Compile time check *client implements otlptrace.Client.
+var _ otlptrace.Client = (*client)(nil)
+
+// NewClient returns an otlptrace.Client that exports to the endpoints of
+// clients. The clients are started and stopped with the returned Client, and
+// must not be used by anything else.
+//
+// The retries and timeout of each of the clients delay failing over to the
+// next endpoint. They should be disabled or kept short.
+//
+// If clients is empty, all exports fail.
+func NewClient(clients []otlptrace.Client, options ...Option) otlptrace.Client {
+	cfg := newConfig(options)
+	c := &client{
+		endpoints:       make([]*endpoint, len(clients)),
+		strategy:        cfg.strategy,
+		unhealthyPeriod: cfg.unhealthyPeriod,
+		now:             time.Now,
+	}
+	for i, cl := range clients {
+		c.endpoints[i] = &endpoint{client: cl}
+	}
+	return c
+}
+
+// Start starts all the clients concurrently. An error is returned if any client fails to
+// start.
+func (c *client) Start(ctx context.Context) error {
+	return c.each(func(cl otlptrace.Client) error { return cl.Start(ctx) })
+}
+
+// Stop stops all the clients concurrently. An error is returned if any client
+// fails to stop.
+func (c *client) Stop(ctx context.Context) error {
+	return c.each(func(cl otlptrace.Client) error { return cl.Stop(ctx) })
+}
+
+// each calls f concurrently for every client and returns the errors
+// returned, if any, combined.
+func (c *client) each(f func(otlptrace.Client) error) error {
+	errs := make([]error, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, cl otlptrace.Client) {
+			defer wg.Done()
+			errs[i] = f(cl)
+		}(i, e.client)
+	}
+	wg.Wait()
+	return combine(errs, "clients")
+}
+
+// UploadTraces sends protoSpans to one or more endpoints based on the
+// Strategy the client is configured with.
+func (c *client) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	n := len(c.endpoints)
+	if n == 0 {
+		return errNoClients
+	}
+
+	switch c.strategy {
+	case RoundRobin:
+		start := int((atomic.AddUint64(&c.next, 1) - 1) % uint64(n))
+		return c.upload(ctx, start, protoSpans)
+	case TraceIDHash:
+		if n == 1 {
+			return c.upload(ctx, 0, protoSpans)
+		}
+		parts := partition(protoSpans, n)
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i, p := range parts {
+			if len(p) == 0 {
+				continue
+			}
+			wg.Add(1)
+			go func(i int, p []*tracepb.ResourceSpans) {
+				defer wg.Done()
+				errs[i] = c.upload(ctx, i, p)
+			}(i, p)
+		}
+		wg.Wait()
+		return combine(errs, "export requests")
+	default:
+		return c.upload(ctx, 0, protoSpans)
+	}
+}
+
+// upload sends protoSpans to the first healthy endpoint starting from the
+// endpoint at index start. If the upload fails, the endpoint is marked
+// unhealthy and the next endpoint is tried. Unhealthy endpoints are tried
+// after all healthy endpoints have failed. If ctx is done, no other endpoint
+// is tried and the endpoint is not marked unhealthy.
+func (c *client) upload(ctx context.Context, start int, protoSpans []*tracepb.ResourceSpans) error {
+	n := len(c.endpoints)
+	now := c.now()
+	order := make([]*endpoint, 0, n)
+	var unhealthy []*endpoint
+	for i := 0; i < n; i++ {
+		e := c.endpoints[(start+i)%n]
+		if e.healthy(now) {
+			order = append(order, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	order = append(order, unhealthy...)
+
+	var err error
+	for i, e := range order {
+		err = e.client.UploadTraces(ctx, protoSpans)
+		if err == nil {
+			e.setUnhealthyUntil(time.Time{})
+			return nil
+		}
+		if ctx.Err() != nil {
+			// The export has been canceled or timed out by the caller. This
+			// says nothing about the health of the endpoint, and other
+			// endpoints cannot be tried either.
+			return fmt.Errorf("%d of %d endpoints failed: %w", i+1, n, err)
+		}
+		e.setUnhealthyUntil(c.now().Add(c.unhealthyPeriod))
+	}
+	return fmt.Errorf("all %d endpoints failed, last error: %w", n, err)
+}
+
+// combine returns nil if all errs are nil, otherwise it returns an error
+// wrapping the first error that describes how many of the things errs
+// correspond with failed.
+func combine(errs []error, things string) error {
+	var (
+		first  error
+		failed int
+	)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		failed++
+	}
+	switch failed {
+	case 0:
+		return nil
+	case 1:
+		return first
+	default:
+		return fmt.Errorf("%d of %d %s failed: %w", failed, len(errs), things, first)
+	}
+}
+
+// partition splits rss into n groups based on the trace ID of each span.
+// All spans of a trace are in the same group. The resource and
+// instrumentation scope grouping of spans is preserved in each group.
+func partition(rss []*tracepb.ResourceSpans, n int) [][]*tracepb.ResourceSpans {
+	parts := make([][]*tracepb.ResourceSpans, n)
+	for _, rs := range rss {
+		rsParts := make([]*tracepb.ResourceSpans, n)
+		for _, ss := range rs.ScopeSpans {
+			ssParts := make([]*tracepb.ScopeSpans, n)
+			for _, s := range ss.Spans {
+				i := index(s.TraceId, n)
+				if ssParts[i] == nil {
+					ssParts[i] = &tracepb.ScopeSpans{
+						Scope:     ss.Scope,
+						SchemaUrl: ss.SchemaUrl,
+					}
+					if rsParts[i] == nil {
+						rsParts[i] = &tracepb.ResourceSpans{
+							Resource:  rs.Resource,
+							SchemaUrl: rs.SchemaUrl,
+						}
+					}
+					rsParts[i].ScopeSpans = append(rsParts[i].ScopeSpans, ssParts[i])
+				}
+				ssParts[i].Spans = append(ssParts[i].Spans, s)
+			}
+		}
+		for i, p := range rsParts {
+			if p != nil {
+				parts[i] = append(parts[i], p)
+			}
+		}
+	}
+	return parts
+}
+
+// index returns the index of the group in [0, n) spans with traceID belong
+// to. The low 8 bytes of the trace ID are used as they are random for W3C
+// trace IDs.
+func index(traceID []byte, n int) int {
+	if len(traceID) < 8 {
+		return 0
+	}
+	h := binary.BigEndian.Uint64(traceID[len(traceID)-8:])
+	return int(h % uint64(n))
+}
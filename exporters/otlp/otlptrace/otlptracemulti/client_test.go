@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracemulti
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+type fakeClient struct {
+	startErr, stopErr error
+
+	mu       sync.Mutex
+	err      error
+	uploads  int
+	traceIDs map[string]struct{}
+}
+
+func (c *fakeClient) Start(context.Context) error { return c.startErr }
+func (c *fakeClient) Stop(context.Context) error  { return c.stopErr }
+
+func (c *fakeClient) UploadTraces(_ context.Context, rss []*tracepb.ResourceSpans) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.uploads++
+	if c.traceIDs == nil {
+		c.traceIDs = make(map[string]struct{})
+	}
+	for _, rs := range rss {
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				c.traceIDs[string(s.TraceId)] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *fakeClient) setErr(err error) {
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+}
+
+func (c *fakeClient) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.uploads
+}
+
+func newTestClient(opts ...Option) (*client, []*fakeClient, *time.Time) {
+	fakes := []*fakeClient{{}, {}, {}}
+	now := time.Unix(0, 0)
+	c := NewClient([]otlptrace.Client{fakes[0], fakes[1], fakes[2]}, opts...).(*client)
+	c.now = func() time.Time { return now }
+	return c, fakes, &now
+}
+
+func spans(traceIDs ...byte) []*tracepb.ResourceSpans {
+	ss := &tracepb.ScopeSpans{}
+	for _, id := range traceIDs {
+		ss.Spans = append(ss.Spans, &tracepb.Span{
+			TraceId: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, id},
+		})
+	}
+	return []*tracepb.ResourceSpans{{ScopeSpans: []*tracepb.ScopeSpans{ss}}}
+}
+
+func TestFailover(t *testing.T) {
+	c, fakes, now := newTestClient(WithUnhealthyPeriod(time.Minute))
+	ctx := context.Background()
+
+	require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	assert.Equal(t, 1, fakes[0].count())
+
+	fakes[0].setErr(assert.AnError)
+	require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	assert.Equal(t, 1, fakes[1].count())
+
+	// The failed endpoint is skipped while unhealthy, even if it recovered.
+	fakes[0].setErr(nil)
+	require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	assert.Equal(t, 1, fakes[0].count())
+	assert.Equal(t, 2, fakes[1].count())
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	assert.Equal(t, 2, fakes[0].count())
+}
+
+func TestAllUnhealthy(t *testing.T) {
+	c, fakes, _ := newTestClient()
+	ctx := context.Background()
+
+	for _, f := range fakes {
+		f.setErr(assert.AnError)
+	}
+	err := c.UploadTraces(ctx, spans(1))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "all 3 endpoints failed")
+
+	// Unhealthy endpoints are still tried when no endpoint is healthy.
+	fakes[2].setErr(nil)
+	require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	assert.Equal(t, 1, fakes[2].count())
+}
+
+func TestCanceledContextStopsFailover(t *testing.T) {
+	c, fakes, _ := newTestClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fakes[0].setErr(context.Canceled)
+	assert.ErrorIs(t, c.UploadTraces(ctx, spans(1)), context.Canceled)
+	assert.Equal(t, 0, fakes[1].count())
+
+	// The endpoint is not marked unhealthy because the caller canceled.
+	fakes[0].setErr(nil)
+	require.NoError(t, c.UploadTraces(context.Background(), spans(1)))
+	assert.Equal(t, 1, fakes[0].count())
+	assert.Equal(t, 0, fakes[1].count())
+}
+
+func TestRoundRobin(t *testing.T) {
+	c, fakes, _ := newTestClient(WithStrategy(RoundRobin))
+	ctx := context.Background()
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	}
+	for _, f := range fakes {
+		assert.Equal(t, 2, f.count())
+	}
+
+	fakes[1].setErr(assert.AnError)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.UploadTraces(ctx, spans(1)))
+	}
+	assert.Equal(t, 7, fakes[0].count()+fakes[2].count())
+	assert.Equal(t, 2, fakes[1].count())
+}
+
+func TestTraceIDHash(t *testing.T) {
+	c, fakes, _ := newTestClient(WithStrategy(TraceIDHash))
+	ctx := context.Background()
+
+	require.NoError(t, c.UploadTraces(ctx, spans(0, 1, 2, 3, 4, 5, 3)))
+	for i, f := range fakes {
+		assert.Equal(t, 1, f.count())
+		for id := range f.traceIDs {
+			assert.Equal(t, i, int(id[15])%3, "trace %x sent to endpoint %d", id, i)
+		}
+	}
+
+	// Traces of a failed endpoint go to the next endpoint.
+	fakes[1].setErr(assert.AnError)
+	require.NoError(t, c.UploadTraces(ctx, spans(1, 4)))
+	assert.Equal(t, 2, fakes[2].count())
+	assert.Equal(t, 1, fakes[0].count())
+}
+
+func TestPartitionPreservesGrouping(t *testing.T) {
+	rss := spans(0, 1, 2, 3)
+	rss = append(rss, spans(4)...)
+	parts := partition(rss, 2)
+	require.Len(t, parts, 2)
+
+	assert.Len(t, parts[0], 2, "spans from both resources")
+	assert.Len(t, parts[0][0].ScopeSpans[0].Spans, 2)
+	assert.Len(t, parts[0][1].ScopeSpans[0].Spans, 1)
+	assert.Len(t, parts[1], 1, "spans from the first resource")
+	assert.Len(t, parts[1][0].ScopeSpans[0].Spans, 2)
+}
+
+func TestStartStop(t *testing.T) {
+	errStart, errStop := errors.New("start"), errors.New("stop")
+	ctx := context.Background()
+
+	c := NewClient([]otlptrace.Client{&fakeClient{}, &fakeClient{}})
+	assert.NoError(t, c.Start(ctx))
+	assert.NoError(t, c.Stop(ctx))
+
+	c = NewClient([]otlptrace.Client{&fakeClient{startErr: errStart}, &fakeClient{stopErr: errStop}})
+	assert.ErrorIs(t, c.Start(ctx), errStart)
+	assert.ErrorIs(t, c.Stop(ctx), errStop)
+
+	c = NewClient([]otlptrace.Client{&fakeClient{startErr: errStart}, &fakeClient{startErr: errStart}})
+	assert.ErrorContains(t, c.Start(ctx), "2 of 2 clients failed")
+}
+
+func TestNoClients(t *testing.T) {
+	c := NewClient(nil)
+	ctx := context.Background()
+	assert.NoError(t, c.Start(ctx))
+	assert.ErrorIs(t, c.UploadTraces(ctx, spans(1)), errNoClients)
+	assert.NoError(t, c.Stop(ctx))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracemulti // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracemulti"
+
+import "time"
+
+// Strategy determines how exports are distributed across endpoints.
+type Strategy int
+
+const (
+	// Failover sends all exports to the first healthy endpoint, in the order
+	// the endpoints were provided. If an export fails it is retried with the
+	// next endpoint.
+	Failover Strategy = iota
+	// RoundRobin rotates exports across the healthy endpoints. If an export
+	// fails it is retried with the next endpoint.
+	RoundRobin
+	// TraceIDHash sends all spans of a trace to the same endpoint. Spans are
+	// assigned an endpoint based on a hash of their trace ID. If the assigned
+	// endpoint is unhealthy or fails the export, the spans are sent to the
+	// next endpoint. This ensures tail-sampling collectors receive complete
+	// traces as long as all endpoints are healthy.
+	TraceIDHash
+)
+
+// DefaultUnhealthyPeriod is the default period an endpoint is considered
+// unhealthy after a failed export.
+const DefaultUnhealthyPeriod = 30 * time.Second
+
+type config struct {
+	strategy        Strategy
+	unhealthyPeriod time.Duration
+}
+
+func newConfig(options []Option) config {
+	c := config{
+		strategy:        Failover,
+		unhealthyPeriod: DefaultUnhealthyPeriod,
+	}
+	for _, o := range options {
+		c = o.apply(c)
+	}
+	return c
+}
+
+// Option applies a configuration option to a Client.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(c config) config {
+	return fn(c)
+}
+
+// WithStrategy sets the Strategy used to distribute exports across
+// endpoints.
+//
+// By default, if this option is not used, Failover is used.
+func WithStrategy(s Strategy) Option {
+	return optionFunc(func(c config) config {
+		c.strategy = s
+		return c
+	})
+}
+
+// WithUnhealthyPeriod sets the period an endpoint is considered unhealthy
+// after it fails an export. Once the period has passed the endpoint is used
+// again. A period less than or equal to zero means an endpoint is always
+// considered healthy.
+//
+// By default, if this option is not used, DefaultUnhealthyPeriod is used.
+func WithUnhealthyPeriod(d time.Duration) Option {
+	return optionFunc(func(c config) config {
+		c.unhealthyPeriod = d
+		return c
+	})
+}
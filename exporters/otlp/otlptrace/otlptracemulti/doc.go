@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlptracemulti provides an otlptrace.Client that sends traces to a
+// set of endpoints using a Client for each of them.
+//
+// The Client tracks the health of each endpoint. An endpoint that fails an
+// export is considered unhealthy for a period of time and is only used once
+// all healthy endpoints have failed. How exports are distributed across the
+// endpoints is determined by the Strategy the Client is configured with.
+//
+// An export only moves on to the next endpoint once the Client of the
+// current one has returned. Any retries and the timeout of that Client delay
+// the failover. For example, the otlptracehttp and otlptracegrpc clients
+// retry for up to a minute by default. Disable their retries and set a short
+// timeout when they are used with this package, retrying is then done by
+// sending the export to the next endpoint.
+package otlptracemulti // import "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracemulti"
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracemulti_test
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracemulti"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func Example() {
+	// Retrying is done by failing over to the next endpoint. Retries of the
+	// endpoint clients would only delay that.
+	endpoint := func(addr string) otlptrace.Client {
+		return otlptracehttp.NewClient(
+			otlptracehttp.WithEndpoint(addr),
+			otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+			otlptracehttp.WithTimeout(2*time.Second),
+		)
+	}
+
+	client := otlptracemulti.NewClient(
+		[]otlptrace.Client{
+			endpoint("collector-a.example.com:4318"),
+			endpoint("collector-b.example.com:4318"),
+		},
+		otlptracemulti.WithStrategy(otlptracemulti.Failover),
+	)
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		panic(err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptracemulti_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracemulti"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newHTTPClient(endpoint string) otlptrace.Client {
+	return otlptracehttp.NewClient(
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		otlptracehttp.WithTimeout(time.Second),
+	)
+}
+
+func TestHTTPFailover(t *testing.T) {
+	down, err := otlptest.NewHTTPCollector()
+	require.NoError(t, err)
+	require.NoError(t, down.Shutdown(context.Background()))
+	up, err := otlptest.NewHTTPCollector()
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, up.Shutdown(context.Background())) })
+
+	client := otlptracemulti.NewClient([]otlptrace.Client{
+		newHTTPClient(down.Endpoint()),
+		newHTTPClient(up.Endpoint()),
+	})
+	ctx := context.Background()
+	exporter, err := otlptrace.New(ctx, client)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+
+	spans := tracetest.SpanStubs{{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x01},
+		}),
+	}}.Snapshots()
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+	require.NoError(t, exporter.ExportSpans(ctx, spans))
+	assert.Len(t, up.Spans(), 2)
+	assert.Equal(t, 2, up.Requests())
+}
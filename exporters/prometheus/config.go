@@ -15,8 +15,12 @@
 package prometheus // import "go.opentelemetry.io/otel/exporters/prometheus"
 
 import (
+	"strings"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/internal/prometheus/naming"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
 
@@ -27,6 +31,9 @@ type config struct {
 	withoutUnits      bool
 	aggregation       metric.AggregationSelector
 	disableScopeInfo  bool
+
+	namespace           string
+	resourceAttrsFilter attribute.Filter
 }
 
 // newConfig creates a validated config configured with options.
@@ -116,3 +123,38 @@ func WithoutScopeInfo() Option {
 		return cfg
 	})
 }
+
+// WithNamespace configures the Exporter to prefix metric names with ns. The
+// namespace is sanitized to be a valid Prometheus metric name and is separated
+// from the metric name with an underscore. The target_info and
+// otel_scope_info metrics are not prefixed.
+//
+// By default, metric names are not prefixed.
+func WithNamespace(ns string) Option {
+	return optionFunc(func(cfg config) config {
+		ns = naming.SanitizeName(ns)
+		if ns != "" && !strings.HasSuffix(ns, "_") {
+			// Namespace and metric names should be separated with an
+			// underscore, add one if it is not already there.
+			ns += "_"
+		}
+		cfg.namespace = ns
+		return cfg
+	})
+}
+
+// WithResourceAsConstantLabels configures the Exporter to add the resource
+// attributes the filter returns true for as labels of all exported metrics.
+//
+// This does not affect the target_info metric, it will still contain all
+// resource attributes unless WithoutTargetInfo is used.
+//
+// A resource attribute is not added as a label to a metric data point that
+// already has a label with the same name. Attributes of the data point and
+// the otel_scope_name and otel_scope_version labels take precedence.
+func WithResourceAsConstantLabels(filter attribute.Filter) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.resourceAttrsFilter = filter
+		return cfg
+	})
+}
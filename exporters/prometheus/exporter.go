@@ -22,7 +22,6 @@ import (
 	"strings"
 	"sync"
 	"unicode"
-	"unicode/utf8"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -30,8 +29,8 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/internal/prometheus/naming"
 	"go.opentelemetry.io/otel/internal/global"
-	"go.opentelemetry.io/otel/metric/unit"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -64,14 +63,45 @@ type collector struct {
 	withoutUnits         bool
 	targetInfo           prometheus.Metric
 	disableScopeInfo     bool
+	namespace            string
+	resourceAttrsFilter  attribute.Filter
 	createTargetInfoOnce sync.Once
+	resourceKeyVals      keyVals
 	scopeInfos           map[instrumentation.Scope]prometheus.Metric
 	metricFamilies       map[string]*dto.MetricFamily
 }
 
-// prometheus counters MUST have a _total suffix:
-// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.14.0/specification/metrics/data-model.md#sums-1
-const counterSuffix = "_total"
+// keyVals is a list of Prometheus label keys and their matching values.
+type keyVals struct {
+	keys []string
+	vals []string
+}
+
+// append returns kv with the keys and values of other appended. Keys of
+// other that are already contained in kv are not appended, the values in kv
+// take precedence.
+func (kv keyVals) append(other keyVals) keyVals {
+	keys := append(make([]string, 0, len(kv.keys)+len(other.keys)), kv.keys...)
+	vals := append(make([]string, 0, len(kv.vals)+len(other.vals)), kv.vals...)
+	for i, k := range other.keys {
+		if kv.contains(k) {
+			continue
+		}
+		keys = append(keys, k)
+		vals = append(vals, other.vals[i])
+	}
+	return keyVals{keys: keys, vals: vals}
+}
+
+// contains returns if key is one of the keys in kv.
+func (kv keyVals) contains(key string) bool {
+	for _, k := range kv.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
 
 // New returns a Prometheus Exporter.
 func New(opts ...Option) (*Exporter, error) {
@@ -83,12 +113,14 @@ func New(opts ...Option) (*Exporter, error) {
 	reader := metric.NewManualReader(cfg.manualReaderOptions()...)
 
 	collector := &collector{
-		reader:            reader,
-		disableTargetInfo: cfg.disableTargetInfo,
-		withoutUnits:      cfg.withoutUnits,
-		disableScopeInfo:  cfg.disableScopeInfo,
-		scopeInfos:        make(map[instrumentation.Scope]prometheus.Metric),
-		metricFamilies:    make(map[string]*dto.MetricFamily),
+		reader:              reader,
+		disableTargetInfo:   cfg.disableTargetInfo,
+		withoutUnits:        cfg.withoutUnits,
+		disableScopeInfo:    cfg.disableScopeInfo,
+		namespace:           cfg.namespace,
+		resourceAttrsFilter: cfg.resourceAttrsFilter,
+		scopeInfos:          make(map[instrumentation.Scope]prometheus.Metric),
+		metricFamilies:      make(map[string]*dto.MetricFamily),
 	}
 
 	if err := cfg.registerer.Register(collector); err != nil {
@@ -130,13 +162,19 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			c.disableTargetInfo = true
 		}
 		c.targetInfo = targetInfo
+
+		if c.resourceAttrsFilter != nil && metrics.Resource != nil {
+			set, _ := metrics.Resource.Set().Filter(c.resourceAttrsFilter)
+			keys, vals := getAttrs(set, keyVals{})
+			c.resourceKeyVals = keyVals{keys: keys, vals: vals}
+		}
 	})
 	if !c.disableTargetInfo {
 		ch <- c.targetInfo
 	}
 
 	for _, scopeMetrics := range metrics.ScopeMetrics {
-		var keys, values [2]string
+		kv := c.resourceKeyVals
 
 		if !c.disableScopeInfo {
 			scopeInfo, ok := c.scopeInfos[scopeMetrics.Scope]
@@ -148,29 +186,37 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 				c.scopeInfos[scopeMetrics.Scope] = scopeInfo
 			}
 			ch <- scopeInfo
-			keys = scopeInfoKeys
-			values = [2]string{scopeMetrics.Scope.Name, scopeMetrics.Scope.Version}
+			// The scope labels take precedence over resource labels with the
+			// same name.
+			kv = keyVals{
+				keys: scopeInfoKeys[:],
+				vals: []string{scopeMetrics.Scope.Name, scopeMetrics.Scope.Version},
+			}.append(c.resourceKeyVals)
 		}
 
 		for _, m := range scopeMetrics.Metrics {
 			switch v := m.Data.(type) {
 			case metricdata.Histogram:
-				addHistogramMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+				addHistogramMetric(ch, v, m, kv, c.getName(m), c.metricFamilies)
 			case metricdata.Sum[int64]:
-				addSumMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+				addSumMetric(ch, v, m, kv, c.getName(m), c.metricFamilies)
 			case metricdata.Sum[float64]:
-				addSumMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+				addSumMetric(ch, v, m, kv, c.getName(m), c.metricFamilies)
 			case metricdata.Gauge[int64]:
-				addGaugeMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+				addGaugeMetric(ch, v, m, kv, c.getName(m), c.metricFamilies)
 			case metricdata.Gauge[float64]:
-				addGaugeMetric(ch, v, m, keys, values, c.getName(m), c.metricFamilies)
+				addGaugeMetric(ch, v, m, kv, c.getName(m), c.metricFamilies)
 			}
 		}
 	}
 }
 
-func addHistogramMetric(ch chan<- prometheus.Metric, histogram metricdata.Histogram, m metricdata.Metrics, ks, vs [2]string, name string, mfs map[string]*dto.MetricFamily) {
+func addHistogramMetric(ch chan<- prometheus.Metric, histogram metricdata.Histogram, m metricdata.Metrics, kv keyVals, name string, mfs map[string]*dto.MetricFamily) {
 	// TODO(https://github.com/open-telemetry/opentelemetry-go/issues/3163): support exemplars
+	// TODO: export exponential histograms as native histograms once the SDK
+	// produces them.
+	// TODO: add _created timestamps from StartTime. This requires a version of
+	// the Prometheus client library that supports created timestamps.
 	drop, help := validateMetrics(name, m.Description, dto.MetricType_HISTOGRAM.Enum(), mfs)
 	if drop {
 		return
@@ -180,7 +226,7 @@ func addHistogramMetric(ch chan<- prometheus.Metric, histogram metricdata.Histog
 	}
 
 	for _, dp := range histogram.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs)
+		keys, values := getAttrs(dp.Attributes, kv)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		buckets := make(map[float64]uint64, len(dp.Bounds))
@@ -199,7 +245,7 @@ func addHistogramMetric(ch chan<- prometheus.Metric, histogram metricdata.Histog
 	}
 }
 
-func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, ks, vs [2]string, name string, mfs map[string]*dto.MetricFamily) {
+func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata.Sum[N], m metricdata.Metrics, kv keyVals, name string, mfs map[string]*dto.MetricFamily) {
 	valueType := prometheus.CounterValue
 	metricType := dto.MetricType_COUNTER
 	if !sum.IsMonotonic {
@@ -208,7 +254,7 @@ func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata
 	}
 	if sum.IsMonotonic {
 		// Add _total suffix for counters
-		name += counterSuffix
+		name += naming.CounterSuffix
 	}
 
 	drop, help := validateMetrics(name, m.Description, metricType.Enum(), mfs)
@@ -220,7 +266,7 @@ func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata
 	}
 
 	for _, dp := range sum.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs)
+		keys, values := getAttrs(dp.Attributes, kv)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		m, err := prometheus.NewConstMetric(desc, valueType, float64(dp.Value), values...)
@@ -232,7 +278,7 @@ func addSumMetric[N int64 | float64](ch chan<- prometheus.Metric, sum metricdata
 	}
 }
 
-func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, ks, vs [2]string, name string, mfs map[string]*dto.MetricFamily) {
+func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metricdata.Gauge[N], m metricdata.Metrics, kv keyVals, name string, mfs map[string]*dto.MetricFamily) {
 	drop, help := validateMetrics(name, m.Description, dto.MetricType_GAUGE.Enum(), mfs)
 	if drop {
 		return
@@ -242,7 +288,7 @@ func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metric
 	}
 
 	for _, dp := range gauge.DataPoints {
-		keys, values := getAttrs(dp.Attributes, ks, vs)
+		keys, values := getAttrs(dp.Attributes, kv)
 
 		desc := prometheus.NewDesc(name, m.Description, keys, nil)
 		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(dp.Value), values...)
@@ -257,7 +303,11 @@ func addGaugeMetric[N int64 | float64](ch chan<- prometheus.Metric, gauge metric
 // getAttrs parses the attribute.Set to two lists of matching Prometheus-style
 // keys and values. It sanitizes invalid characters and handles duplicate keys
 // (due to sanitization) by sorting and concatenating the values following the spec.
-func getAttrs(attrs attribute.Set, ks, vs [2]string) ([]string, []string) {
+//
+// The keys and values of kv are appended to the returned lists unless an
+// attribute with the same key is in attrs. Prometheus does not allow
+// duplicate label names, the attribute values take precedence.
+func getAttrs(attrs attribute.Set, kv keyVals) ([]string, []string) {
 	keysMap := make(map[string][]string)
 	itr := attrs.Iter()
 	for itr.Next() {
@@ -281,15 +331,18 @@ func getAttrs(attrs attribute.Set, ks, vs [2]string) ([]string, []string) {
 		values = append(values, strings.Join(vals, ";"))
 	}
 
-	if ks[0] != "" {
-		keys = append(keys, ks[:]...)
-		values = append(values, vs[:]...)
+	for i, key := range kv.keys {
+		if _, ok := keysMap[key]; ok {
+			continue
+		}
+		keys = append(keys, key)
+		values = append(values, kv.vals[i])
 	}
 	return keys, values
 }
 
 func (c *collector) createInfoMetric(name, description string, res *resource.Resource) (prometheus.Metric, error) {
-	keys, values := getAttrs(*res.Set(), [2]string{}, [2]string{})
+	keys, values := getAttrs(*res.Set(), keyVals{})
 	desc := prometheus.NewDesc(name, description, keys, nil)
 	return prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(1), values...)
 }
@@ -307,77 +360,21 @@ func sanitizeRune(r rune) rune {
 	return '_'
 }
 
-var unitSuffixes = map[unit.Unit]string{
-	unit.Dimensionless: "_ratio",
-	unit.Bytes:         "_bytes",
-	unit.Milliseconds:  "_milliseconds",
-}
-
 // getName returns the sanitized name, including unit suffix.
 func (c *collector) getName(m metricdata.Metrics) string {
-	name := sanitizeName(m.Name)
+	name := naming.SanitizeName(m.Name)
+	if c.namespace != "" {
+		name = c.namespace + name
+	}
 	if c.withoutUnits {
 		return name
 	}
-	if suffix, ok := unitSuffixes[m.Unit]; ok {
+	if suffix, ok := naming.UnitSuffix(string(m.Unit)); ok {
 		name += suffix
 	}
 	return name
 }
 
-func sanitizeName(n string) string {
-	// This algorithm is based on strings.Map from Go 1.19.
-	const replacement = '_'
-
-	valid := func(i int, r rune) bool {
-		// Taken from
-		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric.go#L92-L102
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == ':' || (r >= '0' && r <= '9' && i > 0) {
-			return true
-		}
-		return false
-	}
-
-	// This output buffer b is initialized on demand, the first time a
-	// character needs to be replaced.
-	var b strings.Builder
-	for i, c := range n {
-		if valid(i, c) {
-			continue
-		}
-
-		if i == 0 && c >= '0' && c <= '9' {
-			// Prefix leading number with replacement character.
-			b.Grow(len(n) + 1)
-			_ = b.WriteByte(byte(replacement))
-			break
-		}
-		b.Grow(len(n))
-		_, _ = b.WriteString(n[:i])
-		_ = b.WriteByte(byte(replacement))
-		width := utf8.RuneLen(c)
-		n = n[i+width:]
-		break
-	}
-
-	// Fast path for unchanged input.
-	if b.Cap() == 0 { // b.Grow was not called above.
-		return n
-	}
-
-	for _, c := range n {
-		// Due to inlining, it is more performant to invoke WriteByte rather then
-		// WriteRune.
-		if valid(1, c) { // We are guaranteed to not be at the start.
-			_ = b.WriteByte(byte(c))
-		} else {
-			_ = b.WriteByte(byte(replacement))
-		}
-	}
-
-	return b.String()
-}
-
 func validateMetrics(name, description string, metricType *dto.MetricType, mfs map[string]*dto.MetricFamily) (drop bool, help string) {
 	emf, exist := mfs[name]
 	if !exist {
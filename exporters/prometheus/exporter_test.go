@@ -259,6 +259,69 @@ func TestPrometheusExporter(t *testing.T) {
 				counter.Add(ctx, 1, attrs...)
 			},
 		},
+		{
+			name:         "with namespace",
+			expectedFile: "testdata/with_namespace.txt",
+			options: []Option{
+				WithNamespace("test"),
+			},
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				attrs := []attribute.KeyValue{
+					attribute.Key("A").String("B"),
+					attribute.Key("C").String("D"),
+					attribute.Key("E").Bool(true),
+					attribute.Key("F").Int(42),
+				}
+				counter, err := meter.Float64Counter("foo", instrument.WithDescription("a simple counter"))
+				require.NoError(t, err)
+				counter.Add(ctx, 5, attrs...)
+				counter.Add(ctx, 10.3, attrs...)
+				counter.Add(ctx, 9, attrs...)
+			},
+		},
+		{
+			name:         "with resource attributes as labels",
+			expectedFile: "testdata/resource_as_labels.txt",
+			customResouceAttrs: []attribute.KeyValue{
+				attribute.Key("k8s.pod.name").String("pod-1"),
+				attribute.Key("deployment").String("canary"),
+			},
+			options: []Option{
+				WithResourceAsConstantLabels(func(kv attribute.KeyValue) bool {
+					return kv.Key == "k8s.pod.name" || kv.Key == semconv.ServiceNameKey
+				}),
+			},
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				attrs := []attribute.KeyValue{
+					attribute.Key("A").String("B"),
+					attribute.Key("C").String("D"),
+				}
+				counter, err := meter.Int64Counter("foo", instrument.WithDescription("a simple counter"))
+				require.NoError(t, err)
+				counter.Add(ctx, 2, attrs...)
+				counter.Add(ctx, 1, attrs...)
+			},
+		},
+		{
+			name:         "with colliding resource attributes as labels",
+			expectedFile: "testdata/resource_as_labels_collision.txt",
+			customResouceAttrs: []attribute.KeyValue{
+				attribute.Key("A").String("resource"),
+				attribute.Key("otel.scope.name").String("resource"),
+				attribute.Key("k8s.pod.name").String("pod-1"),
+			},
+			options: []Option{
+				WithResourceAsConstantLabels(func(kv attribute.KeyValue) bool {
+					return kv.Key == "A" || kv.Key == "otel.scope.name" || kv.Key == "k8s.pod.name"
+				}),
+			},
+			recordMetrics: func(ctx context.Context, meter otelmetric.Meter) {
+				counter, err := meter.Int64Counter("foo", instrument.WithDescription("a simple counter"))
+				require.NoError(t, err)
+				counter.Add(ctx, 2, attribute.Key("A").String("B"))
+				counter.Add(ctx, 1, attribute.Key("C").String("D"))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -312,36 +375,6 @@ func TestPrometheusExporter(t *testing.T) {
 	}
 }
 
-func TestSantitizeName(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"nam€_with_3_width_rune", "nam__with_3_width_rune"},
-		{"`", "_"},
-		{
-			`! "#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWKYZ[]\^_abcdefghijklmnopqrstuvwkyz{|}~`,
-			`________________0123456789:______ABCDEFGHIJKLMNOPQRSTUVWKYZ_____abcdefghijklmnopqrstuvwkyz____`,
-		},
-
-		// Test cases taken from
-		// https://github.com/prometheus/common/blob/dfbc25bd00225c70aca0d94c3c4bb7744f28ace0/model/metric_test.go#L85-L136
-		{"Avalid_23name", "Avalid_23name"},
-		{"_Avalid_23name", "_Avalid_23name"},
-		{"1valid_23name", "_1valid_23name"},
-		{"avalid_23name", "avalid_23name"},
-		{"Ava:lid_23name", "Ava:lid_23name"},
-		{"a lid_23name", "a_lid_23name"},
-		{":leading_colon", ":leading_colon"},
-		{"colon:in:the:middle", "colon:in:the:middle"},
-		{"", ""},
-	}
-
-	for _, test := range tests {
-		require.Equalf(t, test.want, sanitizeName(test.input), "input: %q", test.input)
-	}
-}
-
 func TestMultiScopes(t *testing.T) {
 	ctx := context.Background()
 	registry := prometheus.NewRegistry()
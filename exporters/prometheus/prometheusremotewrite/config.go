@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/prometheusremotewrite"
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	defaultEndpoint = "http://localhost:9090/api/v1/write"
+	defaultTimeout  = 10 * time.Second
+)
+
+// DefaultRetryPolicy is the retry.Policy used if WithRetry is not used. It
+// retries requests that failed to be sent and responses with a 5xx or 429
+// status code, as required by the remote-write specification.
+var DefaultRetryPolicy = retry.Policy{
+	Enabled:             true,
+	InitialInterval:     5 * time.Second,
+	MaxInterval:         30 * time.Second,
+	MaxElapsedTime:      time.Minute,
+	RetryableHTTPStatus: retryableStatus,
+}
+
+// retryableStatus returns if a response with the HTTP status code status is
+// retried. The remote-write specification requires 5xx and 429 responses to
+// be retried, all others must not be.
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status/100 == 5
+}
+
+// config contains options for the exporter.
+type config struct {
+	endpoint         string
+	headers          map[string]string
+	timeout          time.Duration
+	httpClient       *http.Client
+	retryPolicy      retry.Policy
+	withoutUnits     bool
+	disableScopeInfo bool
+	aggregation      metric.AggregationSelector
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		endpoint:    defaultEndpoint,
+		timeout:     defaultTimeout,
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.aggregation == nil {
+		cfg.aggregation = metric.DefaultAggregationSelector
+	}
+
+	return cfg
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithEndpoint sets the URL of the remote-write endpoint the Exporter sends
+// data to. If this option is not used, http://localhost:9090/api/v1/write is
+// used.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(func(cfg config) config {
+		if endpoint != "" {
+			cfg.endpoint = endpoint
+		}
+		return cfg
+	})
+}
+
+// WithHeaders sets additional HTTP headers sent with every request.
+func WithHeaders(headers map[string]string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.headers = headers
+		return cfg
+	})
+}
+
+// WithTimeout sets the maximum time a single request is allowed to take. If
+// this option is not used or d is less than or equal to zero, 10 seconds is
+// used as the default.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(func(cfg config) config {
+		if d > 0 {
+			cfg.timeout = d
+		}
+		return cfg
+	})
+}
+
+// WithHTTPClient sets the http.Client used to send requests. The timeout set
+// with WithTimeout is ignored if this option is used, client is expected to
+// be configured with its own timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.httpClient = client
+		return cfg
+	})
+}
+
+// WithRetry sets the retry policy for requests that fail to be sent or
+// receive a retryable response from the remote-write endpoint. If the
+// RetryableHTTPStatus of policy is nil, 5xx and 429 responses are retried,
+// all other failed responses are not. If this option is not used,
+// DefaultRetryPolicy is used.
+func WithRetry(policy retry.Policy) Option {
+	return optionFunc(func(cfg config) config {
+		if policy.RetryableHTTPStatus == nil {
+			policy.RetryableHTTPStatus = retryableStatus
+		}
+		cfg.retryPolicy = policy
+		return cfg
+	})
+}
+
+// WithoutUnits disables the addition of unit suffixes to metric names.
+func WithoutUnits() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.withoutUnits = true
+		return cfg
+	})
+}
+
+// WithoutScopeInfo configures the Exporter to not add the otel_scope_name and
+// otel_scope_version labels to time series.
+func WithoutScopeInfo() Option {
+	return optionFunc(func(cfg config) config {
+		cfg.disableScopeInfo = true
+		return cfg
+	})
+}
+
+// WithAggregationSelector configures the AggregationSelector the Exporter
+// will use. If this option is not used, the DefaultAggregationSelector is
+// used.
+func WithAggregationSelector(agg metric.AggregationSelector) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.aggregation = agg
+		return cfg
+	})
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheusremotewrite provides a metric Exporter that pushes
+// metric data to a Prometheus remote-write endpoint.
+//
+// Metric data is converted to Prometheus time series using the same naming
+// rules as the go.opentelemetry.io/otel/exporters/prometheus exporter: names
+// are sanitized, unit suffixes are added and monotonic sums are given a
+// _total suffix. The time series are encoded as a remote-write protobuf
+// WriteRequest and compressed with snappy.
+//
+// Prometheus only supports cumulative data. The Exporter always requests
+// cumulative temporality from the Reader it is used with.
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/prometheusremotewrite"
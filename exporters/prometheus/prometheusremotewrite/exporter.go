@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/prometheusremotewrite"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/retry"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// remoteWriteVersion is the version of the remote-write protocol implemented.
+const remoteWriteVersion = "0.1.0"
+
+var userAgent = "OTel-Go-Prometheus-Remote-Write-Exporter/" + otel.Version()
+
+// Exporter is a metric Exporter that sends metric data to a Prometheus
+// remote-write endpoint.
+type Exporter struct {
+	endpoint    string
+	headers     map[string]string
+	httpClient  *http.Client
+	retryPolicy retry.Policy
+	requestFunc retry.RequestFunc
+	transformer transformer
+	aggregation metric.AggregationSelector
+
+	shutdownMu sync.RWMutex
+	shutdown   bool
+}
+
+var _ metric.Exporter = (*Exporter)(nil)
+
+// New returns an Exporter that sends metric data to a Prometheus remote-write
+// endpoint.
+func New(opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+
+	// Validate the endpoint once instead of on every request.
+	if _, err := http.NewRequest(http.MethodPost, cfg.endpoint, http.NoBody); err != nil {
+		return nil, fmt.Errorf("invalid remote-write endpoint: %w", err)
+	}
+
+	client := cfg.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: cfg.timeout}
+	}
+
+	return &Exporter{
+		endpoint:    cfg.endpoint,
+		headers:     cfg.headers,
+		httpClient:  client,
+		retryPolicy: cfg.retryPolicy,
+		requestFunc: cfg.retryPolicy.RequestFunc(retry.EvaluateHTTP),
+		transformer: transformer{
+			withoutUnits:     cfg.withoutUnits,
+			disableScopeInfo: cfg.disableScopeInfo,
+		},
+		aggregation: cfg.aggregation,
+	}, nil
+}
+
+// Temporality returns CumulativeTemporality for all instrument kinds, the
+// only temporality Prometheus supports.
+func (e *Exporter) Temporality(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) aggregation.Aggregation {
+	return e.aggregation(k)
+}
+
+// Export converts rm into Prometheus time series and sends them to the
+// remote-write endpoint.
+//
+// Failed requests are retried according to the retry.Policy the Exporter
+// was created with.
+func (e *Exporter) Export(ctx context.Context, rm metricdata.ResourceMetrics) error {
+	e.shutdownMu.RLock()
+	shutdown := e.shutdown
+	e.shutdownMu.RUnlock()
+	if shutdown {
+		return metric.ErrExporterShutdown
+	}
+
+	req := e.transformer.writeRequest(rm)
+	if len(req.timeseries) == 0 {
+		return nil
+	}
+	body := snappy.Encode(nil, req.marshal())
+
+	return e.requestFunc(ctx, func(ctx context.Context) error {
+		return e.send(ctx, body)
+	})
+}
+
+// send sends a single compressed remote-write request body.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return retry.TransportError(err)
+	}
+
+	// Drain the body to reuse the connection.
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	err = fmt.Errorf("failed to send metrics to %s: %s: %s", e.endpoint, resp.Status, bytes.TrimSpace(msg))
+	return e.retryPolicy.HTTPResponseError(resp, err)
+}
+
+// ForceFlush does nothing, the Exporter holds no state.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown shuts down the Exporter. Calls to Export after Shutdown will
+// return an error.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.shutdownMu.Lock()
+	defer e.shutdownMu.Unlock()
+	if e.shutdown {
+		return metric.ErrExporterShutdown
+	}
+	e.shutdown = true
+	e.httpClient.CloseIdleConnections()
+	return ctx.Err()
+}
@@ -0,0 +1,444 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/retry"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// receiver is an in-process remote-write endpoint.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*writeRequest
+	headers  []http.Header
+	// responses are the status codes returned, in order. Once exhausted,
+	// 204 is returned.
+	responses []int
+}
+
+func newReceiver(t *testing.T, responses ...int) *receiver {
+	r := &receiver{responses: responses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if len(r.responses) > 0 {
+			code := r.responses[0]
+			r.responses = r.responses[1:]
+			if code/100 != 2 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+
+		compressed, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		b, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+		wr, err := unmarshalWriteRequest(b)
+		require.NoError(t, err)
+
+		r.requests = append(r.requests, wr)
+		r.headers = append(r.headers, req.Header.Clone())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) Requests() []*writeRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+// unmarshalWriteRequest decodes the protobuf wire encoding of a WriteRequest.
+func unmarshalWriteRequest(b []byte) (*writeRequest, error) {
+	wr := &writeRequest{}
+	err := walk(b, func(num protowire.Number, v []byte, _ uint64) error {
+		switch num {
+		case writeRequestTimeseriesField:
+			var ts timeSeries
+			err := walk(v, func(num protowire.Number, v []byte, _ uint64) error {
+				switch num {
+				case timeSeriesLabelsField:
+					var l label
+					err := walk(v, func(num protowire.Number, v []byte, _ uint64) error {
+						if num == labelNameField {
+							l.name = string(v)
+						} else {
+							l.value = string(v)
+						}
+						return nil
+					})
+					ts.labels = append(ts.labels, l)
+					return err
+				case timeSeriesSamplesField:
+					var s sample
+					err := walk(v, func(num protowire.Number, _ []byte, n uint64) error {
+						if num == sampleValueField {
+							s.value = math.Float64frombits(n)
+						} else {
+							s.timestamp = int64(n)
+						}
+						return nil
+					})
+					ts.samples = append(ts.samples, s)
+					return err
+				}
+				return nil
+			})
+			wr.timeseries = append(wr.timeseries, ts)
+			return err
+		case writeRequestMetadataField:
+			var m metricMetadata
+			err := walk(v, func(num protowire.Number, v []byte, n uint64) error {
+				switch num {
+				case metadataTypeField:
+					m.typ = metricType(n)
+				case metadataFamilyNameField:
+					m.familyName = string(v)
+				case metadataHelpField:
+					m.help = string(v)
+				case metadataUnitField:
+					m.unit = string(v)
+				}
+				return nil
+			})
+			wr.metadata = append(wr.metadata, m)
+			return err
+		}
+		return nil
+	})
+	return wr, err
+}
+
+// walk calls fn for every field in b. Length-delimited values are passed as
+// bytes, all other values as a number.
+func walk(b []byte, fn func(protowire.Number, []byte, uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var (
+			v   []byte
+			val uint64
+		)
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			val, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			val, n = protowire.ConsumeFixed64(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if err := fn(num, v, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// series returns the time series of wr keyed by their labels, excluding the
+// resource and scope labels.
+func series(wr *writeRequest) map[string]float64 {
+	out := make(map[string]float64)
+	for _, ts := range wr.timeseries {
+		var key string
+		for _, l := range ts.labels {
+			switch l.name {
+			case jobLabel, instLabel, scopeNameLabel, scopeVersionLabel:
+				continue
+			}
+			key += l.name + "=" + l.value + ","
+		}
+		out[key] = ts.samples[0].value
+	}
+	return out
+}
+
+func newMeterProvider(t *testing.T, exp *Exporter) (*metric.MeterProvider, metric.Reader) {
+	res := resource.NewSchemaless(
+		semconv.ServiceNameKey.String("checkout"),
+		semconv.ServiceNamespaceKey.String("shop"),
+		semconv.ServiceInstanceIDKey.String("pod-1"),
+	)
+	reader := metric.NewPeriodicReader(exp)
+	mp := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(reader),
+		metric.WithView(metric.NewView(
+			metric.Instrument{Name: "latency"},
+			metric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{
+				Boundaries: []float64{10, 100},
+			}},
+		)),
+	)
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+	return mp, reader
+}
+
+func TestExporterExport(t *testing.T) {
+	recv := newReceiver(t)
+	exp, err := New(WithEndpoint(recv.URL), WithHeaders(map[string]string{"X-Scope-OrgID": "tenant-1"}))
+	require.NoError(t, err)
+
+	mp, reader := newMeterProvider(t, exp)
+	meter := mp.Meter("test", otelmetric.WithInstrumentationVersion("v0.1.0"))
+	ctx := context.Background()
+
+	ctr, err := meter.Int64Counter("requests", instrument.WithDescription("request count"))
+	require.NoError(t, err)
+	ctr.Add(ctx, 3, attribute.String("http.method", "GET"))
+
+	udc, err := meter.Float64UpDownCounter("queue", instrument.WithUnit(unit.Bytes))
+	require.NoError(t, err)
+	udc.Add(ctx, 2.5)
+
+	hist, err := meter.Float64Histogram("latency", instrument.WithUnit(unit.Milliseconds))
+	require.NoError(t, err)
+	hist.Record(ctx, 5)
+	hist.Record(ctx, 50)
+	hist.Record(ctx, 500)
+
+	require.NoError(t, reader.ForceFlush(ctx))
+
+	reqs := recv.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, map[string]float64{
+		"__name__=requests_total,http_method=GET,":      3,
+		"__name__=queue_bytes,":                         2.5,
+		"__name__=latency_milliseconds_bucket,le=10,":   1,
+		"__name__=latency_milliseconds_bucket,le=100,":  2,
+		"__name__=latency_milliseconds_bucket,le=+Inf,": 3,
+		"__name__=latency_milliseconds_sum,":            555,
+		"__name__=latency_milliseconds_count,":          3,
+	}, series(reqs[0]))
+
+	for _, ts := range reqs[0].timeseries {
+		assert.Contains(t, ts.labels, label{name: jobLabel, value: "shop/checkout"})
+		assert.Contains(t, ts.labels, label{name: instLabel, value: "pod-1"})
+		assert.Contains(t, ts.labels, label{name: scopeNameLabel, value: "test"})
+		assert.Contains(t, ts.labels, label{name: scopeVersionLabel, value: "v0.1.0"})
+		assert.IsNonDecreasing(t, labelNames(ts.labels), "labels must be sorted")
+		require.Len(t, ts.samples, 1)
+		assert.NotZero(t, ts.samples[0].timestamp)
+	}
+
+	assert.Contains(t, reqs[0].metadata, metricMetadata{
+		typ:        metricTypeCounter,
+		familyName: "requests_total",
+		help:       "request count",
+	})
+
+	h := recv.headers[0]
+	assert.Equal(t, "snappy", h.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", h.Get("Content-Type"))
+	assert.Equal(t, remoteWriteVersion, h.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "tenant-1", h.Get("X-Scope-OrgID"))
+}
+
+func labelNames(labels []label) []string {
+	out := make([]string, len(labels))
+	for i, l := range labels {
+		out[i] = l.name
+	}
+	return out
+}
+
+func TestExporterOptions(t *testing.T) {
+	recv := newReceiver(t)
+	exp, err := New(WithEndpoint(recv.URL), WithoutUnits(), WithoutScopeInfo())
+	require.NoError(t, err)
+
+	mp, reader := newMeterProvider(t, exp)
+	ctx := context.Background()
+	ctr, err := mp.Meter("test").Int64Counter("sent", instrument.WithUnit(unit.Bytes))
+	require.NoError(t, err)
+	ctr.Add(ctx, 1)
+	require.NoError(t, reader.ForceFlush(ctx))
+
+	reqs := recv.Requests()
+	require.Len(t, reqs, 1)
+	require.Len(t, reqs[0].timeseries, 1)
+	assert.Equal(t, []label{
+		{name: nameLabel, value: "sent_total"},
+		{name: instLabel, value: "pod-1"},
+		{name: jobLabel, value: "shop/checkout"},
+	}, reqs[0].timeseries[0].labels)
+}
+
+func TestExporterRetry(t *testing.T) {
+	recv := newReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	exp, err := New(WithEndpoint(recv.URL), WithRetry(retry.Policy{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Minute,
+	}))
+	require.NoError(t, err)
+
+	mp, reader := newMeterProvider(t, exp)
+	ctx := context.Background()
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	ctr.Add(ctx, 1)
+
+	assert.NoError(t, reader.ForceFlush(ctx))
+	assert.Len(t, recv.Requests(), 1)
+}
+
+// failingTransport fails the first n requests it receives with a transport
+// error before forwarding the rest to http.DefaultTransport.
+type failingTransport struct {
+	n        int32
+	requests int32
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&t.requests, 1) <= t.n {
+		return nil, errors.New("connection refused")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestExporterRetryTransportError(t *testing.T) {
+	recv := newReceiver(t)
+	transport := &failingTransport{n: 2}
+	exp, err := New(
+		WithEndpoint(recv.URL),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetry(retry.Policy{Enabled: true, InitialInterval: time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	mp, reader := newMeterProvider(t, exp)
+	ctx := context.Background()
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	ctr.Add(ctx, 1)
+
+	assert.NoError(t, reader.ForceFlush(ctx))
+	assert.Len(t, recv.Requests(), 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.requests))
+}
+
+func TestExporterShutdownDuringRetry(t *testing.T) {
+	attempted := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case attempted <- struct{}{}:
+		default:
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	exp, err := New(WithEndpoint(srv.URL), WithRetry(retry.Policy{
+		Enabled:         true,
+		InitialInterval: time.Hour,
+	}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- exp.Export(ctx, metricdata.ResourceMetrics{
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Metrics: []metricdata.Metrics{{
+					Name: "queue",
+					Data: metricdata.Gauge[int64]{
+						DataPoints: []metricdata.DataPoint[int64]{{Value: 1}},
+					},
+				}},
+			}},
+		})
+	}()
+	<-attempted
+
+	// Shutdown must not wait for the Export being retried.
+	sCtx, sCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer sCancel()
+	assert.NoError(t, exp.Shutdown(sCtx))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestExporterNonRetryableError(t *testing.T) {
+	recv := newReceiver(t, http.StatusBadRequest)
+	exp, err := New(WithEndpoint(recv.URL))
+	require.NoError(t, err)
+
+	mp, reader := newMeterProvider(t, exp)
+	ctx := context.Background()
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	ctr.Add(ctx, 1)
+
+	assert.ErrorContains(t, reader.ForceFlush(ctx), "400")
+	assert.Len(t, recv.Requests(), 0)
+}
+
+func TestExporterShutdown(t *testing.T) {
+	exp, err := New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.ErrorIs(t, exp.Shutdown(ctx), metric.ErrExporterShutdown)
+	assert.ErrorIs(t, exp.Export(ctx, metricdata.ResourceMetrics{}), metric.ErrExporterShutdown)
+}
+
+func TestNewInvalidEndpoint(t *testing.T) {
+	_, err := New(WithEndpoint("://invalid"))
+	assert.Error(t, err)
+}
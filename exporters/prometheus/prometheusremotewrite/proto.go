@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/prometheusremotewrite"
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The types in this file mirror the messages of the Prometheus remote-write
+// protocol (prometheus/prompb/remote.proto and types.proto). They are encoded
+// directly to avoid depending on the Prometheus server module.
+
+// writeRequest is a remote-write WriteRequest message.
+type writeRequest struct {
+	timeseries []timeSeries
+	metadata   []metricMetadata
+}
+
+// timeSeries is a remote-write TimeSeries message.
+type timeSeries struct {
+	labels  []label
+	samples []sample
+}
+
+// label is a remote-write Label message.
+type label struct {
+	name  string
+	value string
+}
+
+// sample is a remote-write Sample message.
+type sample struct {
+	value float64
+	// timestamp is the sample time in milliseconds since the Unix epoch.
+	timestamp int64
+}
+
+// metricType is a remote-write MetricMetadata.MetricType enum value.
+type metricType int32
+
+const (
+	metricTypeUnknown   metricType = 0
+	metricTypeCounter   metricType = 1
+	metricTypeGauge     metricType = 2
+	metricTypeHistogram metricType = 3
+)
+
+// metricMetadata is a remote-write MetricMetadata message.
+type metricMetadata struct {
+	typ        metricType
+	familyName string
+	help       string
+	unit       string
+}
+
+// Field numbers of the remote-write messages.
+const (
+	writeRequestTimeseriesField protowire.Number = 1
+	writeRequestMetadataField   protowire.Number = 3
+
+	timeSeriesLabelsField  protowire.Number = 1
+	timeSeriesSamplesField protowire.Number = 2
+
+	labelNameField  protowire.Number = 1
+	labelValueField protowire.Number = 2
+
+	sampleValueField     protowire.Number = 1
+	sampleTimestampField protowire.Number = 2
+
+	metadataTypeField       protowire.Number = 1
+	metadataFamilyNameField protowire.Number = 2
+	metadataHelpField       protowire.Number = 4
+	metadataUnitField       protowire.Number = 5
+)
+
+// marshal returns the protobuf wire encoding of r.
+func (r *writeRequest) marshal() []byte {
+	var b []byte
+	for i := range r.timeseries {
+		b = protowire.AppendTag(b, writeRequestTimeseriesField, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.timeseries[i].marshal())
+	}
+	for i := range r.metadata {
+		b = protowire.AppendTag(b, writeRequestMetadataField, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.metadata[i].marshal())
+	}
+	return b
+}
+
+func (ts *timeSeries) marshal() []byte {
+	var b []byte
+	for _, l := range ts.labels {
+		b = protowire.AppendTag(b, timeSeriesLabelsField, protowire.BytesType)
+		b = protowire.AppendBytes(b, l.marshal())
+	}
+	for _, s := range ts.samples {
+		b = protowire.AppendTag(b, timeSeriesSamplesField, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	return b
+}
+
+func (l label) marshal() []byte {
+	var b []byte
+	b = appendString(b, labelNameField, l.name)
+	b = appendString(b, labelValueField, l.value)
+	return b
+}
+
+func (s sample) marshal() []byte {
+	var b []byte
+	if s.value != 0 {
+		b = protowire.AppendTag(b, sampleValueField, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(s.value))
+	}
+	if s.timestamp != 0 {
+		b = protowire.AppendTag(b, sampleTimestampField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(s.timestamp))
+	}
+	return b
+}
+
+func (m metricMetadata) marshal() []byte {
+	var b []byte
+	if m.typ != metricTypeUnknown {
+		b = protowire.AppendTag(b, metadataTypeField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.typ))
+	}
+	b = appendString(b, metadataFamilyNameField, m.familyName)
+	b = appendString(b, metadataHelpField, m.help)
+	b = appendString(b, metadataUnitField, m.unit)
+	return b
+}
+
+// appendString appends the field num with value v to b if v is not empty.
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
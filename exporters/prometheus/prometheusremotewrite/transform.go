@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "go.opentelemetry.io/otel/exporters/prometheus/prometheusremotewrite"
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/internal/prometheus/naming"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	nameLabel   = "__name__"
+	bucketLabel = "le"
+	jobLabel    = "job"
+	instLabel   = "instance"
+
+	scopeNameLabel    = "otel_scope_name"
+	scopeVersionLabel = "otel_scope_version"
+
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+)
+
+// transformer converts metricdata into remote-write time series.
+type transformer struct {
+	withoutUnits     bool
+	disableScopeInfo bool
+}
+
+// writeRequest returns the remote-write request containing all data in rm
+// that can be represented by Prometheus.
+func (t transformer) writeRequest(rm metricdata.ResourceMetrics) *writeRequest {
+	req := &writeRequest{}
+	resLabels := resourceLabels(rm.Resource)
+	for _, sm := range rm.ScopeMetrics {
+		base := resLabels
+		if !t.disableScopeInfo {
+			base = append(base[:len(base):len(base)],
+				label{name: scopeNameLabel, value: sm.Scope.Name},
+				label{name: scopeVersionLabel, value: sm.Scope.Version},
+			)
+		}
+
+		for _, m := range sm.Metrics {
+			name := t.name(m)
+			switch a := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				addSum(req, a, m, name, base)
+			case metricdata.Sum[float64]:
+				addSum(req, a, m, name, base)
+			case metricdata.Gauge[int64]:
+				addGauge(req, a, m, name, base)
+			case metricdata.Gauge[float64]:
+				addGauge(req, a, m, name, base)
+			case metricdata.Histogram:
+				addHistogram(req, a, m, name, base)
+			}
+		}
+	}
+	return req
+}
+
+// name returns the sanitized name of m, including unit suffix.
+func (t transformer) name(m metricdata.Metrics) string {
+	name := naming.SanitizeName(m.Name)
+	if t.withoutUnits {
+		return name
+	}
+	if suffix, ok := naming.UnitSuffix(string(m.Unit)); ok {
+		name += suffix
+	}
+	return name
+}
+
+func addSum[N int64 | float64](req *writeRequest, sum metricdata.Sum[N], m metricdata.Metrics, name string, base []label) {
+	if sum.Temporality != metricdata.CumulativeTemporality {
+		// Prometheus only supports cumulative data.
+		return
+	}
+
+	typ := metricTypeGauge
+	if sum.IsMonotonic {
+		typ = metricTypeCounter
+		name += naming.CounterSuffix
+	}
+	req.metadata = append(req.metadata, newMetadata(typ, name, m))
+
+	for _, dp := range sum.DataPoints {
+		req.timeseries = append(req.timeseries, timeSeries{
+			labels:  seriesLabels(name, base, dp.Attributes),
+			samples: []sample{{value: float64(dp.Value), timestamp: timestamp(dp.Time)}},
+		})
+	}
+}
+
+func addGauge[N int64 | float64](req *writeRequest, gauge metricdata.Gauge[N], m metricdata.Metrics, name string, base []label) {
+	req.metadata = append(req.metadata, newMetadata(metricTypeGauge, name, m))
+
+	for _, dp := range gauge.DataPoints {
+		req.timeseries = append(req.timeseries, timeSeries{
+			labels:  seriesLabels(name, base, dp.Attributes),
+			samples: []sample{{value: float64(dp.Value), timestamp: timestamp(dp.Time)}},
+		})
+	}
+}
+
+func addHistogram(req *writeRequest, histogram metricdata.Histogram, m metricdata.Metrics, name string, base []label) {
+	if histogram.Temporality != metricdata.CumulativeTemporality {
+		// Prometheus only supports cumulative data.
+		return
+	}
+	req.metadata = append(req.metadata, newMetadata(metricTypeHistogram, name, m))
+
+	for _, dp := range histogram.DataPoints {
+		ts := timestamp(dp.Time)
+		attrs := append(attrLabels(dp.Attributes), base...)
+
+		var cumulative uint64
+		for i, bound := range dp.Bounds {
+			cumulative += dp.BucketCounts[i]
+			req.timeseries = append(req.timeseries, bucketSeries(name, attrs, formatFloat(bound), cumulative, ts))
+		}
+		req.timeseries = append(req.timeseries,
+			bucketSeries(name, attrs, "+Inf", dp.Count, ts),
+			timeSeries{
+				labels:  withName(name+sumSuffix, attrs),
+				samples: []sample{{value: dp.Sum, timestamp: ts}},
+			},
+			timeSeries{
+				labels:  withName(name+countSuffix, attrs),
+				samples: []sample{{value: float64(dp.Count), timestamp: ts}},
+			},
+		)
+	}
+}
+
+func bucketSeries(name string, attrs []label, le string, count uint64, ts int64) timeSeries {
+	labels := append([]label{{name: bucketLabel, value: le}}, attrs...)
+	return timeSeries{
+		labels:  withName(name+bucketSuffix, labels),
+		samples: []sample{{value: float64(count), timestamp: ts}},
+	}
+}
+
+func newMetadata(typ metricType, name string, m metricdata.Metrics) metricMetadata {
+	return metricMetadata{
+		typ:        typ,
+		familyName: name,
+		help:       m.Description,
+		unit:       string(m.Unit),
+	}
+}
+
+// seriesLabels returns the sorted labels of a time series named name. The
+// attribute labels take precedence over the base labels with the same name.
+func seriesLabels(name string, base []label, attrs attribute.Set) []label {
+	return withName(name, append(attrLabels(attrs), base...))
+}
+
+// withName returns labels with the metric name label added, sorted by name
+// as required by the remote-write protocol.
+//
+// The remote-write protocol does not allow duplicate label names. Only the
+// first of the labels with the same name is kept, and the metric name label
+// takes precedence over all labels.
+func withName(name string, labels []label) []label {
+	out := make([]label, 0, len(labels)+1)
+	out = append(out, label{name: nameLabel, value: name})
+	out = append(out, labels...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].name < out[j].name })
+
+	n := 1
+	for i := 1; i < len(out); i++ {
+		if out[i].name == out[n-1].name {
+			continue
+		}
+		out[n] = out[i]
+		n++
+	}
+	return out[:n]
+}
+
+// attrLabels returns attrs as sanitized labels. Values of attributes whose
+// keys are the same after sanitization are joined with a semicolon.
+func attrLabels(attrs attribute.Set) []label {
+	var (
+		keys []string
+		vals = make(map[string][]string, attrs.Len())
+	)
+	itr := attrs.Iter()
+	for itr.Next() {
+		kv := itr.Attribute()
+		key := naming.SanitizeLabel(string(kv.Key))
+		if _, ok := vals[key]; !ok {
+			keys = append(keys, key)
+		}
+		vals[key] = append(vals[key], kv.Value.Emit())
+	}
+
+	out := make([]label, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, label{name: k, value: strings.Join(vals[k], ";")})
+	}
+	return out
+}
+
+// resourceLabels returns the job and instance labels for res.
+//
+// See https://github.com/open-telemetry/opentelemetry-specification/blob/v1.14.0/specification/metrics/data-model.md#resource-attributes-1
+func resourceLabels(res *resource.Resource) []label {
+	if res == nil {
+		return nil
+	}
+
+	var (
+		out       []label
+		job       string
+		namespace string
+	)
+	itr := res.Iter()
+	for itr.Next() {
+		kv := itr.Attribute()
+		switch kv.Key {
+		case semconv.ServiceNameKey:
+			job = kv.Value.Emit()
+		case semconv.ServiceNamespaceKey:
+			namespace = kv.Value.Emit()
+		case semconv.ServiceInstanceIDKey:
+			out = append(out, label{name: instLabel, value: kv.Value.Emit()})
+		}
+	}
+	if job != "" {
+		if namespace != "" {
+			job = namespace + "/" + job
+		}
+		out = append(out, label{name: jobLabel, value: job})
+	}
+	return out
+}
+
+// timestamp returns t in milliseconds since the Unix epoch.
+func timestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
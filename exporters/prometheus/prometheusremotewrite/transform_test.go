@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func TestWriteRequestCollidingLabels(t *testing.T) {
+	attrs := attribute.NewSet(
+		attribute.String("__name__", "name"),
+		attribute.String("instance", "attr-instance"),
+		attribute.String("job", "attr-job"),
+		attribute.String("le", "attr-le"),
+		attribute.String("otel.scope.name", "attr-scope"),
+	)
+	rm := metricdata.ResourceMetrics{
+		Resource: resource.NewSchemaless(
+			semconv.ServiceNameKey.String("checkout"),
+			semconv.ServiceInstanceIDKey.String("pod-1"),
+		),
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "test", Version: "v0.1.0"},
+			Metrics: []metricdata.Metrics{{
+				Name: "latency",
+				Data: metricdata.Histogram{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint{{
+						Attributes:   attrs,
+						Bounds:       []float64{10},
+						BucketCounts: []uint64{1, 0},
+						Count:        1,
+						Sum:          5,
+					}},
+				},
+			}},
+		}},
+	}
+
+	req := transformer{}.writeRequest(rm)
+	require.Len(t, req.timeseries, 4)
+	for _, ts := range req.timeseries {
+		names := labelNames(ts.labels)
+		assert.IsIncreasing(t, names, "labels must be sorted and unique")
+
+		// The metric name, le, and attribute labels take precedence.
+		assert.Contains(t, ts.labels, label{name: instLabel, value: "attr-instance"})
+		assert.Contains(t, ts.labels, label{name: jobLabel, value: "attr-job"})
+		assert.Contains(t, ts.labels, label{name: scopeNameLabel, value: "attr-scope"})
+		assert.Contains(t, ts.labels, label{name: scopeVersionLabel, value: "v0.1.0"})
+		assert.Equal(t, label{name: nameLabel, value: ts.labels[0].value}, ts.labels[0])
+		assert.NotEqual(t, "name", ts.labels[0].value)
+	}
+
+	bucket := req.timeseries[0].labels
+	assert.Contains(t, bucket, label{name: nameLabel, value: "latency_bucket"})
+	assert.Contains(t, bucket, label{name: bucketLabel, value: "10"})
+	assert.Contains(t, req.timeseries[2].labels, label{name: bucketLabel, value: "attr-le"})
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry provides a retry policy for exporters sending telemetry to a
+// remote endpoint.
+//
+// A Policy retries requests that fail with a transient error using an
+// exponential backoff with jitter, honoring any explicit throttle delay
+// received from the endpoint. The number of attempts and the total time
+// spent on a request can be bounded, and a circuit breaker can stop sending
+// requests to an endpoint that keeps failing.
+//
+// Which responses are retried is configured with predicates of HTTP status
+// codes and gRPC codes. These are evaluated by the exporters, so the same
+// Policy can be used with the OTLP, Zipkin, and Jaeger exporters.
+//
+// HTTP exporters use HTTPResponseError and TransportError to mark failed
+// requests as retryable and EvaluateHTTP to evaluate them. The evaluation of
+// gRPC status errors is provided by the
+// go.opentelemetry.io/otel/exporters/retry/retrygrpc package so this package
+// does not depend on gRPC.
+package retry // import "go.opentelemetry.io/otel/exporters/retry"
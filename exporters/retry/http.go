@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry // import "go.opentelemetry.io/otel/exporters/retry"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryableError is a failed request that can be retried.
+type RetryableError struct {
+	// Err is the error the request failed with.
+	Err error
+	// Throttle is the explicit delay requested by the endpoint before the
+	// request is retried. It is zero if no delay was requested.
+	Throttle time.Duration
+}
+
+// Error returns the message of the wrapped error.
+func (e *RetryableError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *RetryableError) Unwrap() error { return e.Err }
+
+// HTTPResponseError returns err as a *RetryableError if p retries requests
+// that received resp. The delay of a Retry-After header in resp is used as
+// the throttle delay. Otherwise, err is returned unchanged.
+func (p Policy) HTTPResponseError(resp *http.Response, err error) error {
+	if !p.HTTPStatusRetryable(resp.StatusCode) {
+		return err
+	}
+	return &RetryableError{Err: err, Throttle: retryAfter(resp.Header)}
+}
+
+// TransportError returns err, an error returned from an http.Client when a
+// request could not be sent or its response not received (i.e. the
+// connection was refused or reset), as a *RetryableError. These failures are
+// transient in most cases. Errors caused by the request context being
+// canceled or its deadline being exceeded are returned unchanged.
+func TransportError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &RetryableError{Err: err}
+}
+
+// EvaluateHTTP is an EvaluateFunc for the errors of HTTP requests. Errors
+// wrapping a *RetryableError, as returned from HTTPResponseError and
+// TransportError, are retryable.
+func EvaluateHTTP(err error) (bool, time.Duration) {
+	var rErr *RetryableError
+	if errors.As(err, &rErr) {
+		return true, rErr.Throttle
+	}
+	return false, 0
+}
+
+// retryAfter returns the delay of the Retry-After header. Both the
+// delay-seconds and the HTTP-date forms are supported. Zero is returned if
+// the header is not set, invalid, or in the past.
+func retryAfter(header http.Header) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if s <= 0 {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now()); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
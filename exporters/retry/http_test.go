@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPResponseError(t *testing.T) {
+	current := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		retryable bool
+		throttle  time.Duration
+	}{
+		{"NotRetryable", http.StatusBadRequest, nil, false, 0},
+		{"Retryable", http.StatusServiceUnavailable, nil, true, 0},
+		{"RetryAfterSeconds", http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}}, true, 3 * time.Second},
+		{"RetryAfterDate", http.StatusTooManyRequests, http.Header{"Retry-After": {current.Add(time.Minute).Format(http.TimeFormat)}}, true, time.Minute},
+		{"RetryAfterPast", http.StatusTooManyRequests, http.Header{"Retry-After": {current.Add(-time.Minute).Format(http.TimeFormat)}}, true, 0},
+		{"RetryAfterInvalid", http.StatusTooManyRequests, http.Header{"Retry-After": {"soon"}}, true, 0},
+		{"RetryAfterNegative", http.StatusTooManyRequests, http.Header{"Retry-After": {"-1"}}, true, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: test.status, Header: test.header}
+			err := Policy{}.HTTPResponseError(resp, assert.AnError)
+			assert.ErrorIs(t, err, assert.AnError)
+
+			retryable, throttle := EvaluateHTTP(fmt.Errorf("wrapped: %w", err))
+			assert.Equal(t, test.retryable, retryable)
+			assert.Equal(t, test.throttle, throttle)
+		})
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	assert.NoError(t, TransportError(nil))
+
+	err := TransportError(assert.AnError)
+	assert.ErrorIs(t, err, assert.AnError)
+	retryable, throttle := EvaluateHTTP(err)
+	assert.True(t, retryable)
+	assert.Equal(t, time.Duration(0), throttle)
+
+	for _, ctxErr := range []error{context.Canceled, context.DeadlineExceeded} {
+		err := TransportError(fmt.Errorf("Post: %w", ctxErr))
+		retryable, _ := EvaluateHTTP(err)
+		assert.False(t, retryable, ctxErr)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry // import "go.opentelemetry.io/otel/exporters/retry"
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Jitter returns the delay to wait for a backoff interval.
+type Jitter func(interval time.Duration) time.Duration
+
+var (
+	randMu sync.Mutex
+	// #nosec G404 -- jitter does not need a cryptographically secure
+	// source of randomness.
+	random = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// randFloat returns a pseudo-random number in [0.0,1.0).
+func randFloat() float64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return random.Float64()
+}
+
+// NoJitter returns interval unchanged.
+func NoJitter(interval time.Duration) time.Duration {
+	return interval
+}
+
+// FullJitter returns a random delay between zero and interval.
+func FullJitter(interval time.Duration) time.Duration {
+	return time.Duration(randFloat() * float64(interval))
+}
+
+// EqualJitter returns half of interval plus a random delay between zero and
+// the other half of interval.
+func EqualJitter(interval time.Duration) time.Duration {
+	half := interval / 2
+	return half + time.Duration(randFloat()*float64(interval-half))
+}
+
+// ProportionalJitter returns a Jitter that returns a random delay within
+// factor times interval of interval. For example, a factor of 0.5 returns a
+// delay between 0.5 and 1.5 times interval. The factor is bounded to [0,1].
+func ProportionalJitter(factor float64) Jitter {
+	if factor < 0 {
+		factor = 0
+	} else if factor > 1 {
+		factor = 1
+	}
+	return func(interval time.Duration) time.Duration {
+		delta := factor * float64(interval)
+		min := float64(interval) - delta
+		return time.Duration(min + randFloat()*(2*delta+1))
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry // import "go.opentelemetry.io/otel/exporters/retry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultPolicy is the recommended Policy to use.
+var DefaultPolicy = Policy{
+	Enabled:         true,
+	InitialInterval: 5 * time.Second,
+	MaxInterval:     30 * time.Second,
+	MaxElapsedTime:  time.Minute,
+}
+
+// multiplier is the factor the backoff interval grows by after each retry.
+const multiplier = 1.5
+
+// defaultCooldown is the time a circuit breaker stays open if no cooldown is
+// configured.
+const defaultCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned for requests not sent because the circuit
+// breaker of a Policy is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Policy defines how failed requests are retried.
+type Policy struct {
+	// Enabled indicates whether failed requests are retried.
+	Enabled bool
+	// InitialInterval is the time to wait after the first failure before
+	// retrying.
+	InitialInterval time.Duration
+	// MaxInterval is the upper bound on the backoff interval. Once this
+	// value is reached the delay between consecutive retries will always be
+	// MaxInterval. If zero, the interval is not bounded.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the maximum amount of time (including retries) spent
+	// trying to send a request. Once this value is reached, the request is
+	// abandoned. If zero, there is no time limit.
+	MaxElapsedTime time.Duration
+	// MaxAttempts is the maximum number of times, including the first, a
+	// request is attempted. If zero or negative, the number of attempts is
+	// only limited by MaxElapsedTime.
+	MaxAttempts int
+
+	// Jitter randomizes the backoff intervals. If nil, intervals are
+	// randomized by ±50% (ProportionalJitter(0.5)).
+	Jitter Jitter
+
+	// RetryableHTTPStatus returns if a request that received a response with
+	// the HTTP status code status is retried. If nil,
+	// DefaultRetryableHTTPStatus is used.
+	RetryableHTTPStatus func(status int) bool
+	// RetryableGRPCCode returns if a request that failed with the gRPC
+	// status code is retried. The code is a google.golang.org/grpc/codes.Code
+	// value. If nil, DefaultRetryableGRPCCode is used.
+	RetryableGRPCCode func(code uint32) bool
+
+	// CircuitBreaker stops requests from being sent after consecutive
+	// failures. It applies even if Enabled is false.
+	CircuitBreaker CircuitBreaker
+}
+
+// CircuitBreaker stops requests from being attempted once a number of
+// consecutive attempts have failed with a retryable error.
+//
+// When open, requests fail with ErrCircuitOpen without being attempted.
+// After Cooldown, a single probe request is attempted while all others still
+// fail with ErrCircuitOpen. The circuit is closed if the probe succeeds,
+// otherwise it is opened for another Cooldown.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failed attempts that open the
+	// circuit. If zero or negative, the circuit breaker is disabled.
+	Threshold int
+	// Cooldown is the time the circuit stays open. If zero, 30 seconds is
+	// used.
+	Cooldown time.Duration
+}
+
+// DefaultRetryableHTTPStatus returns true for the HTTP status codes that
+// indicate a transient failure: 429, 502, 503, and 504.
+func DefaultRetryableHTTPStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// gRPC status codes as defined in google.golang.org/grpc/codes. They are
+// replicated here to not depend on the gRPC module.
+const (
+	grpcCanceled          uint32 = 1
+	grpcDeadlineExceeded  uint32 = 4
+	grpcResourceExhausted uint32 = 8
+	grpcAborted           uint32 = 10
+	grpcOutOfRange        uint32 = 11
+	grpcUnavailable       uint32 = 14
+	grpcDataLoss          uint32 = 15
+)
+
+// DefaultRetryableGRPCCode returns true for the gRPC status codes that
+// indicate a transient failure as defined by the OTLP specification:
+// Canceled, DeadlineExceeded, ResourceExhausted, Aborted, OutOfRange,
+// Unavailable, and DataLoss.
+func DefaultRetryableGRPCCode(code uint32) bool {
+	switch code {
+	case grpcCanceled,
+		grpcDeadlineExceeded,
+		grpcResourceExhausted,
+		grpcAborted,
+		grpcOutOfRange,
+		grpcUnavailable,
+		grpcDataLoss:
+		return true
+	}
+	return false
+}
+
+// HTTPStatusRetryable returns if a request that received a response with
+// the HTTP status code status is retried by p.
+func (p Policy) HTTPStatusRetryable(status int) bool {
+	if p.RetryableHTTPStatus != nil {
+		return p.RetryableHTTPStatus(status)
+	}
+	return DefaultRetryableHTTPStatus(status)
+}
+
+// GRPCCodeRetryable returns if a request that failed with the gRPC status
+// code is retried by p.
+func (p Policy) GRPCCodeRetryable(code uint32) bool {
+	if p.RetryableGRPCCode != nil {
+		return p.RetryableGRPCCode(code)
+	}
+	return DefaultRetryableGRPCCode(code)
+}
+
+// RequestFunc wraps a request with retry logic.
+type RequestFunc func(context.Context, func(context.Context) error) error
+
+// EvaluateFunc returns if an error is retry-able and if an explicit throttle
+// duration should be honored that was included in the error.
+//
+// The function must return true if the error argument is retry-able,
+// otherwise it must return false for the first return parameter.
+//
+// The function must return a non-zero time.Duration if the error contains
+// explicit throttle duration that should be honored, otherwise it must return
+// a zero valued time.Duration.
+type EvaluateFunc func(error) (bool, time.Duration)
+
+// RequestFunc returns a RequestFunc using the evaluate function to determine
+// if requests can be retried and based on the backoff configuration of p.
+//
+// The state of the circuit breaker is shared by all requests made with the
+// returned RequestFunc.
+func (p Policy) RequestFunc(evaluate EvaluateFunc) RequestFunc {
+	cb := newBreaker(p.CircuitBreaker)
+	jitter := p.Jitter
+	if jitter == nil {
+		jitter = ProportionalJitter(0.5)
+	}
+
+	return func(ctx context.Context, fn func(context.Context) error) error {
+		start := now()
+		interval := p.InitialInterval
+		var lastErr error
+		for attempt := 1; ; attempt++ {
+			if !cb.allow() {
+				if lastErr != nil {
+					return fmt.Errorf("%w: %s", ErrCircuitOpen, lastErr)
+				}
+				return ErrCircuitOpen
+			}
+			err := fn(ctx)
+			if err == nil {
+				cb.record(false)
+				return nil
+			}
+			if !p.Enabled && cb == nil {
+				return err
+			}
+
+			retryable, throttle := evaluate(err)
+			cb.record(retryable)
+			if !retryable || !p.Enabled {
+				return err
+			}
+			if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+				return fmt.Errorf("max retry attempts reached: %w", err)
+			}
+
+			bOff := jitter(interval)
+			interval = nextInterval(interval, p.MaxInterval)
+			elapsed := now().Sub(start)
+			if p.MaxElapsedTime != 0 && elapsed+bOff > p.MaxElapsedTime {
+				return fmt.Errorf("max retry time elapsed: %w", err)
+			}
+
+			// Wait for the greater of the backoff or throttle delay.
+			delay := bOff
+			if throttle > bOff {
+				if p.MaxElapsedTime != 0 && elapsed+throttle > p.MaxElapsedTime {
+					return fmt.Errorf("max retry time would elapse: %w", err)
+				}
+				delay = throttle
+			}
+
+			if ctxErr := waitFunc(ctx, delay); ctxErr != nil {
+				return fmt.Errorf("%w: %s", ctxErr, err)
+			}
+			lastErr = err
+		}
+	}
+}
+
+// nextInterval returns the backoff interval following the current one.
+func nextInterval(current, max time.Duration) time.Duration {
+	next := time.Duration(float64(current) * multiplier)
+	if max > 0 && (next > max || next < current) {
+		// Also guards against overflow.
+		return max
+	}
+	return next
+}
+
+// breaker is the state of a CircuitBreaker. A nil *breaker is a disabled
+// circuit breaker.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// probing is true while the single attempt allowed after the cooldown of
+	// an open circuit is in flight.
+	probing bool
+}
+
+func newBreaker(c CircuitBreaker) *breaker {
+	if c.Threshold <= 0 {
+		return nil
+	}
+	b := &breaker{threshold: c.Threshold, cooldown: c.Cooldown}
+	if b.cooldown <= 0 {
+		b.cooldown = defaultCooldown
+	}
+	return b
+}
+
+// allow returns if an attempt can be made. Once the cooldown of an open
+// circuit has passed, only a single attempt is allowed until its outcome is
+// recorded.
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		// Closed.
+		return true
+	}
+	if now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record records the outcome of an attempt. Only failures that are
+// retryable, and therefore likely to affect following attempts, count
+// towards opening the circuit.
+func (b *breaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now().Add(b.cooldown)
+	}
+}
+
+// Allow override for testing.
+var (
+	now      = time.Now
+	waitFunc = wait
+)
+
+// wait takes the caller's context, and the amount of time to wait.  It will
+// return nil if the timer fires before or at the same time as the context's
+// deadline.  This indicates that the call can be retried.
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Handle the case where the timer and context deadline end
+		// simultaneously by prioritizing the timer expiration nil value
+		// response.
+		select {
+		case <-timer.C:
+		default:
+			return ctx.Err()
+		}
+	case <-timer.C:
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		ctx      context.Context
+		delay    time.Duration
+		expected error
+	}{
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(0),
+		},
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(1),
+		},
+		{
+			ctx:   context.Background(),
+			delay: time.Duration(-1),
+		},
+		{
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			// Ensure the timer and context do not end simultaneously.
+			delay:    1 * time.Hour,
+			expected: context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		err := wait(test.ctx, test.delay)
+		if test.expected == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, test.expected)
+		}
+	}
+}
+
+func TestNonRetryableError(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return false, 0 }
+
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: 1 * time.Nanosecond,
+		MaxInterval:     1 * time.Nanosecond,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+	ctx := context.Background()
+	assert.NoError(t, reqFunc(ctx, func(context.Context) error {
+		return nil
+	}))
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}), assert.AnError)
+}
+
+func TestThrottledRetry(t *testing.T) {
+	// Ensure the throttle delay is used by making longer than backoff delay.
+	throttleDelay, backoffDelay := time.Second, time.Nanosecond
+
+	ev := func(error) (bool, time.Duration) {
+		// Retry everything with a throttle delay.
+		return true, throttleDelay
+	}
+
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: backoffDelay,
+		MaxInterval:     backoffDelay,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+
+	origWait := waitFunc
+	var done bool
+	waitFunc = func(_ context.Context, delay time.Duration) error {
+		assert.Equal(t, throttleDelay, delay, "retry not throttled")
+		// Try twice to ensure call is attempted again after delay.
+		if done {
+			return assert.AnError
+		}
+		done = true
+		return nil
+	}
+	defer func() { waitFunc = origWait }()
+
+	ctx := context.Background()
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return errors.New("not this error")
+	}), assert.AnError)
+}
+
+func TestBackoffRetry(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	delay := time.Nanosecond
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: delay,
+		MaxInterval:     delay,
+		// Never stop retrying.
+		MaxElapsedTime: 0,
+	}.RequestFunc(ev)
+
+	origWait := waitFunc
+	var done bool
+	waitFunc = func(_ context.Context, d time.Duration) error {
+		delta := math.Ceil(float64(delay) * 0.5)
+		assert.InDelta(t, delay, d, delta, "retry not backoffed")
+		// Try twice to ensure call is attempted again after delay.
+		if done {
+			return assert.AnError
+		}
+		done = true
+		return nil
+	}
+	t.Cleanup(func() { waitFunc = origWait })
+
+	ctx := context.Background()
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return errors.New("not this error")
+	}), assert.AnError)
+}
+
+func TestBackoffRetryCanceledContext(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+
+	delay := time.Millisecond
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: delay,
+		MaxInterval:     delay,
+		// Never stop retrying.
+		MaxElapsedTime: 10 * time.Millisecond,
+	}.RequestFunc(ev)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	cancel()
+	err := reqFunc(ctx, func(context.Context) error {
+		count++
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), assert.AnError.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestThrottledRetryGreaterThanMaxElapsedTime(t *testing.T) {
+	// Ensure the throttle delay is used by making longer than backoff delay.
+	tDelay, bDelay := time.Hour, time.Nanosecond
+	ev := func(error) (bool, time.Duration) { return true, tDelay }
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: bDelay,
+		MaxInterval:     bDelay,
+		MaxElapsedTime:  tDelay - (time.Nanosecond),
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	assert.Contains(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}).Error(), "max retry time would elapse: ")
+}
+
+func TestMaxElapsedTime(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	delay := time.Nanosecond
+	reqFunc := Policy{
+		Enabled: true,
+		// InitialInterval > MaxElapsedTime means immediate return.
+		InitialInterval: 2 * delay,
+		MaxElapsedTime:  delay,
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	assert.Contains(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}).Error(), "max retry time elapsed: ")
+}
+
+func TestRetryNotEnabled(t *testing.T) {
+	ev := func(error) (bool, time.Duration) {
+		t.Error("evaluated retry when not enabled")
+		return false, 0
+	}
+
+	reqFunc := Policy{}.RequestFunc(ev)
+	ctx := context.Background()
+	assert.NoError(t, reqFunc(ctx, func(context.Context) error {
+		return nil
+	}))
+	assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+		return assert.AnError
+	}), assert.AnError)
+}
+
+func TestMaxAttempts(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		MaxAttempts:     3,
+	}.RequestFunc(ev)
+
+	var count int
+	err := reqFunc(context.Background(), func(context.Context) error {
+		count++
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "max retry attempts reached: ")
+	assert.Equal(t, 3, count)
+}
+
+func TestJitter(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	var delays []time.Duration
+	origWait := waitFunc
+	waitFunc = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() { waitFunc = origWait })
+
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     200 * time.Millisecond,
+		MaxAttempts:     5,
+		Jitter:          NoJitter,
+	}.RequestFunc(ev)
+	_ = reqFunc(context.Background(), func(context.Context) error {
+		return assert.AnError
+	})
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond,
+		150 * time.Millisecond,
+		200 * time.Millisecond,
+		200 * time.Millisecond,
+	}, delays)
+}
+
+func TestJitterStrategies(t *testing.T) {
+	const interval = time.Second
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, interval, NoJitter(interval))
+
+		d := FullJitter(interval)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, interval)
+
+		d = EqualJitter(interval)
+		assert.GreaterOrEqual(t, d, interval/2)
+		assert.LessOrEqual(t, d, interval)
+
+		d = ProportionalJitter(0.25)(interval)
+		assert.GreaterOrEqual(t, d, 750*time.Millisecond)
+		assert.LessOrEqual(t, d, 1250*time.Millisecond)
+	}
+	assert.Equal(t, interval, ProportionalJitter(-1)(interval))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	current := time.Unix(0, 0)
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	retryable := errors.New("retryable")
+	ev := func(err error) (bool, time.Duration) { return err == retryable, 0 }
+	reqFunc := Policy{
+		CircuitBreaker: CircuitBreaker{Threshold: 2, Cooldown: time.Minute},
+	}.RequestFunc(ev)
+
+	var count int
+	fail := func(err error) func(context.Context) error {
+		return func(context.Context) error {
+			count++
+			return err
+		}
+	}
+	ctx := context.Background()
+
+	// Non-retryable failures do not open the circuit.
+	assert.ErrorIs(t, reqFunc(ctx, fail(assert.AnError)), assert.AnError)
+	assert.ErrorIs(t, reqFunc(ctx, fail(retryable)), retryable)
+	assert.ErrorIs(t, reqFunc(ctx, fail(assert.AnError)), assert.AnError)
+	assert.ErrorIs(t, reqFunc(ctx, fail(retryable)), retryable)
+	assert.ErrorIs(t, reqFunc(ctx, fail(retryable)), retryable)
+	assert.Equal(t, 5, count)
+
+	assert.ErrorIs(t, reqFunc(ctx, fail(nil)), ErrCircuitOpen)
+	assert.Equal(t, 5, count, "request attempted with open circuit")
+
+	// A failed attempt after the cooldown opens the circuit again.
+	current = current.Add(time.Minute)
+	assert.ErrorIs(t, reqFunc(ctx, fail(retryable)), retryable)
+	assert.ErrorIs(t, reqFunc(ctx, fail(nil)), ErrCircuitOpen)
+
+	current = current.Add(time.Minute)
+	assert.NoError(t, reqFunc(ctx, fail(nil)))
+	assert.NoError(t, reqFunc(ctx, fail(nil)))
+	assert.Equal(t, 8, count)
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	current := time.Unix(0, 0)
+	origNow := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = origNow })
+
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Policy{
+		CircuitBreaker: CircuitBreaker{Threshold: 1, Cooldown: time.Minute},
+	}.RequestFunc(ev)
+
+	ctx := context.Background()
+	require.ErrorIs(t, reqFunc(ctx, func(context.Context) error { return assert.AnError }), assert.AnError)
+
+	current = current.Add(time.Minute)
+	var probes int
+	err := reqFunc(ctx, func(context.Context) error {
+		probes++
+		// Concurrent requests are not attempted while the probe is in flight.
+		assert.ErrorIs(t, reqFunc(ctx, func(context.Context) error {
+			probes++
+			return nil
+		}), ErrCircuitOpen)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, probes)
+
+	// The successful probe closed the circuit.
+	assert.NoError(t, reqFunc(ctx, func(context.Context) error { return nil }))
+}
+
+func TestCircuitBreakerStopsRetries(t *testing.T) {
+	ev := func(error) (bool, time.Duration) { return true, 0 }
+	reqFunc := Policy{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+		CircuitBreaker:  CircuitBreaker{Threshold: 3},
+	}.RequestFunc(ev)
+
+	var count int
+	err := reqFunc(context.Background(), func(context.Context) error {
+		count++
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Contains(t, err.Error(), assert.AnError.Error())
+	assert.Equal(t, 3, count)
+}
+
+func TestRetryablePredicates(t *testing.T) {
+	var p Policy
+	assert.True(t, p.HTTPStatusRetryable(http.StatusServiceUnavailable))
+	assert.True(t, p.HTTPStatusRetryable(http.StatusTooManyRequests))
+	assert.False(t, p.HTTPStatusRetryable(http.StatusBadRequest))
+	assert.True(t, p.GRPCCodeRetryable(grpcUnavailable))
+	assert.False(t, p.GRPCCodeRetryable(3)) // InvalidArgument
+
+	const grpcInternal = 13
+	p = Policy{
+		RetryableHTTPStatus: func(status int) bool { return status == http.StatusInternalServerError },
+		RetryableGRPCCode:   func(code uint32) bool { return code == grpcInternal },
+	}
+	assert.True(t, p.HTTPStatusRetryable(http.StatusInternalServerError))
+	assert.False(t, p.HTTPStatusRetryable(http.StatusServiceUnavailable))
+	assert.True(t, p.GRPCCodeRetryable(grpcInternal))
+	assert.False(t, p.GRPCCodeRetryable(grpcUnavailable))
+}
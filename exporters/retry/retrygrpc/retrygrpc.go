@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retrygrpc provides the evaluation of gRPC status errors for the
+// retry policies of go.opentelemetry.io/otel/exporters/retry.
+package retrygrpc // import "go.opentelemetry.io/otel/exporters/retry/retrygrpc"
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/exporters/retry"
+)
+
+// Evaluate returns a retry.EvaluateFunc that reports errors with a gRPC
+// status code p considers retryable as retryable. The delay of a RetryInfo
+// detail included in the status is used as the throttle delay.
+func Evaluate(p retry.Policy) retry.EvaluateFunc {
+	return func(err error) (bool, time.Duration) {
+		s, ok := status.FromError(err)
+		if !ok || !p.GRPCCodeRetryable(uint32(s.Code())) {
+			// Not a retry-able error.
+			return false, 0
+		}
+		return true, throttleDelay(s)
+	}
+}
+
+// throttleDelay returns a duration to wait for if an explicit throttle time
+// is included in the response status.
+func throttleDelay(s *status.Status) time.Duration {
+	for _, detail := range s.Details() {
+		if t, ok := detail.(*errdetails.RetryInfo); ok {
+			return t.RetryDelay.AsDuration()
+		}
+	}
+	return 0
+}
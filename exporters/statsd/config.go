@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	defaultUDPEndpoint = "localhost:8125"
+
+	// defaultUDPPacketSize is the default max size of a UDP datagram. It fits
+	// within the MTU of most networks, synced with the DogStatsD clients.
+	defaultUDPPacketSize = 1432
+	// defaultUnixPacketSize is the default max size of a Unix datagram,
+	// synced with the DogStatsD clients.
+	defaultUnixPacketSize = 8192
+	// maxPacketSize is the max size of any datagram the Exporter sends.
+	maxPacketSize = 65000
+)
+
+// HistogramFormat defines how histogram data is sent.
+type HistogramFormat int
+
+const (
+	// HistogramSamples sends each non-empty bucket of a histogram as a single
+	// sampled histogram (h) or distribution (d) value. The value is the
+	// middle of the bucket, bounded by the histogram minimum and maximum,
+	// and the sample rate is the inverse of the bucket count.
+	//
+	// Only histograms with delta temporality can be sent as samples.
+	// Histograms with cumulative temporality are sent using the
+	// HistogramSummary format.
+	HistogramSamples HistogramFormat = iota
+	// HistogramSummary sends the count and sum of a histogram as counters,
+	// the min and max as gauges, and each bucket count as a counter. The
+	// count, sum, and bucket counts of histograms with cumulative
+	// temporality are sent as gauges instead of counters. Bucket
+	// counters are tagged with their upper bound in the DogStatsD format,
+	// otherwise the bound is added to the metric name.
+	HistogramSummary
+)
+
+// config contains options for the exporter.
+type config struct {
+	network         string
+	address         string
+	maxPacketSize   int
+	dogStatsD       bool
+	prefix          string
+	histogramFormat HistogramFormat
+
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(options ...Option) config {
+	cfg := config{
+		network: "udp",
+		address: defaultUDPEndpoint,
+	}
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+
+	if cfg.maxPacketSize <= 0 || cfg.maxPacketSize > maxPacketSize {
+		cfg.maxPacketSize = defaultUDPPacketSize
+		if cfg.network == "unixgram" {
+			cfg.maxPacketSize = defaultUnixPacketSize
+		}
+	}
+
+	if cfg.temporalitySelector == nil {
+		cfg.temporalitySelector = DefaultTemporalitySelector
+	}
+
+	if cfg.aggregationSelector == nil {
+		cfg.aggregationSelector = metric.DefaultAggregationSelector
+	}
+
+	return cfg
+}
+
+// DefaultTemporalitySelector is the TemporalitySelector used if
+// WithTemporalitySelector is not provided. DeltaTemporality is used for
+// counters and histograms, which StatsD agents aggregate themselves, and
+// CumulativeTemporality is used for all other instrument kinds so they can
+// be sent as gauges.
+func DefaultTemporalitySelector(k metric.InstrumentKind) metricdata.Temporality {
+	switch k {
+	case metric.InstrumentKindCounter, metric.InstrumentKindObservableCounter, metric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	}
+	return metricdata.CumulativeTemporality
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (o optionFunc) apply(c config) config {
+	return o(c)
+}
+
+// WithUDPEndpoint sets the host:port address of the agent the Exporter sends
+// data to over UDP. If neither this option nor WithUnixEndpoint is used,
+// localhost:8125 is used.
+func WithUDPEndpoint(address string) Option {
+	return optionFunc(func(c config) config {
+		c.network = "udp"
+		c.address = address
+		return c
+	})
+}
+
+// WithUnixEndpoint sets the path of the Unix datagram socket of the agent the
+// Exporter sends data to.
+func WithUnixEndpoint(path string) Option {
+	return optionFunc(func(c config) config {
+		c.network = "unixgram"
+		c.address = path
+		return c
+	})
+}
+
+// WithMaxPacketSize sets the max size, in bytes, of a datagram sent to the
+// agent. Metric lines are batched into datagrams up to this size.
+//
+// If this option is not used or size is not in the range (0, 65000], 1432 is
+// used for UDP and 8192 for Unix datagram sockets.
+func WithMaxPacketSize(size int) Option {
+	return optionFunc(func(c config) config {
+		c.maxPacketSize = size
+		return c
+	})
+}
+
+// WithDogStatsD configures the Exporter to use the DogStatsD format. Metric
+// attributes are sent as tags and histogram samples are sent as
+// distributions.
+func WithDogStatsD() Option {
+	return optionFunc(func(c config) config {
+		c.dogStatsD = true
+		return c
+	})
+}
+
+// WithPrefix sets a prefix that is added to all metric names.
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(c config) config {
+		c.prefix = prefix
+		return c
+	})
+}
+
+// WithHistogramFormat sets the format used to send histogram data. If this
+// option is not used, HistogramSamples is used.
+func WithHistogramFormat(format HistogramFormat) Option {
+	return optionFunc(func(c config) config {
+		c.histogramFormat = format
+		return c
+	})
+}
+
+// WithTemporalitySelector sets the TemporalitySelector the exporter will use
+// to determine the Temporality of an instrument based on its kind. If this
+// option is not used, the exporter will use the DefaultTemporalitySelector.
+func WithTemporalitySelector(selector metric.TemporalitySelector) Option {
+	return optionFunc(func(c config) config {
+		c.temporalitySelector = selector
+		return c
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the exporter will use
+// to determine the aggregation to use for an instrument based on its kind. If
+// this option is not used, the exporter will use the
+// DefaultAggregationSelector from the go.opentelemetry.io/otel/sdk/metric
+// package or the aggregation explicitly passed for a view matching an
+// instrument.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return optionFunc(func(c config) config {
+		c.aggregationSelector = selector
+		return c
+	})
+}
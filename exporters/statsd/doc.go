@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statsd provides a metric Exporter that sends metric data to a
+// StatsD or DogStatsD agent over UDP or a Unix datagram socket.
+//
+// Metric data is mapped to StatsD metric types as follows:
+//
+//   - Sums with delta temporality are sent as counters (c).
+//   - Sums with cumulative temporality and gauges are sent as gauges (g).
+//   - Histograms are sent as sampled histogram (h) or, for DogStatsD,
+//     distribution (d) values. Each bucket is sent as one representative
+//     value with a sample rate that accounts for the bucket count.
+//     Alternatively, histograms can be sent as count, sum, min, max and
+//     per-bucket summaries using WithHistogramFormat. Histograms with
+//     cumulative temporality are always sent as summaries.
+//
+// By default, the Exporter requests delta temporality for counters and
+// histograms, and cumulative temporality for up-down counters and gauges.
+//
+// Plain StatsD has no concept of tags. Attributes are only sent when the
+// DogStatsD format is used, otherwise all streams of an instrument are
+// combined by the agent.
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
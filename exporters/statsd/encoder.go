@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// StatsD metric types.
+const (
+	counterType      = "c"
+	gaugeType        = "g"
+	histogramType    = "h"
+	distributionType = "d"
+)
+
+// encoder encodes metric data into StatsD lines.
+type encoder struct {
+	prefix          string
+	dogStatsD       bool
+	histogramFormat HistogramFormat
+}
+
+// encode returns the StatsD lines representing all data in rm.
+func (e encoder) encode(rm metricdata.ResourceMetrics) []string {
+	var lines []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			name := sanitizeName(e.prefix + m.Name)
+			switch a := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				lines = encodeSum(e, lines, name, a)
+			case metricdata.Sum[float64]:
+				lines = encodeSum(e, lines, name, a)
+			case metricdata.Gauge[int64]:
+				for _, dp := range a.DataPoints {
+					lines = e.gauge(lines, name, float64(dp.Value), dp.Attributes)
+				}
+			case metricdata.Gauge[float64]:
+				for _, dp := range a.DataPoints {
+					lines = e.gauge(lines, name, dp.Value, dp.Attributes)
+				}
+			case metricdata.Histogram:
+				lines = e.encodeHistogram(lines, name, a)
+			}
+		}
+	}
+	return lines
+}
+
+func encodeSum[N int64 | float64](e encoder, lines []string, name string, sum metricdata.Sum[N]) []string {
+	for _, dp := range sum.DataPoints {
+		if sum.Temporality == metricdata.DeltaTemporality {
+			lines = append(lines, e.line(name, float64(dp.Value), counterType, 1, dp.Attributes))
+		} else {
+			lines = e.gauge(lines, name, float64(dp.Value), dp.Attributes)
+		}
+	}
+	return lines
+}
+
+// gauge appends the lines setting the gauge name to value.
+func (e encoder) gauge(lines []string, name string, value float64, attrs attribute.Set) []string {
+	if value < 0 && !e.dogStatsD {
+		// A signed value is interpreted as a change to the current gauge
+		// value by StatsD. Reset the gauge first so the result is absolute.
+		lines = append(lines, e.line(name, 0, gaugeType, 1, attrs))
+	}
+	return append(lines, e.line(name, value, gaugeType, 1, attrs))
+}
+
+func (e encoder) encodeHistogram(lines []string, name string, h metricdata.Histogram) []string {
+	if e.histogramFormat == HistogramSummary || h.Temporality != metricdata.DeltaTemporality {
+		// Samples are aggregated by the StatsD agent, sending the samples of
+		// cumulative data would count them again every collection. The
+		// summary encodes cumulative data as gauges instead.
+		return e.histogramSummary(lines, name, h)
+	}
+
+	typ := histogramType
+	if e.dogStatsD {
+		typ = distributionType
+	}
+	for _, dp := range h.DataPoints {
+		for i, n := range dp.BucketCounts {
+			if n == 0 {
+				continue
+			}
+			v := bucketValue(dp, i)
+			lines = append(lines, e.line(name, v, typ, 1/float64(n), dp.Attributes))
+		}
+	}
+	return lines
+}
+
+// bucketValue returns the representative value of bucket i of dp. This is
+// the middle of the bucket range, bounded by the min and max of dp if known.
+func bucketValue(dp metricdata.HistogramDataPoint, i int) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		lower = dp.Bounds[i-1]
+	}
+	if i < len(dp.Bounds) {
+		upper = dp.Bounds[i]
+	}
+	if dp.Min != nil && *dp.Min > lower {
+		lower = *dp.Min
+	}
+	if dp.Max != nil && *dp.Max < upper {
+		upper = *dp.Max
+	}
+
+	switch {
+	case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+		return 0
+	case math.IsInf(lower, -1):
+		return upper
+	case math.IsInf(upper, 1):
+		return lower
+	}
+	return lower + (upper-lower)/2
+}
+
+func (e encoder) histogramSummary(lines []string, name string, h metricdata.Histogram) []string {
+	typ := gaugeType
+	if h.Temporality == metricdata.DeltaTemporality {
+		typ = counterType
+	}
+	for _, dp := range h.DataPoints {
+		lines = append(lines,
+			e.line(name+".count", float64(dp.Count), typ, 1, dp.Attributes),
+			e.line(name+".sum", dp.Sum, typ, 1, dp.Attributes),
+		)
+		if dp.Min != nil {
+			lines = e.gauge(lines, name+".min", *dp.Min, dp.Attributes)
+		}
+		if dp.Max != nil {
+			lines = e.gauge(lines, name+".max", *dp.Max, dp.Attributes)
+		}
+
+		for i, n := range dp.BucketCounts {
+			le := "+Inf"
+			if i < len(dp.Bounds) {
+				le = formatValue(dp.Bounds[i])
+			}
+			if e.dogStatsD {
+				attrs := append(dp.Attributes.ToSlice(), attribute.String("le", le))
+				set := attribute.NewSet(attrs...)
+				lines = append(lines, e.line(name+".bucket", float64(n), typ, 1, set))
+			} else {
+				bucket := name + ".bucket.le_" + strings.NewReplacer(".", "_", "+", "").Replace(le)
+				lines = append(lines, e.line(bucket, float64(n), typ, 1, dp.Attributes))
+			}
+		}
+	}
+	return lines
+}
+
+// line returns a single StatsD line.
+func (e encoder) line(name string, value float64, typ string, rate float64, attrs attribute.Set) string {
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte(':')
+	b.WriteString(formatValue(value))
+	b.WriteByte('|')
+	b.WriteString(typ)
+	if rate < 1 {
+		b.WriteString("|@")
+		b.WriteString(strconv.FormatFloat(rate, 'g', -1, 64))
+	}
+	if e.dogStatsD && attrs.Len() > 0 {
+		b.WriteString("|#")
+		itr := attrs.Iter()
+		for itr.Next() {
+			i, kv := itr.IndexedAttribute()
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(sanitizeTag(string(kv.Key)))
+			b.WriteByte(':')
+			b.WriteString(sanitizeTag(kv.Value.Emit()))
+		}
+	}
+	return b.String()
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// sanitizeName replaces all characters that have a meaning in the StatsD
+// line protocol with an underscore.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', '#', ',', ' ', '\n':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// sanitizeTag replaces all characters that have a meaning in the DogStatsD
+// tag syntax with an underscore. Colons are allowed in tag values, and the
+// first colon is used to separate a tag key from its value.
+func sanitizeTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '|', '#', ',', '\n':
+			return '_'
+		}
+		return r
+	}, tag)
+}
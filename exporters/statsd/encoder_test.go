@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var attrs = attribute.NewSet(attribute.String("method", "GET"), attribute.Int("code", 200))
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func rm(m ...metricdata.Metrics) metricdata.ResourceMetrics {
+	return metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: m}},
+	}
+}
+
+var histogram = metricdata.Metrics{
+	Name: "latency",
+	Data: metricdata.Histogram{
+		Temporality: metricdata.DeltaTemporality,
+		DataPoints: []metricdata.HistogramDataPoint{{
+			Attributes:   attrs,
+			Count:        6,
+			Bounds:       []float64{10, 100},
+			BucketCounts: []uint64{1, 0, 5},
+			Min:          float64Ptr(2),
+			Max:          float64Ptr(300),
+			Sum:          1002,
+		}},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		name string
+		enc  encoder
+		data metricdata.ResourceMetrics
+		want []string
+	}{
+		{
+			name: "DeltaSum",
+			data: rm(metricdata.Metrics{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}},
+				},
+			}),
+			want: []string{"requests:3|c"},
+		},
+		{
+			name: "CumulativeSumAsGauge",
+			data: rm(metricdata.Metrics{
+				Name: "queue",
+				Data: metricdata.Sum[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  []metricdata.DataPoint[float64]{{Value: 2.5}},
+				},
+			}),
+			want: []string{"queue:2.5|g"},
+		},
+		{
+			name: "NegativeGauge",
+			data: rm(metricdata.Metrics{
+				Name: "temperature",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Value: -4}},
+				},
+			}),
+			want: []string{"temperature:0|g", "temperature:-4|g"},
+		},
+		{
+			name: "DogStatsDNegativeGauge",
+			enc:  encoder{dogStatsD: true},
+			data: rm(metricdata.Metrics{
+				Name: "temperature",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{Value: -4}},
+				},
+			}),
+			want: []string{"temperature:-4|g"},
+		},
+		{
+			name: "DogStatsDTags",
+			enc:  encoder{dogStatsD: true, prefix: "app."},
+			data: rm(metricdata.Metrics{
+				Name: "requests",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.DeltaTemporality,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 1}},
+				},
+			}),
+			want: []string{"app.requests:1|c|#code:200,method:GET"},
+		},
+		{
+			name: "SanitizedName",
+			data: rm(metricdata.Metrics{
+				Name: "a:b|c@d",
+				Data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{Value: 1}},
+				},
+			}),
+			want: []string{"a_b_c_d:1|g"},
+		},
+		{
+			name: "HistogramSamples",
+			data: rm(histogram),
+			want: []string{"latency:6|h", "latency:200|h|@0.2"},
+		},
+		{
+			name: "HistogramDistribution",
+			enc:  encoder{dogStatsD: true},
+			data: rm(histogram),
+			want: []string{
+				"latency:6|d|#code:200,method:GET",
+				"latency:200|d|@0.2|#code:200,method:GET",
+			},
+		},
+		{
+			name: "HistogramSummary",
+			enc:  encoder{histogramFormat: HistogramSummary},
+			data: rm(histogram),
+			want: []string{
+				"latency.count:6|c",
+				"latency.sum:1002|c",
+				"latency.min:2|g",
+				"latency.max:300|g",
+				"latency.bucket.le_10:1|c",
+				"latency.bucket.le_100:0|c",
+				"latency.bucket.le_Inf:5|c",
+			},
+		},
+		{
+			name: "DogStatsDHistogramSummary",
+			enc:  encoder{dogStatsD: true, histogramFormat: HistogramSummary},
+			data: rm(histogram),
+			want: []string{
+				"latency.count:6|c|#code:200,method:GET",
+				"latency.sum:1002|c|#code:200,method:GET",
+				"latency.min:2|g|#code:200,method:GET",
+				"latency.max:300|g|#code:200,method:GET",
+				"latency.bucket:1|c|#code:200,le:10,method:GET",
+				"latency.bucket:0|c|#code:200,le:100,method:GET",
+				"latency.bucket:5|c|#code:200,le:+Inf,method:GET",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.enc.encode(tc.data))
+		})
+	}
+}
+
+func TestBucketValue(t *testing.T) {
+	dp := metricdata.HistogramDataPoint{Bounds: []float64{0, 10}}
+	assert.Equal(t, 0.0, bucketValue(dp, 0), "unbounded first bucket uses upper bound")
+	assert.Equal(t, 5.0, bucketValue(dp, 1))
+	assert.Equal(t, 10.0, bucketValue(dp, 2), "unbounded last bucket uses lower bound")
+
+	dp.Min, dp.Max = float64Ptr(4), float64Ptr(8)
+	assert.Equal(t, 6.0, bucketValue(dp, 1), "min and max bound the bucket")
+}
+
+func TestBatch(t *testing.T) {
+	lines := []string{"a:1|c", "b:2|c", "c:3|c", "too_long_for_a_packet:1|c"}
+
+	packets, dropped := batch(lines, 11)
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, [][]byte{[]byte("a:1|c\nb:2|c"), []byte("c:3|c")}, packets)
+
+	packets, dropped = batch(lines[:3], 1000)
+	assert.Equal(t, 0, dropped)
+	assert.Equal(t, [][]byte{[]byte("a:1|c\nb:2|c\nc:3|c")}, packets)
+}
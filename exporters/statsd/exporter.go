@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd // import "go.opentelemetry.io/otel/exporters/statsd"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// exporter is an OpenTelemetry metric exporter that sends data to a StatsD
+// agent.
+type exporter struct {
+	enc           encoder
+	maxPacketSize int
+
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+
+	connMu sync.Mutex
+	conn   net.Conn
+}
+
+// New returns a metric exporter that sends metric data to a StatsD agent.
+//
+// If no options are passed, the returned exporter sends data in the plain
+// StatsD format over UDP to localhost:8125.
+func New(options ...Option) (metric.Exporter, error) {
+	cfg := newConfig(options...)
+	conn, err := net.Dial(cfg.network, cfg.address)
+	if err != nil {
+		return nil, fmt.Errorf("statsd: failed to connect to %s: %w", cfg.address, err)
+	}
+
+	return &exporter{
+		enc: encoder{
+			prefix:          cfg.prefix,
+			dogStatsD:       cfg.dogStatsD,
+			histogramFormat: cfg.histogramFormat,
+		},
+		maxPacketSize:       cfg.maxPacketSize,
+		temporalitySelector: cfg.temporalitySelector,
+		aggregationSelector: cfg.aggregationSelector,
+		conn:                conn,
+	}, nil
+}
+
+func (e *exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return e.temporalitySelector(k)
+}
+
+func (e *exporter) Aggregation(k metric.InstrumentKind) aggregation.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export encodes data as StatsD lines and sends them to the agent in
+// datagrams no larger than the configured max packet size. Lines that do not
+// fit in a single datagram are dropped and reported in the returned error.
+func (e *exporter) Export(ctx context.Context, data metricdata.ResourceMetrics) error {
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+	if e.conn == nil {
+		return metric.ErrExporterShutdown
+	}
+
+	packets, dropped := batch(e.enc.encode(data), e.maxPacketSize)
+	for _, p := range packets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := e.conn.Write(p); err != nil {
+			return fmt.Errorf("statsd: failed to send metrics: %w", err)
+		}
+	}
+
+	if dropped > 0 {
+		return fmt.Errorf("statsd: dropped %d metric lines larger than the max packet size %d", dropped, e.maxPacketSize)
+	}
+	return nil
+}
+
+// batch joins lines into newline separated packets no larger than max bytes.
+// The number of lines that do not fit into a packet on their own is
+// returned.
+func batch(lines []string, max int) (packets [][]byte, dropped int) {
+	var b strings.Builder
+	for _, l := range lines {
+		if len(l) > max {
+			dropped++
+			continue
+		}
+		if b.Len() > 0 && b.Len()+1+len(l) > max {
+			packets = append(packets, []byte(b.String()))
+			b.Reset()
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(l)
+	}
+	if b.Len() > 0 {
+		packets = append(packets, []byte(b.String()))
+	}
+	return packets, dropped
+}
+
+func (e *exporter) ForceFlush(ctx context.Context) error {
+	// exporter holds no state, nothing to flush.
+	return ctx.Err()
+}
+
+func (e *exporter) Shutdown(ctx context.Context) error {
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// readPackets reads all datagrams received by conn until it is idle.
+func readPackets(t *testing.T, conn net.PacketConn) []string {
+	t.Helper()
+
+	var packets []string
+	buf := make([]byte, maxPacketSize)
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return packets
+		}
+		packets = append(packets, string(buf[:n]))
+	}
+}
+
+func TestExporterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	exp, err := New(WithUDPEndpoint(conn.LocalAddr().String()), WithDogStatsD())
+	require.NoError(t, err)
+
+	reader := metric.NewPeriodicReader(exp)
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	ctx := context.Background()
+
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+	ctr.Add(ctx, 2, attribute.String("method", "GET"))
+	require.NoError(t, reader.ForceFlush(ctx))
+	ctr.Add(ctx, 3, attribute.String("method", "GET"))
+	require.NoError(t, reader.ForceFlush(ctx))
+
+	assert.Equal(t, []string{
+		"requests:2|c|#method:GET",
+		"requests:3|c|#method:GET",
+	}, readPackets(t, conn))
+
+	require.NoError(t, mp.Shutdown(ctx))
+}
+
+func TestExporterCumulativeHistogram(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	exp, err := New(
+		WithUDPEndpoint(conn.LocalAddr().String()),
+		WithTemporalitySelector(metric.DefaultTemporalitySelector),
+	)
+	require.NoError(t, err)
+
+	reader := metric.NewPeriodicReader(exp)
+	mp := metric.NewMeterProvider(
+		metric.WithReader(reader),
+		metric.WithView(metric.NewView(
+			metric.Instrument{Name: "latency"},
+			metric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{
+				Boundaries: []float64{10},
+				NoMinMax:   true,
+			}},
+		)),
+	)
+	ctx := context.Background()
+
+	hist, err := mp.Meter("test").Float64Histogram("latency")
+	require.NoError(t, err)
+	hist.Record(ctx, 5)
+	require.NoError(t, reader.ForceFlush(ctx))
+	hist.Record(ctx, 50)
+	require.NoError(t, reader.ForceFlush(ctx))
+
+	// Cumulative histograms are sent as gauges so the agent does not count
+	// the measurements of the first collection twice.
+	assert.Equal(t, []string{
+		"latency.count:1|g\nlatency.sum:5|g\nlatency.bucket.le_10:1|g\nlatency.bucket.le_Inf:0|g",
+		"latency.count:2|g\nlatency.sum:55|g\nlatency.bucket.le_10:1|g\nlatency.bucket.le_Inf:1|g",
+	}, readPackets(t, conn))
+
+	require.NoError(t, mp.Shutdown(ctx))
+}
+
+func TestExporterUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram not supported: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	exp, err := New(WithUnixEndpoint(path))
+	require.NoError(t, err)
+	assert.Equal(t, defaultUnixPacketSize, exp.(*exporter).maxPacketSize)
+
+	data := rm(metricdata.Metrics{
+		Name: "queue",
+		Data: metricdata.Gauge[int64]{
+			DataPoints: []metricdata.DataPoint[int64]{{Value: 7}},
+		},
+	})
+	require.NoError(t, exp.Export(context.Background(), data))
+	assert.Equal(t, []string{"queue:7|g"}, readPackets(t, conn))
+}
+
+func TestExporterMaxPacketSize(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	exp, err := New(WithUDPEndpoint(conn.LocalAddr().String()), WithMaxPacketSize(20))
+	require.NoError(t, err)
+
+	dps := make([]metricdata.DataPoint[int64], 5)
+	for i := range dps {
+		dps[i].Value = int64(i)
+	}
+	data := rm(metricdata.Metrics{
+		Name: "items",
+		Data: metricdata.Gauge[int64]{DataPoints: dps},
+	})
+	require.NoError(t, exp.Export(context.Background(), data))
+
+	packets := readPackets(t, conn)
+	assert.Len(t, packets, 3)
+	var lines []string
+	for _, p := range packets {
+		assert.LessOrEqual(t, len(p), 20)
+		lines = append(lines, strings.Split(p, "\n")...)
+	}
+	assert.Equal(t, []string{"items:0|g", "items:1|g", "items:2|g", "items:3|g", "items:4|g"}, lines)
+}
+
+func TestExporterShutdown(t *testing.T) {
+	exp, err := New()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.NoError(t, exp.Shutdown(ctx), "repeated shutdown")
+	assert.ErrorIs(t, exp.Export(ctx, metricdata.ResourceMetrics{}), metric.ErrExporterShutdown)
+}
+
+func TestDefaultTemporalitySelector(t *testing.T) {
+	delta := []metric.InstrumentKind{
+		metric.InstrumentKindCounter,
+		metric.InstrumentKindObservableCounter,
+		metric.InstrumentKindHistogram,
+	}
+	for _, k := range delta {
+		assert.Equal(t, metricdata.DeltaTemporality, DefaultTemporalitySelector(k))
+	}
+
+	cumulative := []metric.InstrumentKind{
+		metric.InstrumentKindUpDownCounter,
+		metric.InstrumentKindObservableUpDownCounter,
+		metric.InstrumentKindObservableGauge,
+	}
+	for _, k := range cumulative {
+		assert.Equal(t, metricdata.CumulativeTemporality, DefaultTemporalitySelector(k))
+	}
+}
+
+func TestNewConfigMaxPacketSize(t *testing.T) {
+	assert.Equal(t, defaultUDPPacketSize, newConfig().maxPacketSize)
+	assert.Equal(t, defaultUnixPacketSize, newConfig(WithUnixEndpoint("/tmp/s")).maxPacketSize)
+	assert.Equal(t, 512, newConfig(WithMaxPacketSize(512)).maxPacketSize)
+	assert.Equal(t, defaultUDPPacketSize, newConfig(WithMaxPacketSize(maxPacketSize+1)).maxPacketSize)
+}
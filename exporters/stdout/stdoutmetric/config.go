@@ -16,6 +16,8 @@ package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdout
 import (
 	"encoding/json"
 	"os"
+	"regexp"
+	"strings"
 
 	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/sdk/metric"
@@ -27,6 +29,25 @@ type config struct {
 	encoder             *encoderHolder
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
+	include             []*regexp.Regexp
+	exclude             []*regexp.Regexp
+}
+
+// exported returns if the metric with name should be exported.
+func (c config) exported(name string) bool {
+	if len(c.include) > 0 && !matchAny(c.include, name) {
+		return false
+	}
+	return !matchAny(c.exclude, name)
+}
+
+func matchAny(res []*regexp.Regexp, name string) bool {
+	for _, re := range res {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
 }
 
 // newConfig creates a validated config configured with options.
@@ -125,3 +146,42 @@ func (t aggregationSelectorOption) apply(c config) config {
 	c.aggregationSelector = t.selector
 	return c
 }
+
+// WithInstrumentNames sets the exporter to only output the metrics with a
+// name matching one of the patterns. Patterns support the same wildcards as
+// the Name of a view Instrument criteria: "*" matches zero or more
+// characters and "?" matches exactly one character.
+//
+// If this option is not used, or is passed no patterns, all metrics are
+// output. Patterns passed with multiple uses of this option are combined.
+func WithInstrumentNames(patterns ...string) Option {
+	return optionFunc(func(c config) config {
+		c.include = append(c.include, compileWildcards(patterns)...)
+		return c
+	})
+}
+
+// WithoutInstrumentNames sets the exporter to not output the metrics with a
+// name matching one of the patterns. Patterns support the same wildcards as
+// WithInstrumentNames. Metrics excluded by this option are not output even if
+// they are matched by a pattern passed to WithInstrumentNames.
+func WithoutInstrumentNames(patterns ...string) Option {
+	return optionFunc(func(c config) config {
+		c.exclude = append(c.exclude, compileWildcards(patterns)...)
+		return c
+	})
+}
+
+// compileWildcards returns the regular expressions matching the wildcard
+// patterns.
+func compileWildcards(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		pattern := regexp.QuoteMeta(p)
+		pattern = "^" + pattern + "$"
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		res = append(res, regexp.MustCompile(pattern))
+	}
+	return res
+}
@@ -20,4 +20,10 @@
 // format for OpenTelemetry that is supported with any stability or
 // compatibility guarantees. If these are needed features, please use the OTLP
 // exporter instead.
+//
+// By default, metric data is written to STDOUT as indented JSON. The table
+// (NewTableEncoder), Prometheus text (NewPrometheusEncoder) and OTLP JSON
+// (NewOTLPJSONEncoder) encoders can be used instead with the WithEncoder
+// option, and the output can be restricted to specific metrics with the
+// WithInstrumentNames and WithoutInstrumentNames options.
 package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
@@ -14,7 +14,12 @@
 
 package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
 
 // Encoder encodes and outputs OpenTelemetry metric data-types as human
 // readable text.
@@ -38,3 +43,17 @@ type shutdownEncoder struct{}
 var errShutdown = errors.New("exporter shutdown")
 
 func (shutdownEncoder) Encode(any) error { return errShutdown }
+
+// resourceMetrics returns the metricdata.ResourceMetrics held by v, which
+// needs to be a metricdata.ResourceMetrics or a pointer to one.
+func resourceMetrics(v any) (metricdata.ResourceMetrics, error) {
+	switch rm := v.(type) {
+	case metricdata.ResourceMetrics:
+		return rm, nil
+	case *metricdata.ResourceMetrics:
+		if rm != nil {
+			return *rm, nil
+		}
+	}
+	return metricdata.ResourceMetrics{}, fmt.Errorf("unsupported value type %T", v)
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdoutmetric_test // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestEncodersUnsupportedValue(t *testing.T) {
+	for name, enc := range map[string]stdoutmetric.Encoder{
+		"Table":      stdoutmetric.NewTableEncoder(&bytes.Buffer{}),
+		"Prometheus": stdoutmetric.NewPrometheusEncoder(&bytes.Buffer{}),
+		"OTLPJSON":   stdoutmetric.NewOTLPJSONEncoder(&bytes.Buffer{}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, enc.Encode("invalid"))
+			assert.Error(t, enc.Encode((*metricdata.ResourceMetrics)(nil)))
+			assert.NoError(t, enc.Encode(&mockData))
+		})
+	}
+}
+
+func TestOTLPJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, stdoutmetric.NewOTLPJSONEncoder(&buf).Encode(mockData))
+
+	want := `{"resourceMetrics":[{
+		"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"stdoutmetric-example"}}]},
+		"scopeMetrics":[{
+			"scope":{"name":"example","version":"v0.0.1"},
+			"metrics":[
+				{
+					"name":"requests","description":"Number of requests received","unit":"1",
+					"sum":{
+						"dataPoints":[{
+							"attributes":[{"key":"server","value":{"stringValue":"central"}}],
+							"startTimeUnixNano":"946684800000000000","timeUnixNano":"946684801000000000",
+							"asInt":"5"
+						}],
+						"aggregationTemporality":1,"isMonotonic":true
+					}
+				},
+				{
+					"name":"latency","description":"Time spend processing received requests","unit":"ms",
+					"histogram":{
+						"dataPoints":[{
+							"attributes":[{"key":"server","value":{"stringValue":"central"}}],
+							"startTimeUnixNano":"946684800000000000","timeUnixNano":"946684801000000000",
+							"count":"10","sum":57,"bucketCounts":["1","3","6","0"],"explicitBounds":[1,5,10]
+						}],
+						"aggregationTemporality":1
+					}
+				},
+				{
+					"name":"temperature","description":"CPU global temperature","unit":"cel(1 K)",
+					"gauge":{
+						"dataPoints":[{
+							"attributes":[{"key":"server","value":{"stringValue":"central"}}],
+							"timeUnixNano":"946684801000000000",
+							"asDouble":32.4
+						}]
+					}
+				}
+			]
+		}]
+	}]}`
+	assert.JSONEq(t, want, buf.String())
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")), "one request per line")
+}
+
+func TestOTLPJSONEncoderValues(t *testing.T) {
+	var buf bytes.Buffer
+	data := metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "values",
+				Data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{
+						Attributes: attribute.NewSet(
+							attribute.Bool("bool", true),
+							attribute.Int64Slice("ints", []int64{1, 2}),
+						),
+						Value: math.Inf(1),
+					}},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, stdoutmetric.NewOTLPJSONEncoder(&buf).Encode(data))
+
+	want := `{"resourceMetrics":[{
+		"resource":{},
+		"scopeMetrics":[{
+			"scope":{},
+			"metrics":[{
+				"name":"values",
+				"gauge":{"dataPoints":[{
+					"attributes":[
+						{"key":"bool","value":{"boolValue":true}},
+						{"key":"ints","value":{"arrayValue":{"values":[{"intValue":"1"},{"intValue":"2"}]}}}
+					],
+					"asDouble":"Infinity"
+				}]}
+			}]
+		}]
+	}]}`
+	assert.JSONEq(t, want, buf.String())
+}
+
+func TestPrometheusEncoderEscaping(t *testing.T) {
+	var buf bytes.Buffer
+	data := metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{Name: "scope"},
+			Metrics: []metricdata.Metrics{{
+				Name:        "http.server.active_requests",
+				Description: "Line one\nline \\ two",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Attributes: attribute.NewSet(
+							attribute.String("http.method", `"GET"`),
+							attribute.String("http_method", "POST"),
+						),
+						Value: 3,
+					}},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, stdoutmetric.NewPrometheusEncoder(&buf).Encode(data))
+
+	want := `# HELP http_server_active_requests Line one\nline \\ two
+# TYPE http_server_active_requests gauge
+http_server_active_requests{http_method="\"GET\";POST",otel_scope_name="scope",otel_scope_version=""} 3
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrometheusEncoderLabels(t *testing.T) {
+	var buf bytes.Buffer
+	data := metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{{
+				Name: "1st:metric",
+				Data: metricdata.Gauge[int64]{
+					DataPoints: []metricdata.DataPoint[int64]{{
+						Attributes: attribute.NewSet(
+							attribute.String("0.key", "a"),
+							attribute.String("k8s:pod", "b"),
+						),
+						Value: 1,
+					}},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, stdoutmetric.NewPrometheusEncoder(&buf).Encode(data))
+
+	want := `# TYPE _1st:metric gauge
+_1st:metric{key_0_key="a",k8s_pod="b"} 1
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrometheusEncoderDropsDelta(t *testing.T) {
+	var errs []error
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {})) })
+
+	var buf bytes.Buffer
+	require.NoError(t, stdoutmetric.NewPrometheusEncoder(&buf).Encode(metricdata.ResourceMetrics{
+		ScopeMetrics: mockData.ScopeMetrics,
+	}))
+
+	want := `# HELP temperature CPU global temperature
+# TYPE temperature gauge
+temperature{server="central",otel_scope_name="example",otel_scope_version="v0.0.1"} 32.4
+`
+	assert.Equal(t, want, buf.String())
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], `"requests"`)
+	assert.ErrorContains(t, errs[1], `"latency"`)
+}
+
+func TestExporterInstrumentNameFilters(t *testing.T) {
+	testcases := []struct {
+		name string
+		opts []stdoutmetric.Option
+		want []string
+	}{
+		{
+			name: "NoFilter",
+			want: []string{"requests", "latency", "temperature"},
+		},
+		{
+			name: "Include",
+			opts: []stdoutmetric.Option{stdoutmetric.WithInstrumentNames("temp*", "re?uests")},
+			want: []string{"requests", "temperature"},
+		},
+		{
+			name: "Exclude",
+			opts: []stdoutmetric.Option{stdoutmetric.WithoutInstrumentNames("*e*s*")},
+			want: []string{"latency", "temperature"},
+		},
+		{
+			name: "IncludeAndExclude",
+			opts: []stdoutmetric.Option{
+				stdoutmetric.WithInstrumentNames("*"),
+				stdoutmetric.WithoutInstrumentNames("requests"),
+			},
+			want: []string{"latency", "temperature"},
+		},
+		{
+			name: "NoMatch",
+			opts: []stdoutmetric.Option{stdoutmetric.WithInstrumentNames("unknown")},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			enc := &recordingEncoder{}
+			exp, err := stdoutmetric.New(append(tc.opts, stdoutmetric.WithEncoder(enc))...)
+			require.NoError(t, err)
+			require.NoError(t, exp.Export(context.Background(), mockData))
+
+			var got []string
+			for _, sm := range enc.data.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					got = append(got, m.Name)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, mockData.Resource, enc.data.Resource)
+		})
+	}
+}
+
+type recordingEncoder struct {
+	data metricdata.ResourceMetrics
+}
+
+func (e *recordingEncoder) Encode(v any) error {
+	e.data = v.(metricdata.ResourceMetrics)
+	return nil
+}
@@ -124,3 +124,77 @@ func Example() {
 	// Ensure the periodic reader is cleaned up by shutting down the sdk.
 	_ = sdk.Shutdown(ctx)
 }
+
+func ExampleNewTableEncoder() {
+	exp, err := stdoutmetric.New(
+		stdoutmetric.WithEncoder(stdoutmetric.NewTableEncoder(os.Stdout)),
+		// Only output the request metrics.
+		stdoutmetric.WithInstrumentNames("requests", "latency"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	_ = exp.Export(context.Background(), mockData)
+	// Output:
+	// SCOPE    NAME      UNIT  TYPE              ATTRIBUTES        VALUE
+	// example  requests  1     counter(delta)    {server=central}  5
+	// example  latency   ms    histogram(delta)  {server=central}  count=10 sum=57 buckets=[1:1 5:3 10:6 +Inf:0]
+}
+
+// cumulative returns a copy of data with the temporality of all sums and
+// histograms set to cumulative.
+func cumulative(data metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	scopes := make([]metricdata.ScopeMetrics, len(data.ScopeMetrics))
+	for i, sm := range data.ScopeMetrics {
+		metrics := make([]metricdata.Metrics, len(sm.Metrics))
+		for j, m := range sm.Metrics {
+			switch a := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				a.Temporality = metricdata.CumulativeTemporality
+				m.Data = a
+			case metricdata.Histogram:
+				a.Temporality = metricdata.CumulativeTemporality
+				m.Data = a
+			}
+			metrics[j] = m
+		}
+		sm.Metrics = metrics
+		scopes[i] = sm
+	}
+	data.ScopeMetrics = scopes
+	return data
+}
+
+func ExampleNewPrometheusEncoder() {
+	exp, err := stdoutmetric.New(
+		stdoutmetric.WithEncoder(stdoutmetric.NewPrometheusEncoder(os.Stdout)),
+		// Prometheus only supports cumulative data.
+		stdoutmetric.WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+			return metricdata.CumulativeTemporality
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	// The mocked data is delta, the SDK would produce cumulative data
+	// because of the temporality selector.
+	_ = exp.Export(context.Background(), cumulative(mockData))
+	// Output:
+	// # HELP target_info Target metadata
+	// # TYPE target_info gauge
+	// target_info{service_name="stdoutmetric-example"} 1
+	// # HELP requests_ratio_total Number of requests received
+	// # TYPE requests_ratio_total counter
+	// requests_ratio_total{server="central",otel_scope_name="example",otel_scope_version="v0.0.1"} 5
+	// # HELP latency_milliseconds Time spend processing received requests
+	// # TYPE latency_milliseconds histogram
+	// latency_milliseconds_bucket{server="central",otel_scope_name="example",otel_scope_version="v0.0.1",le="1"} 1
+	// latency_milliseconds_bucket{server="central",otel_scope_name="example",otel_scope_version="v0.0.1",le="5"} 4
+	// latency_milliseconds_bucket{server="central",otel_scope_name="example",otel_scope_version="v0.0.1",le="10"} 10
+	// latency_milliseconds_bucket{server="central",otel_scope_name="example",otel_scope_version="v0.0.1",le="+Inf"} 10
+	// latency_milliseconds_sum{server="central",otel_scope_name="example",otel_scope_version="v0.0.1"} 57
+	// latency_milliseconds_count{server="central",otel_scope_name="example",otel_scope_version="v0.0.1"} 10
+	// # HELP temperature CPU global temperature
+	// # TYPE temperature gauge
+	// temperature{server="central",otel_scope_name="example",otel_scope_version="v0.0.1"} 32.4
+}
@@ -32,6 +32,8 @@ type exporter struct {
 
 	temporalitySelector metric.TemporalitySelector
 	aggregationSelector metric.AggregationSelector
+
+	exported func(name string) bool
 }
 
 // New returns a configured metric exporter.
@@ -44,6 +46,9 @@ func New(options ...Option) (metric.Exporter, error) {
 		temporalitySelector: cfg.temporalitySelector,
 		aggregationSelector: cfg.aggregationSelector,
 	}
+	if len(cfg.include) > 0 || len(cfg.exclude) > 0 {
+		exp.exported = cfg.exported
+	}
 	exp.encVal.Store(*cfg.encoder)
 	return exp, nil
 }
@@ -65,9 +70,33 @@ func (e *exporter) Export(ctx context.Context, data metricdata.ResourceMetrics)
 		// Context is still valid, continue.
 	}
 
+	if e.exported != nil {
+		data = e.filter(data)
+	}
 	return e.encVal.Load().(encoderHolder).Encode(data)
 }
 
+// filter returns a copy of data only containing the metrics to export.
+// Scopes without any such metric are dropped.
+func (e *exporter) filter(data metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	out := metricdata.ResourceMetrics{Resource: data.Resource}
+	for _, sm := range data.ScopeMetrics {
+		var metrics []metricdata.Metrics
+		for _, m := range sm.Metrics {
+			if e.exported(m.Name) {
+				metrics = append(metrics, m)
+			}
+		}
+		if len(metrics) > 0 {
+			out.ScopeMetrics = append(out.ScopeMetrics, metricdata.ScopeMetrics{
+				Scope:   sm.Scope,
+				Metrics: metrics,
+			})
+		}
+	}
+	return out
+}
+
 func (e *exporter) ForceFlush(ctx context.Context) error {
 	// exporter holds no state, nothing to flush.
 	return ctx.Err()
@@ -5,9 +5,13 @@ go 1.18
 require (
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/otel v1.11.2
+	go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform v0.34.0
+	go.opentelemetry.io/otel/exporters/internal/prometheus/naming v0.34.0
 	go.opentelemetry.io/otel/metric v0.34.0
 	go.opentelemetry.io/otel/sdk v1.11.2
 	go.opentelemetry.io/otel/sdk/metric v0.34.0
+	go.opentelemetry.io/proto/otlp v0.19.0
+	google.golang.org/protobuf v1.28.1
 )
 
 require (
@@ -29,3 +33,7 @@ replace go.opentelemetry.io/otel/sdk/metric => ../../../sdk/metric
 replace go.opentelemetry.io/otel/trace => ../../../trace
 
 replace go.opentelemetry.io/otel/sdk => ../../../sdk
+
+replace go.opentelemetry.io/otel/exporters/internal/prometheus/naming => ../../internal/prometheus/naming
+
+replace go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform => ../../internal/otlpmetric/transform
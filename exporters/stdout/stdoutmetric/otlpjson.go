@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.opentelemetry.io/otel/exporters/internal/otlpmetric/transform"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+// otlpJSONEncoder writes metric data in the OTLP JSON encoding.
+type otlpJSONEncoder struct {
+	w io.Writer
+}
+
+// otlpJSON is the protobuf JSON mapping required by the OTLP/JSON
+// specification: field names are lowerCamelCase and enums are integers.
+var otlpJSON = protojson.MarshalOptions{UseEnumNumbers: true}
+
+// NewOTLPJSONEncoder returns an Encoder that writes metric data to w in the
+// JSON encoding of the OpenTelemetry protocol (OTLP). Each call to Encode
+// writes a single line holding a MetricsData message, the format used by
+// OTLP JSON files. It is encoded the same as an ExportMetricsServiceRequest.
+//
+// The returned Encoder only encodes metricdata.ResourceMetrics.
+func NewOTLPJSONEncoder(w io.Writer) Encoder {
+	return &otlpJSONEncoder{w: w}
+}
+
+func (e *otlpJSONEncoder) Encode(v any) error {
+	rm, err := resourceMetrics(v)
+	if err != nil {
+		return err
+	}
+	// Best effort encoding of transformable metrics, the same as the OTLP
+	// exporters.
+	orm, err := transform.ResourceMetrics(rm)
+	b, mErr := otlpJSON.Marshal(&mpb.MetricsData{
+		ResourceMetrics: []*mpb.ResourceMetrics{orm},
+	})
+	if mErr != nil {
+		return mErr
+	}
+	if _, wErr := e.w.Write(append(b, '\n')); wErr != nil {
+		return wErr
+	}
+	return err
+}
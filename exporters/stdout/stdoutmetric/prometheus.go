@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/internal/prometheus/naming"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	targetInfoName        = "target_info"
+	targetInfoDescription = "Target metadata"
+	scopeNameLabel        = "otel_scope_name"
+	scopeVersionLabel     = "otel_scope_version"
+)
+
+// prometheusEncoder writes metric data in the Prometheus text exposition
+// format.
+type prometheusEncoder struct {
+	w io.Writer
+}
+
+// NewPrometheusEncoder returns an Encoder that writes metric data to w in
+// the Prometheus text exposition format. Metric and label names are
+// translated the same way the go.opentelemetry.io/otel/exporters/prometheus
+// exporter translates them.
+//
+// Prometheus only supports cumulative data. Sums and histograms with delta
+// temporality are dropped and reported to the global error handler, use
+// WithTemporalitySelector to export cumulative data instead.
+//
+// The returned Encoder only encodes metricdata.ResourceMetrics.
+func NewPrometheusEncoder(w io.Writer) Encoder {
+	return &prometheusEncoder{w: w}
+}
+
+// promFamily is a Prometheus metric family.
+type promFamily struct {
+	name, help, typ string
+	samples         []string
+}
+
+func (e *prometheusEncoder) Encode(v any) error {
+	rm, err := resourceMetrics(v)
+	if err != nil {
+		return err
+	}
+
+	var families []*promFamily
+	byName := make(map[string]*promFamily)
+	family := func(name, help, typ string) *promFamily {
+		if f, ok := byName[name]; ok && f.typ == typ {
+			return f
+		} else if ok {
+			// Conflicting types cannot be represented, the first one wins.
+			return nil
+		}
+		f := &promFamily{name: name, help: help, typ: typ}
+		byName[name] = f
+		families = append(families, f)
+		return f
+	}
+
+	if rm.Resource != nil && rm.Resource.Len() > 0 {
+		f := family(targetInfoName, targetInfoDescription, "gauge")
+		f.samples = append(f.samples, promSample(targetInfoName, promLabels(*rm.Resource.Set(), nil), "1"))
+	}
+	for _, sm := range rm.ScopeMetrics {
+		scope := scopeLabels(sm.Scope)
+		for _, m := range sm.Metrics {
+			addPromMetric(family, m, scope)
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, f := range families {
+		if f.help != "" {
+			fmt.Fprintf(&buf, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		}
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", f.name, f.typ)
+		for _, s := range f.samples {
+			buf.WriteString(s)
+		}
+	}
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+func addPromMetric(family func(name, help, typ string) *promFamily, m metricdata.Metrics, scope [][2]string) {
+	name := naming.SanitizeName(m.Name)
+	if suffix, ok := naming.UnitSuffix(string(m.Unit)); ok {
+		name += suffix
+	}
+
+	switch a := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		if f := family(name, m.Description, "gauge"); f != nil {
+			for _, dp := range a.DataPoints {
+				f.samples = append(f.samples, promSample(name, promLabels(dp.Attributes, scope), strconv.FormatInt(dp.Value, 10)))
+			}
+		}
+	case metricdata.Gauge[float64]:
+		if f := family(name, m.Description, "gauge"); f != nil {
+			for _, dp := range a.DataPoints {
+				f.samples = append(f.samples, promSample(name, promLabels(dp.Attributes, scope), formatFloat(dp.Value)))
+			}
+		}
+	case metricdata.Sum[int64]:
+		if a.Temporality != metricdata.CumulativeTemporality {
+			dropDelta(m)
+			return
+		}
+		name, typ := sumFamily(name, a.IsMonotonic)
+		if f := family(name, m.Description, typ); f != nil {
+			for _, dp := range a.DataPoints {
+				f.samples = append(f.samples, promSample(name, promLabels(dp.Attributes, scope), strconv.FormatInt(dp.Value, 10)))
+			}
+		}
+	case metricdata.Sum[float64]:
+		if a.Temporality != metricdata.CumulativeTemporality {
+			dropDelta(m)
+			return
+		}
+		name, typ := sumFamily(name, a.IsMonotonic)
+		if f := family(name, m.Description, typ); f != nil {
+			for _, dp := range a.DataPoints {
+				f.samples = append(f.samples, promSample(name, promLabels(dp.Attributes, scope), formatFloat(dp.Value)))
+			}
+		}
+	case metricdata.Histogram:
+		if a.Temporality != metricdata.CumulativeTemporality {
+			dropDelta(m)
+			return
+		}
+		f := family(name, m.Description, "histogram")
+		if f == nil {
+			return
+		}
+		for _, dp := range a.DataPoints {
+			labels := promLabels(dp.Attributes, scope)
+			var cumulative uint64
+			for i, count := range dp.BucketCounts {
+				cumulative += count
+				le := "+Inf"
+				if i < len(dp.Bounds) {
+					le = formatFloat(dp.Bounds[i])
+				}
+				bucket := append(labels[:len(labels):len(labels)], [2]string{"le", le})
+				f.samples = append(f.samples, promSample(name+"_bucket", bucket, strconv.FormatUint(cumulative, 10)))
+			}
+			f.samples = append(f.samples,
+				promSample(name+"_sum", labels, formatFloat(dp.Sum)),
+				promSample(name+"_count", labels, strconv.FormatUint(dp.Count, 10)),
+			)
+		}
+	}
+}
+
+// dropDelta reports that m is dropped because Prometheus does not support
+// data with delta temporality.
+func dropDelta(m metricdata.Metrics) {
+	otel.Handle(fmt.Errorf("stdoutmetric: dropping %q: Prometheus format only supports cumulative temporality", m.Name))
+}
+
+// sumFamily returns the name and type of the family of a sum.
+func sumFamily(name string, monotonic bool) (string, string) {
+	if monotonic {
+		return name + naming.CounterSuffix, "counter"
+	}
+	return name, "gauge"
+}
+
+func scopeLabels(scope instrumentation.Scope) [][2]string {
+	if scope.Name == "" {
+		return nil
+	}
+	return [][2]string{{scopeNameLabel, scope.Name}, {scopeVersionLabel, scope.Version}}
+}
+
+// promLabels returns the sanitized labels of attrs followed by extra. The
+// values of attributes with keys that are sanitized to the same label are
+// joined with ";".
+func promLabels(attrs attribute.Set, extra [][2]string) [][2]string {
+	labels := make([][2]string, 0, attrs.Len()+len(extra))
+	index := make(map[string]int, attrs.Len())
+	for iter := attrs.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		key := naming.SanitizeLabel(string(kv.Key))
+		if i, ok := index[key]; ok {
+			labels[i][1] += ";" + kv.Value.Emit()
+			continue
+		}
+		index[key] = len(labels)
+		labels = append(labels, [2]string{key, kv.Value.Emit()})
+	}
+	return append(labels, extra...)
+}
+
+func promSample(name string, labels [][2]string, value string) string {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l[0])
+			b.WriteString(`="`)
+			b.WriteString(escapeLabelValue(l[1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(value)
+	b.WriteByte('\n')
+	return b.String()
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+func escapeLabelValue(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdoutmetric // import "go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// tableEncoder writes metric data as a table with one line per data point.
+type tableEncoder struct {
+	w io.Writer
+}
+
+// NewTableEncoder returns an Encoder that writes metric data to w as a
+// compact table readable by humans. Each data point is written on its own
+// line with the name, unit, type, attributes and value of its metric.
+//
+// The returned Encoder only encodes metricdata.ResourceMetrics.
+func NewTableEncoder(w io.Writer) Encoder {
+	return &tableEncoder{w: w}
+}
+
+func (e *tableEncoder) Encode(v any) error {
+	rm, err := resourceMetrics(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCOPE\tNAME\tUNIT\tTYPE\tATTRIBUTES\tVALUE")
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, row := range tableRows(m.Data) {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", sm.Scope.Name, m.Name, m.Unit, row.typ, row.attrs, row.value)
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+// tableRow is a data point formatted for the table.
+type tableRow struct {
+	typ, attrs, value string
+}
+
+func tableRows(data metricdata.Aggregation) []tableRow {
+	var rows []tableRow
+	switch a := data.(type) {
+	case metricdata.Gauge[int64]:
+		for _, dp := range a.DataPoints {
+			rows = append(rows, tableRow{"gauge", formatAttrs(dp.Attributes), strconv.FormatInt(dp.Value, 10)})
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range a.DataPoints {
+			rows = append(rows, tableRow{"gauge", formatAttrs(dp.Attributes), formatFloat(dp.Value)})
+		}
+	case metricdata.Sum[int64]:
+		typ := sumType(a.Temporality, a.IsMonotonic)
+		for _, dp := range a.DataPoints {
+			rows = append(rows, tableRow{typ, formatAttrs(dp.Attributes), strconv.FormatInt(dp.Value, 10)})
+		}
+	case metricdata.Sum[float64]:
+		typ := sumType(a.Temporality, a.IsMonotonic)
+		for _, dp := range a.DataPoints {
+			rows = append(rows, tableRow{typ, formatAttrs(dp.Attributes), formatFloat(dp.Value)})
+		}
+	case metricdata.Histogram:
+		typ := fmt.Sprintf("histogram(%s)", temporality(a.Temporality))
+		for _, dp := range a.DataPoints {
+			rows = append(rows, tableRow{typ, formatAttrs(dp.Attributes), formatHistogram(dp)})
+		}
+	}
+	return rows
+}
+
+func sumType(t metricdata.Temporality, monotonic bool) string {
+	if monotonic {
+		return fmt.Sprintf("counter(%s)", temporality(t))
+	}
+	return fmt.Sprintf("updowncounter(%s)", temporality(t))
+}
+
+func temporality(t metricdata.Temporality) string {
+	switch t {
+	case metricdata.CumulativeTemporality:
+		return "cumulative"
+	case metricdata.DeltaTemporality:
+		return "delta"
+	}
+	return "undefined"
+}
+
+// formatAttrs returns attrs as comma separated key=value pairs in braces.
+func formatAttrs(attrs attribute.Set) string {
+	return "{" + attrs.Encoded(attribute.DefaultEncoder()) + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatHistogram returns the count, sum, min, max and buckets of dp. Each
+// bucket is written as its upper bound followed by its count.
+func formatHistogram(dp metricdata.HistogramDataPoint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "count=%d sum=%s", dp.Count, formatFloat(dp.Sum))
+	if dp.Min != nil {
+		fmt.Fprintf(&b, " min=%s", formatFloat(*dp.Min))
+	}
+	if dp.Max != nil {
+		fmt.Fprintf(&b, " max=%s", formatFloat(*dp.Max))
+	}
+	b.WriteString(" buckets=[")
+	for i, count := range dp.BucketCounts {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		bound := "+Inf"
+		if i < len(dp.Bounds) {
+			bound = formatFloat(dp.Bounds[i])
+		}
+		fmt.Fprintf(&b, "%s:%d", bound, count)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -17,12 +17,18 @@ package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdoutt
 import (
 	"io"
 	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
 	defaultWriter      = os.Stdout
 	defaultPrettyPrint = false
 	defaultTimestamps  = true
+
+	defaultTreeMaxSpans = 2048
+	defaultTreeMaxAge   = 30 * time.Second
 )
 
 // config contains options for the STDOUT exporter.
@@ -37,6 +43,29 @@ type config struct {
 	// Timestamps specifies if timestamps should be printed. Default is
 	// true.
 	Timestamps bool
+
+	// Tree renders spans as a human-readable tree of each trace instead of
+	// JSON. Default is false.
+	Tree bool
+
+	// Color adds ANSI colors to the tree output. Default is false.
+	Color bool
+
+	// Waterfall is the width of the timing bar in the tree output. No bar
+	// is rendered if it is zero, the default.
+	Waterfall int
+
+	// TreeAttributes are the keys of the attributes included in the tree
+	// output. All attributes are included if it is empty, the default.
+	TreeAttributes []attribute.Key
+
+	// TreeMaxSpans is the maximum number of spans buffered for the tree
+	// output. Default is 2048.
+	TreeMaxSpans int
+
+	// TreeMaxAge is the maximum time a trace is buffered for the tree
+	// output. Default is 30 seconds.
+	TreeMaxAge time.Duration
 }
 
 // newConfig creates a validated Config configured with options.
@@ -45,6 +74,9 @@ func newConfig(options ...Option) (config, error) {
 		Writer:      defaultWriter,
 		PrettyPrint: defaultPrettyPrint,
 		Timestamps:  defaultTimestamps,
+
+		TreeMaxSpans: defaultTreeMaxSpans,
+		TreeMaxAge:   defaultTreeMaxAge,
 	}
 	for _, opt := range options {
 		cfg = opt.apply(cfg)
@@ -94,3 +126,102 @@ func (o timestampsOption) apply(cfg config) config {
 	cfg.Timestamps = bool(o)
 	return cfg
 }
+
+// WithTree sets the export stream format to a human-readable tree of each
+// trace. Spans are buffered until the local root span of their trace ends,
+// the whole trace is then rendered with the duration, status, attributes and
+// events of each span. Traces are rendered incomplete if they are buffered
+// longer than allowed by WithTreeMaxAge, if the buffer exceeds the limit set
+// by WithTreeMaxSpans, or if they are still buffered when the exporter is
+// shut down.
+//
+// This format is intended for local debugging, it is not meant to be parsed.
+func WithTree() Option {
+	return treeOption(true)
+}
+
+type treeOption bool
+
+func (o treeOption) apply(cfg config) config {
+	cfg.Tree = bool(o)
+	return cfg
+}
+
+// WithColor adds ANSI colors to the tree output. It has no effect unless
+// WithTree is also passed.
+func WithColor() Option {
+	return colorOption(true)
+}
+
+type colorOption bool
+
+func (o colorOption) apply(cfg config) config {
+	cfg.Color = bool(o)
+	return cfg
+}
+
+// WithWaterfall adds a timing bar of width characters to each span of the
+// tree output, showing when the span ran relative to the whole trace. It has
+// no effect unless WithTree is also passed.
+func WithWaterfall(width int) Option {
+	return waterfallOption(width)
+}
+
+type waterfallOption int
+
+func (o waterfallOption) apply(cfg config) config {
+	if o > 0 {
+		cfg.Waterfall = int(o)
+	}
+	return cfg
+}
+
+// WithTreeAttributes restricts the span and event attributes included in the
+// tree output to the ones with the passed keys. It has no effect unless
+// WithTree is also passed.
+func WithTreeAttributes(keys ...attribute.Key) Option {
+	return treeAttributesOption(keys)
+}
+
+type treeAttributesOption []attribute.Key
+
+func (o treeAttributesOption) apply(cfg config) config {
+	cfg.TreeAttributes = append(cfg.TreeAttributes, o...)
+	return cfg
+}
+
+// WithTreeMaxSpans sets the maximum number of spans buffered for the tree
+// output. When it is exceeded, the traces buffered the longest are rendered
+// incomplete until the buffer is below the limit again. Non-positive values
+// are ignored and the default of 2048 is used. It has no effect unless
+// WithTree is also passed.
+func WithTreeMaxSpans(n int) Option {
+	return treeMaxSpansOption(n)
+}
+
+type treeMaxSpansOption int
+
+func (o treeMaxSpansOption) apply(cfg config) config {
+	if o > 0 {
+		cfg.TreeMaxSpans = int(o)
+	}
+	return cfg
+}
+
+// WithTreeMaxAge sets the maximum time a trace is buffered for the tree
+// output, measured from when its first span was exported. Traces still
+// buffered after d are rendered incomplete. Non-positive values are ignored
+// and the default of 30 seconds is used. It has no effect unless WithTree is
+// also passed.
+func WithTreeMaxAge(d time.Duration) Option {
+	return treeMaxAgeOption(d)
+}
+
+type treeMaxAgeOption time.Duration
+
+func (o treeMaxAgeOption) apply(cfg config) config {
+	if o > 0 {
+		cfg.TreeMaxAge = time.Duration(o)
+	}
+	return cfg
+}
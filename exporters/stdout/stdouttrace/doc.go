@@ -13,5 +13,6 @@
 // limitations under the License.
 
 // Package stdouttrace contains an OpenTelemetry exporter for tracing
-// telemetry to be written to an output destination as JSON.
+// telemetry to be written to an output destination as JSON, or as a
+// human-readable tree of each trace when configured with WithTree.
 package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
@@ -40,10 +40,14 @@ func New(options ...Option) (*Exporter, error) {
 		enc.SetIndent("", "\t")
 	}
 
-	return &Exporter{
+	exp := &Exporter{
 		encoder:    enc,
 		timestamps: cfg.Timestamps,
-	}, nil
+	}
+	if cfg.Tree {
+		exp.tree = newTreeWriter(cfg)
+	}
+	return exp, nil
 }
 
 // Exporter is an implementation of trace.SpanSyncer that writes spans to stdout.
@@ -51,12 +55,14 @@ type Exporter struct {
 	encoder    *json.Encoder
 	encoderMu  sync.Mutex
 	timestamps bool
+	tree       *treeWriter
 
 	stoppedMu sync.RWMutex
 	stopped   bool
 }
 
-// ExportSpans writes spans in json format to stdout.
+// ExportSpans writes spans in json format, or as trace trees if configured
+// with WithTree, to stdout.
 func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
 	e.stoppedMu.RLock()
 	stopped := e.stopped
@@ -69,6 +75,10 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 		return nil
 	}
 
+	if e.tree != nil {
+		return e.tree.write(spans)
+	}
+
 	stubs := tracetest.SpanStubsFromReadOnlySpans(spans)
 
 	e.encoderMu.Lock()
@@ -93,12 +103,19 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan)
 	return nil
 }
 
-// Shutdown is called to stop the exporter, it preforms no action.
+// Shutdown is called to stop the exporter. It writes any traces still
+// buffered when the tree output is used, otherwise it preforms no action.
 func (e *Exporter) Shutdown(ctx context.Context) error {
 	e.stoppedMu.Lock()
 	e.stopped = true
 	e.stoppedMu.Unlock()
 
+	if e.tree != nil {
+		if err := e.tree.flush(); err != nil {
+			return err
+		}
+	}
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdouttrace // import "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ANSI escape sequences used to color the tree output.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// treeWriter buffers spans per trace and writes each trace as a tree once
+// its local root span has ended.
+//
+// The buffer is bounded. When it holds more than maxSpans spans, the traces
+// seen first are written incomplete until it is below the limit again. A
+// trace that was first seen more than maxAge ago is written incomplete as
+// well.
+type treeWriter struct {
+	w          io.Writer
+	color      bool
+	waterfall  int
+	timestamps bool
+	attrKeys   map[attribute.Key]struct{}
+	maxSpans   int
+	maxAge     time.Duration
+
+	mu      sync.Mutex
+	pending map[trace.TraceID]*pendingTrace
+	// order holds the pending traces in the order they were first seen.
+	order []trace.TraceID
+	// spans is the number of spans buffered across all pending traces.
+	spans   int
+	timer   *time.Timer
+	stopped bool
+}
+
+// pendingTrace holds the buffered spans of a trace.
+type pendingTrace struct {
+	spans []sdktrace.ReadOnlySpan
+	// seen is when the first span of the trace was buffered.
+	seen time.Time
+}
+
+func newTreeWriter(cfg config) *treeWriter {
+	t := &treeWriter{
+		w:          cfg.Writer,
+		color:      cfg.Color,
+		waterfall:  cfg.Waterfall,
+		timestamps: cfg.Timestamps,
+		maxSpans:   cfg.TreeMaxSpans,
+		maxAge:     cfg.TreeMaxAge,
+		pending:    make(map[trace.TraceID]*pendingTrace),
+	}
+	if len(cfg.TreeAttributes) > 0 {
+		t.attrKeys = make(map[attribute.Key]struct{}, len(cfg.TreeAttributes))
+		for _, k := range cfg.TreeAttributes {
+			t.attrKeys[k] = struct{}{}
+		}
+	}
+	return t
+}
+
+// write buffers spans and writes the traces completed by them. Traces that
+// no longer fit in the buffer are written incomplete.
+func (t *treeWriter) write(spans []sdktrace.ReadOnlySpan) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var completed []trace.TraceID
+	now := time.Now()
+	for _, s := range spans {
+		if s == nil {
+			continue
+		}
+		id := s.SpanContext().TraceID()
+		pt, ok := t.pending[id]
+		if !ok {
+			pt = &pendingTrace{seen: now}
+			t.pending[id] = pt
+			t.order = append(t.order, id)
+		}
+		pt.spans = append(pt.spans, s)
+		t.spans++
+
+		// The trace is complete once its local root span ended. Children
+		// always end before their parent when instrumented correctly.
+		if p := s.Parent(); !p.IsValid() || p.IsRemote() {
+			completed = append(completed, id)
+		}
+	}
+
+	for _, id := range completed {
+		if err := t.render(id, true); err != nil {
+			return err
+		}
+	}
+	for t.spans > t.maxSpans && len(t.order) > 0 {
+		if err := t.render(t.order[0], false); err != nil {
+			return err
+		}
+	}
+	t.schedule()
+	return nil
+}
+
+// schedule arms the timer to write the oldest pending trace once it is older
+// than maxAge. The caller needs to hold t.mu.
+func (t *treeWriter) schedule() {
+	if t.stopped || len(t.order) == 0 {
+		if t.timer != nil {
+			t.timer.Stop()
+		}
+		return
+	}
+
+	d := time.Until(t.pending[t.order[0]].seen.Add(t.maxAge))
+	if t.timer == nil {
+		t.timer = time.AfterFunc(d, t.expire)
+		return
+	}
+	t.timer.Reset(d)
+}
+
+// expire writes the pending traces older than maxAge.
+func (t *treeWriter) expire() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
+	now := time.Now()
+	for len(t.order) > 0 && now.Sub(t.pending[t.order[0]].seen) >= t.maxAge {
+		if err := t.render(t.order[0], false); err != nil {
+			otel.Handle(err)
+		}
+	}
+	t.schedule()
+}
+
+// flush writes all buffered traces, complete or not, and stops writing
+// traces based on their age.
+func (t *treeWriter) flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	for len(t.order) > 0 {
+		if err := t.render(t.order[0], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render writes the buffered spans of the trace id and removes them from
+// the buffer. The trace is marked as incomplete unless complete is true.
+// The caller needs to hold t.mu.
+func (t *treeWriter) render(id trace.TraceID, complete bool) error {
+	pt, ok := t.pending[id]
+	if !ok {
+		return nil
+	}
+	spans := pt.spans
+	delete(t.pending, id)
+	t.spans -= len(spans)
+	for i := range t.order {
+		if t.order[i] == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+
+	known := make(map[trace.SpanID]struct{}, len(spans))
+	for _, s := range spans {
+		known[s.SpanContext().SpanID()] = struct{}{}
+	}
+
+	r := traceRenderer{
+		treeWriter: t,
+		children:   make(map[trace.SpanID][]sdktrace.ReadOnlySpan),
+		start:      spans[0].StartTime(),
+	}
+	end := spans[0].EndTime()
+	var roots []sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		// Spans with a parent that was not exported are rendered as roots.
+		p := s.Parent()
+		if _, ok := known[p.SpanID()]; ok && p.IsValid() {
+			r.children[p.SpanID()] = append(r.children[p.SpanID()], s)
+		} else {
+			roots = append(roots, s)
+		}
+		if s.StartTime().Before(r.start) {
+			r.start = s.StartTime()
+		}
+		if s.EndTime().After(end) {
+			end = s.EndTime()
+		}
+	}
+	r.total = end.Sub(r.start)
+
+	r.header(id, len(spans), complete)
+	sortByStartTime(roots)
+	for _, root := range roots {
+		r.span(root, "", "", "")
+	}
+	r.buf.WriteByte('\n')
+
+	_, err := t.w.Write(r.buf.Bytes())
+	return err
+}
+
+// traceRenderer renders a single trace.
+type traceRenderer struct {
+	*treeWriter
+
+	buf      bytes.Buffer
+	children map[trace.SpanID][]sdktrace.ReadOnlySpan
+	start    time.Time
+	total    time.Duration
+}
+
+func (r *traceRenderer) header(id trace.TraceID, n int, complete bool) {
+	noun := "spans"
+	if n == 1 {
+		noun = "span"
+	}
+	r.style(ansiBold, fmt.Sprintf("Trace %s", id))
+	fmt.Fprintf(&r.buf, " (%d %s, %s", n, noun, formatDuration(r.total))
+	if !complete {
+		r.buf.WriteString(", incomplete")
+	}
+	r.buf.WriteByte(')')
+	if r.timestamps {
+		fmt.Fprintf(&r.buf, " started at %s", r.start.Format(time.RFC3339Nano))
+	}
+	r.buf.WriteByte('\n')
+}
+
+// span renders s and its children. The prefix is written before the
+// connector of the line of s, childPrefix before the lines of its children.
+func (r *traceRenderer) span(s sdktrace.ReadOnlySpan, prefix, connector, childPrefix string) {
+	children := r.children[s.SpanContext().SpanID()]
+	sortByStartTime(children)
+
+	r.bar(s)
+	r.buf.WriteString(prefix)
+	r.buf.WriteString(connector)
+	r.style(ansiBold, s.Name())
+	if s.SpanKind() != trace.SpanKindInternal {
+		r.buf.WriteByte(' ')
+		r.style(ansiDim, fmt.Sprintf("[%s]", s.SpanKind()))
+	}
+	r.buf.WriteByte(' ')
+	r.buf.WriteString(formatDuration(s.EndTime().Sub(s.StartTime())))
+	r.status(s.Status())
+	r.buf.WriteByte('\n')
+
+	detailPrefix := childPrefix + "   "
+	if len(children) > 0 {
+		detailPrefix = childPrefix + "│  "
+	}
+	if attrs := r.attributes(s.Attributes()); attrs != "" {
+		r.detail(detailPrefix, attrs)
+	}
+	for _, ev := range s.Events() {
+		line := fmt.Sprintf("• %s +%s", ev.Name, formatDuration(ev.Time.Sub(s.StartTime())))
+		if attrs := r.attributes(ev.Attributes); attrs != "" {
+			line += " " + attrs
+		}
+		r.detail(detailPrefix, line)
+	}
+
+	for i, child := range children {
+		if i == len(children)-1 {
+			r.span(child, childPrefix, "└─ ", childPrefix+"   ")
+		} else {
+			r.span(child, childPrefix, "├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+// detail renders a line with additional information about a span.
+func (r *traceRenderer) detail(prefix, line string) {
+	if r.waterfall > 0 {
+		r.buf.WriteString(strings.Repeat(" ", r.waterfall+3))
+	}
+	r.buf.WriteString(prefix)
+	r.style(ansiDim, line)
+	r.buf.WriteByte('\n')
+}
+
+// bar renders the waterfall timing bar of s, if enabled.
+func (r *traceRenderer) bar(s sdktrace.ReadOnlySpan) {
+	if r.waterfall <= 0 {
+		return
+	}
+
+	offset, length := 0, r.waterfall
+	if r.total > 0 {
+		width := float64(r.waterfall)
+		offset = int(float64(s.StartTime().Sub(r.start)) / float64(r.total) * width)
+		length = int(float64(s.EndTime().Sub(s.StartTime()))/float64(r.total)*width + 0.5)
+	}
+	if offset >= r.waterfall {
+		offset = r.waterfall - 1
+	}
+	if length < 1 {
+		length = 1
+	}
+	if offset+length > r.waterfall {
+		length = r.waterfall - offset
+	}
+
+	r.buf.WriteByte('[')
+	r.buf.WriteString(strings.Repeat(" ", offset))
+	r.style(ansiCyan, strings.Repeat("█", length))
+	r.buf.WriteString(strings.Repeat(" ", r.waterfall-offset-length))
+	r.buf.WriteString("] ")
+}
+
+func (r *traceRenderer) status(status sdktrace.Status) {
+	switch status.Code {
+	case codes.Ok:
+		r.buf.WriteByte(' ')
+		r.style(ansiGreen, "✓")
+	case codes.Error:
+		msg := "✗ ERROR"
+		if status.Description != "" {
+			msg += ": " + status.Description
+		}
+		r.buf.WriteByte(' ')
+		r.style(ansiRed, msg)
+	}
+}
+
+// attributes returns the attributes to render formatted as key=value pairs.
+func (r *traceRenderer) attributes(attrs []attribute.KeyValue) string {
+	var b strings.Builder
+	for _, kv := range attrs {
+		if r.attrKeys != nil {
+			if _, ok := r.attrKeys[kv.Key]; !ok {
+				continue
+			}
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(kv.Key))
+		b.WriteByte('=')
+		b.WriteString(kv.Value.Emit())
+	}
+	return b.String()
+}
+
+// style writes s, colored with the ANSI escape sequence code if colors are
+// enabled.
+func (r *traceRenderer) style(code, s string) {
+	if !r.color {
+		r.buf.WriteString(s)
+		return
+	}
+	r.buf.WriteString(code)
+	r.buf.WriteString(s)
+	r.buf.WriteString(ansiReset)
+}
+
+func sortByStartTime(spans []sdktrace.ReadOnlySpan) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].StartTime().Before(spans[j].StartTime())
+	})
+}
+
+// formatDuration returns d rounded to a precision readable by humans.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		d = d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		d = d.Round(time.Microsecond)
+	}
+	return d.String()
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdouttrace_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	treeTraceID, _ = trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	treeStart      = time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+)
+
+func treeSpanContext(id byte) trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: treeTraceID,
+		SpanID:  trace.SpanID{id},
+	})
+}
+
+func treeSpan(name string, id, parent byte, start, end time.Duration) tracetest.SpanStub {
+	s := tracetest.SpanStub{
+		Name:        name,
+		SpanContext: treeSpanContext(id),
+		SpanKind:    trace.SpanKindInternal,
+		StartTime:   treeStart.Add(start),
+		EndTime:     treeStart.Add(end),
+	}
+	if parent != 0 {
+		s.Parent = treeSpanContext(parent)
+	}
+	return s
+}
+
+// treeSpans returns the spans of a trace in the order they end.
+func treeSpans() tracetest.SpanStubs {
+	root := treeSpan("GET /users", 1, 0, 0, 10*time.Millisecond)
+	root.SpanKind = trace.SpanKindServer
+	root.Status = tracesdk.Status{Code: codes.Ok}
+	root.Attributes = []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.Int("http.status_code", 200),
+	}
+
+	query := treeSpan("query", 2, 1, time.Millisecond, 5*time.Millisecond)
+	query.SpanKind = trace.SpanKindClient
+	query.Status = tracesdk.Status{Code: codes.Error, Description: "timeout"}
+
+	connect := treeSpan("connect", 3, 2, time.Millisecond, 2*time.Millisecond)
+
+	render := treeSpan("render", 4, 1, 6*time.Millisecond, 9*time.Millisecond)
+	render.Events = []tracesdk.Event{{
+		Name:       "cache miss",
+		Time:       treeStart.Add(7 * time.Millisecond),
+		Attributes: []attribute.KeyValue{attribute.String("key", "users")},
+	}}
+
+	return tracetest.SpanStubs{connect, query, render, root}
+}
+
+func TestExporterTree(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(stdouttrace.WithWriter(&buf), stdouttrace.WithTree(), stdouttrace.WithoutTimestamps())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	spans := treeSpans().Snapshots()
+	// Nothing is written until the root span ends.
+	require.NoError(t, exp.ExportSpans(ctx, spans[:3]))
+	assert.Empty(t, buf.String())
+	require.NoError(t, exp.ExportSpans(ctx, spans[3:]))
+
+	want := `Trace 0102030405060708090a0b0c0d0e0f10 (4 spans, 10ms)
+GET /users [server] 10ms ✓
+│  http.method=GET http.status_code=200
+├─ query [client] 4ms ✗ ERROR: timeout
+│  └─ connect 1ms
+└─ render 3ms
+      • cache miss +1ms key=users
+
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestExporterTreeWaterfall(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTree(),
+		stdouttrace.WithoutTimestamps(),
+		stdouttrace.WithWaterfall(10),
+		stdouttrace.WithTreeAttributes("key"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(context.Background(), treeSpans().Snapshots()))
+
+	want := `Trace 0102030405060708090a0b0c0d0e0f10 (4 spans, 10ms)
+[██████████] GET /users [server] 10ms ✓
+[ ████     ] ├─ query [client] 4ms ✗ ERROR: timeout
+[ █        ] │  └─ connect 1ms
+[      ███ ] └─ render 3ms
+                   • cache miss +1ms key=users
+
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestExporterTreeColor(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTree(),
+		stdouttrace.WithoutTimestamps(),
+		stdouttrace.WithColor(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(context.Background(), treeSpans().Snapshots()))
+
+	out := buf.String()
+	assert.Contains(t, out, "\x1b[1mGET /users\x1b[0m")
+	assert.Contains(t, out, "\x1b[32m✓\x1b[0m")
+	assert.Contains(t, out, "\x1b[31m✗ ERROR: timeout\x1b[0m")
+}
+
+func TestExporterTreeShutdownFlushes(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(stdouttrace.WithWriter(&buf), stdouttrace.WithTree())
+	require.NoError(t, err)
+
+	// The root span never ends, its children are rendered as roots.
+	ctx := context.Background()
+	require.NoError(t, exp.ExportSpans(ctx, treeSpans()[:3].Snapshots()))
+	assert.Empty(t, buf.String())
+	require.NoError(t, exp.Shutdown(ctx))
+
+	want := `Trace 0102030405060708090a0b0c0d0e0f10 (3 spans, 8ms, incomplete) started at 2022-10-18T12:00:00.001Z
+query [client] 4ms ✗ ERROR: timeout
+└─ connect 1ms
+render 3ms
+   • cache miss +1ms key=users
+
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestExporterTreeMaxSpans(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTree(),
+		stdouttrace.WithoutTimestamps(),
+		stdouttrace.WithTreeMaxSpans(2),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	spans := treeSpans().Snapshots()
+	require.NoError(t, exp.ExportSpans(ctx, spans[:2]))
+	assert.Empty(t, buf.String())
+	// The third span exceeds the limit, the trace is written incomplete.
+	require.NoError(t, exp.ExportSpans(ctx, spans[2:3]))
+
+	want := `Trace 0102030405060708090a0b0c0d0e0f10 (3 spans, 8ms, incomplete)
+query [client] 4ms ✗ ERROR: timeout
+└─ connect 1ms
+render 3ms
+   • cache miss +1ms key=users
+
+`
+	assert.Equal(t, want, buf.String())
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestExporterTreeMaxAge(t *testing.T) {
+	var buf syncBuffer
+	exp, err := stdouttrace.New(
+		stdouttrace.WithWriter(&buf),
+		stdouttrace.WithTree(),
+		stdouttrace.WithoutTimestamps(),
+		stdouttrace.WithTreeMaxAge(time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	// The root span never ends, the trace is written once it is too old.
+	require.NoError(t, exp.ExportSpans(context.Background(), treeSpans()[:1].Snapshots()))
+	want := `Trace 0102030405060708090a0b0c0d0e0f10 (1 span, 1ms, incomplete)
+connect 1ms
+
+`
+	assert.Eventually(t, func() bool {
+		return buf.String() == want
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Equal(t, want, buf.String())
+}
@@ -72,21 +72,37 @@ func getServiceName(attrs []attribute.KeyValue) string {
 
 func toZipkinSpanModel(data tracesdk.ReadOnlySpan) zkmodel.SpanModel {
 	return zkmodel.SpanModel{
-		SpanContext: toZipkinSpanContext(data),
-		Name:        data.Name(),
-		Kind:        toZipkinKind(data.SpanKind()),
-		Timestamp:   data.StartTime(),
-		Duration:    data.EndTime().Sub(data.StartTime()),
-		Shared:      false,
-		LocalEndpoint: &zkmodel.Endpoint{
-			ServiceName: getServiceName(data.Resource().Attributes()),
-		},
+		SpanContext:    toZipkinSpanContext(data),
+		Name:           data.Name(),
+		Kind:           toZipkinKind(data.SpanKind()),
+		Timestamp:      data.StartTime(),
+		Duration:       data.EndTime().Sub(data.StartTime()),
+		Shared:         false,
+		LocalEndpoint:  toZipkinLocalEndpoint(data),
 		RemoteEndpoint: toZipkinRemoteEndpoint(data),
 		Annotations:    toZipkinAnnotations(data.Events()),
 		Tags:           toZipkinTags(data),
 	}
 }
 
+// toZipkinLocalEndpoint returns the endpoint of the service that recorded
+// the span. The IP address and port are set from the net.host.ip and
+// net.host.port attributes of the span, if present.
+func toZipkinLocalEndpoint(data tracesdk.ReadOnlySpan) *zkmodel.Endpoint {
+	endpoint := &zkmodel.Endpoint{
+		ServiceName: getServiceName(data.Resource().Attributes()),
+	}
+	for _, kv := range data.Attributes() {
+		switch kv.Key {
+		case semconv.NetHostIPKey:
+			setEndpointIP(endpoint, kv.Value.AsString())
+		case semconv.NetHostPortKey:
+			endpoint.Port = endpointPort(kv)
+		}
+	}
+	return endpoint
+}
+
 func toZipkinSpanContext(data tracesdk.ReadOnlySpan) zkmodel.SpanContext {
 	return zkmodel.SpanContext{
 		TraceID:  toZipkinTraceID(data.SpanContext().TraceID()),
@@ -273,39 +289,48 @@ func toZipkinRemoteEndpoint(data tracesdk.ReadOnlySpan) *zkmodel.Endpoint {
 		return nil
 	}
 
+	// The service name is the highest ranked attribute naming the peer. The
+	// address of the peer is added if known.
+	endpoint := &zkmodel.Endpoint{}
 	if endpointAttr.Key != semconv.NetPeerIPKey &&
 		endpointAttr.Value.Type() == attribute.STRING {
-		return &zkmodel.Endpoint{
-			ServiceName: endpointAttr.Value.AsString(),
+		endpoint.ServiceName = endpointAttr.Value.AsString()
+	}
+	for _, kv := range attr {
+		switch kv.Key {
+		case semconv.NetPeerIPKey:
+			setEndpointIP(endpoint, kv.Value.AsString())
+		case semconv.NetPeerPortKey:
+			if endpoint.IPv4 != nil || endpoint.IPv6 != nil || endpoint.ServiceName != "" {
+				endpoint.Port = endpointPort(kv)
+			}
 		}
 	}
-
-	return remoteEndpointPeerIPWithPort(endpointAttr.Value.AsString(), attr)
-}
-
-// Handles `net.peer.ip` remote endpoint separately (should include `net.peer.ip`
-// as well, if available).
-func remoteEndpointPeerIPWithPort(peerIP string, attrs []attribute.KeyValue) *zkmodel.Endpoint {
-	ip := net.ParseIP(peerIP)
-	if ip == nil {
+	if endpoint.ServiceName == "" && endpoint.IPv4 == nil && endpoint.IPv6 == nil {
+		// Only an invalid IP address was found.
 		return nil
 	}
+	return endpoint
+}
 
-	endpoint := &zkmodel.Endpoint{}
+// setEndpointIP sets the IPv4 or IPv6 address of endpoint to ip. Nothing is
+// set if ip is not a valid IP address.
+func setEndpointIP(endpoint *zkmodel.Endpoint, ip string) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return
+	}
 	// Determine if IPv4 or IPv6
-	if ip.To4() != nil {
-		endpoint.IPv4 = ip
+	if parsed.To4() != nil {
+		endpoint.IPv4 = parsed
 	} else {
-		endpoint.IPv6 = ip
-	}
-
-	for _, kv := range attrs {
-		if kv.Key == semconv.NetPeerPortKey {
-			port, _ := strconv.ParseUint(kv.Value.Emit(), 10, 16)
-			endpoint.Port = uint16(port)
-			return endpoint
-		}
+		endpoint.IPv6 = parsed
 	}
+}
 
-	return endpoint
+// endpointPort returns the port held by kv, or zero if it is not a valid
+// port number.
+func endpointPort(kv attribute.KeyValue) uint16 {
+	port, _ := strconv.ParseUint(kv.Value.Emit(), 10, 16)
+	return uint16(port)
 }
@@ -1195,6 +1195,33 @@ func TestRemoteEndpointTransformation(t *testing.T) {
 				Port: 9876,
 			},
 		},
+		{
+			name: "peer-service-with-address",
+			data: tracetest.SpanStub{
+				SpanKind: trace.SpanKindClient,
+				Attributes: []attribute.KeyValue{
+					semconv.NetPeerPortKey.Int(9876),
+					semconv.NetPeerIPKey.String("2001:db8::1"),
+					semconv.PeerServiceKey.String("peer-service-test"),
+				},
+			},
+			want: &zkmodel.Endpoint{
+				ServiceName: "peer-service-test",
+				IPv6:        net.ParseIP("2001:db8::1"),
+				Port:        9876,
+			},
+		},
+		{
+			name: "net-peer-ip-invalid",
+			data: tracetest.SpanStub{
+				SpanKind: trace.SpanKindClient,
+				Attributes: []attribute.KeyValue{
+					semconv.NetPeerIPKey.String("invalid"),
+					semconv.NetPeerPortKey.Int(9876),
+				},
+			},
+			want: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1216,3 +1243,54 @@ func TestServiceName(t *testing.T) {
 	attrs = append(attrs, semconv.ServiceNameKey.String("my_service"))
 	assert.Equal(t, "my_service", getServiceName(attrs))
 }
+
+func TestLocalEndpointTransformation(t *testing.T) {
+	res := resource.NewSchemaless(semconv.ServiceNameKey.String("local-service"))
+	tests := []struct {
+		name string
+		data tracetest.SpanStub
+		want *zkmodel.Endpoint
+	}{
+		{
+			name: "service-name-only",
+			data: tracetest.SpanStub{Resource: res},
+			want: &zkmodel.Endpoint{ServiceName: "local-service"},
+		},
+		{
+			name: "net-host-ipv4-port",
+			data: tracetest.SpanStub{
+				Resource: res,
+				Attributes: []attribute.KeyValue{
+					semconv.NetHostIPKey.String("1.2.3.4"),
+					semconv.NetHostPortKey.Int(8080),
+				},
+			},
+			want: &zkmodel.Endpoint{
+				ServiceName: "local-service",
+				IPv4:        net.ParseIP("1.2.3.4"),
+				Port:        8080,
+			},
+		},
+		{
+			name: "net-host-ipv6",
+			data: tracetest.SpanStub{
+				Resource: res,
+				Attributes: []attribute.KeyValue{
+					semconv.NetHostIPKey.String("2001:db8::1"),
+				},
+			},
+			want: &zkmodel.Endpoint{
+				ServiceName: "local-service",
+				IPv6:        net.ParseIP("2001:db8::1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toZipkinLocalEndpoint(tt.data.Snapshot())
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Diff%v", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package zipkin // import "go.opentelemetry.io/otel/exporters/zipkin"
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -27,7 +28,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/stdr"
+	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 
+	"go.opentelemetry.io/otel/exporters/retry"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -37,9 +41,16 @@ const (
 
 // Exporter exports spans to the zipkin collector.
 type Exporter struct {
-	url    string
-	client *http.Client
-	logger logr.Logger
+	url         string
+	client      *http.Client
+	logger      logr.Logger
+	requestFunc retry.RequestFunc
+	retryPolicy retry.Policy
+	encoding    Encoding
+	compression Compression
+	headers     map[string]string
+	headerFunc  HeaderProvider
+	batchSize   int
 
 	stoppedMu sync.RWMutex
 	stopped   bool
@@ -51,10 +62,43 @@ var emptyLogger = logr.Logger{}
 
 // Options contains configuration for the exporter.
 type config struct {
-	client *http.Client
-	logger logr.Logger
+	client      *http.Client
+	logger      logr.Logger
+	retryPolicy retry.Policy
+	encoding    Encoding
+	compression Compression
+	headers     map[string]string
+	headerFunc  HeaderProvider
+	batchSize   int
 }
 
+// Encoding is the format spans are encoded with when sent to the Zipkin
+// collector.
+type Encoding int
+
+const (
+	// JSONEncoding encodes spans using the Zipkin v2 JSON format.
+	JSONEncoding Encoding = iota
+	// ProtobufEncoding encodes spans using the Zipkin v2 protobuf
+	// (proto3) format.
+	ProtobufEncoding
+)
+
+// Compression is the compression applied to requests sent to the Zipkin
+// collector.
+type Compression int
+
+const (
+	// NoCompression sends requests uncompressed.
+	NoCompression Compression = iota
+	// GzipCompression compresses requests with gzip.
+	GzipCompression
+)
+
+// HeaderProvider returns HTTP headers to add to a request sent to the Zipkin
+// collector. It is called for every request made, including retries.
+type HeaderProvider func(ctx context.Context) (map[string]string, error)
+
 // Option defines a function that configures the exporter.
 type Option interface {
 	apply(config) config
@@ -89,6 +133,73 @@ func WithClient(client *http.Client) Option {
 	})
 }
 
+// WithRetry sets the retry policy for requests to the Zipkin collector that
+// fail with a transient error. Responses with an HTTP status code the policy
+// considers retryable are retried, honoring any Retry-After header received.
+//
+// If this option is not passed, failed requests are not retried.
+func WithRetry(policy retry.Policy) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.retryPolicy = policy
+		return cfg
+	})
+}
+
+// WithEncoding sets the encoding used to send spans to the Zipkin collector.
+//
+// If this option is not passed, JSONEncoding is used.
+func WithEncoding(encoding Encoding) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.encoding = encoding
+		return cfg
+	})
+}
+
+// WithCompression sets the compression used for requests sent to the Zipkin
+// collector.
+//
+// If this option is not passed, requests are not compressed.
+func WithCompression(compression Compression) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.compression = compression
+		return cfg
+	})
+}
+
+// WithHeaders sets static HTTP headers sent with every request to the Zipkin
+// collector.
+func WithHeaders(headers map[string]string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.headers = headers
+		return cfg
+	})
+}
+
+// WithHeaderProvider sets a function that returns HTTP headers to send with
+// every request to the Zipkin collector. The headers it returns take
+// precedence over those set with WithHeaders. If it returns an error the
+// request is not sent.
+func WithHeaderProvider(provider HeaderProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.headerFunc = provider
+		return cfg
+	})
+}
+
+// WithMaxBatchSize sets the maximum number of spans sent to the Zipkin
+// collector in a single request. Spans passed to ExportSpans in a larger
+// batch are sent using multiple requests, and each request is retried
+// independently.
+//
+// If this option is not passed, or size is less than or equal to zero, all
+// spans passed to ExportSpans are sent in a single request.
+func WithMaxBatchSize(size int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.batchSize = size
+		return cfg
+	})
+}
+
 // New creates a new Zipkin exporter.
 func New(collectorURL string, opts ...Option) (*Exporter, error) {
 	if collectorURL == "" {
@@ -112,9 +223,16 @@ func New(collectorURL string, opts ...Option) (*Exporter, error) {
 		cfg.client = http.DefaultClient
 	}
 	return &Exporter{
-		url:    collectorURL,
-		client: cfg.client,
-		logger: cfg.logger,
+		url:         collectorURL,
+		client:      cfg.client,
+		logger:      cfg.logger,
+		requestFunc: cfg.retryPolicy.RequestFunc(retry.EvaluateHTTP),
+		retryPolicy: cfg.retryPolicy,
+		encoding:    cfg.encoding,
+		compression: cfg.compression,
+		headers:     cfg.headers,
+		headerFunc:  cfg.headerFunc,
+		batchSize:   cfg.batchSize,
 	}, nil
 }
 
@@ -133,19 +251,124 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 		return nil
 	}
 	models := SpanModels(spans)
-	body, err := json.Marshal(models)
+	if e.batchSize <= 0 || len(models) <= e.batchSize {
+		return e.export(ctx, models)
+	}
+
+	var (
+		requests = (len(models) + e.batchSize - 1) / e.batchSize
+		failed   int
+		firstErr error
+	)
+	for i := 0; i < len(models); i += e.batchSize {
+		if err := ctx.Err(); err != nil {
+			// The remaining batches are not sent.
+			failed += requests - i/e.batchSize
+			if firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+		end := i + e.batchSize
+		if end > len(models) {
+			end = len(models)
+		}
+		if err := e.export(ctx, models[i:end]); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr == nil {
+		return nil
+	}
+	return fmt.Errorf("%d of %d export requests failed: %w", failed, requests, firstErr)
+}
+
+// export sends models to the Zipkin collector in a single request.
+func (e *Exporter) export(ctx context.Context, models []zkmodel.SpanModel) error {
+	body, err := e.marshal(models)
 	if err != nil {
-		return e.errf("failed to serialize zipkin models to JSON: %v", err)
+		return err
+	}
+	return e.requestFunc(ctx, func(ctx context.Context) error {
+		return e.send(ctx, body)
+	})
+}
+
+// marshal encodes models with the configured encoding and compression.
+func (e *Exporter) marshal(models []zkmodel.SpanModel) ([]byte, error) {
+	var (
+		body []byte
+		err  error
+	)
+	switch e.encoding {
+	case ProtobufEncoding:
+		ptrs := make([]*zkmodel.SpanModel, len(models))
+		for i := range models {
+			ptrs[i] = &models[i]
+		}
+		body, err = zipkin_proto3.SpanSerializer{}.Serialize(ptrs)
+		if err != nil {
+			return nil, e.errf("failed to serialize zipkin models to protobuf: %v", err)
+		}
+	default:
+		body, err = json.Marshal(models)
+		if err != nil {
+			return nil, e.errf("failed to serialize zipkin models to JSON: %v", err)
+		}
+	}
+
+	if e.compression == GzipCompression {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return nil, e.errf("failed to compress request body: %v", err)
+		}
+		if err := gz.Close(); err != nil {
+			return nil, e.errf("failed to compress request body: %v", err)
+		}
+		body = buf.Bytes()
 	}
-	e.logf("about to send a POST request to %s with body %s", e.url, body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(body))
+	return body, nil
+}
+
+// contentType returns the Content-Type of requests sent by e.
+func (e *Exporter) contentType() string {
+	if e.encoding == ProtobufEncoding {
+		return zipkin_proto3.SpanSerializer{}.ContentType()
+	}
+	return "application/json"
+}
+
+// send makes a single request sending body to the Zipkin collector.
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	e.logf("about to send a POST request to %s with a body of %d bytes", e.url, len(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
 	if err != nil {
 		return e.errf("failed to create request to %s: %v", e.url, err)
 	}
-	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	if e.headerFunc != nil {
+		headers, err := e.headerFunc(ctx)
+		if err != nil {
+			return e.errf("failed to get request headers: %v", err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", e.contentType())
+	if e.compression == GzipCompression {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return e.errf("request to %s failed: %v", e.url, err)
+		e.logf("request to %s failed: %v", e.url, err)
+		return retry.TransportError(fmt.Errorf("request to %s failed: %w", e.url, err))
 	}
 	defer resp.Body.Close()
 
@@ -159,7 +382,8 @@ func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpa
 	}
 
 	if resp.StatusCode != http.StatusAccepted {
-		return e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+		err := e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+		return e.retryPolicy.HTTPResponseError(resp, err)
 	}
 
 	return nil
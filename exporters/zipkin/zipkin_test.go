@@ -15,24 +15,30 @@
 package zipkin
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
 
 	zkmodel "github.com/openzipkin/zipkin-go/model"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/retry"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -364,3 +370,165 @@ func TestErrorOnExportShutdownExporter(t *testing.T) {
 	assert.NoError(t, exp.Shutdown(context.Background()))
 	assert.NoError(t, exp.ExportSpans(context.Background(), nil))
 }
+
+func TestExportSpansRetry(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	exp, err := New(srv.URL, WithRetry(retry.Policy{
+		Enabled:         true,
+		InitialInterval: time.Nanosecond,
+	}))
+	require.NoError(t, err)
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestExportSpansMaxBatchSize(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var models []json.RawMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&models))
+		mu.Lock()
+		sizes = append(sizes, len(models))
+		n := len(sizes)
+		mu.Unlock()
+		if n == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	exp, err := New(srv.URL, WithMaxBatchSize(2))
+	require.NoError(t, err)
+	stubs := make(tracetest.SpanStubs, 5)
+	for i := range stubs {
+		stubs[i].Name = fmt.Sprintf("span%d", i)
+	}
+	// All requests are sent even though the second one fails.
+	err = exp.ExportSpans(context.Background(), stubs.Snapshots())
+	assert.ErrorContains(t, err, "1 of 3 export requests failed")
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+
+	sizes = nil
+	require.NoError(t, exp.ExportSpans(context.Background(), stubs[:2].Snapshots()))
+	assert.Equal(t, []int{2}, sizes)
+}
+
+type failingTransport struct {
+	n        int32
+	requests int32
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&t.requests, 1) <= t.n {
+		return nil, errors.New("connection reset by peer")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestExportSpansRetryTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	transport := &failingTransport{n: 1}
+	exp, err := New(srv.URL,
+		WithClient(&http.Client{Transport: transport}),
+		WithRetry(retry.Policy{Enabled: true, InitialInterval: time.Nanosecond}),
+	)
+	require.NoError(t, err)
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&transport.requests))
+}
+
+func TestExportSpansProtobufGzipHeaders(t *testing.T) {
+	type request struct {
+		header http.Header
+		spans  []*zkmodel.SpanModel
+	}
+	requests := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gz, err := gzip.NewReader(r.Body)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(gz)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		spans, err := zipkin_proto3.ParseSpans(body, false)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests <- request{header: r.Header, spans: spans}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	exp, err := New(
+		srv.URL,
+		WithEncoding(ProtobufEncoding),
+		WithCompression(GzipCompression),
+		WithHeaders(map[string]string{"X-Static": "static", "X-Override": "static"}),
+		WithHeaderProvider(func(context.Context) (map[string]string, error) {
+			return map[string]string{"X-Override": "dynamic"}, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	spans := tracetest.SpanStubs{{
+		Name: "span",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x02},
+		}),
+	}}.Snapshots()
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+
+	got := <-requests
+	assert.Equal(t, "application/x-protobuf", got.header.Get("Content-Type"))
+	assert.Equal(t, "gzip", got.header.Get("Content-Encoding"))
+	assert.Equal(t, "static", got.header.Get("X-Static"))
+	assert.Equal(t, "dynamic", got.header.Get("X-Override"))
+	require.Len(t, got.spans, 1)
+	assert.Equal(t, "span", got.spans[0].Name)
+	want := SpanModels(spans)[0]
+	assert.Equal(t, want.TraceID, got.spans[0].TraceID)
+	assert.Equal(t, want.ID, got.spans[0].ID)
+}
+
+func TestExportSpansHeaderProviderError(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	exp, err := New(srv.URL, WithHeaderProvider(func(context.Context) (map[string]string, error) {
+		return nil, assert.AnError
+	}))
+	require.NoError(t, err)
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	assert.Error(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observ provides the hooks SDK components and exporters use to
+// report on their own operation.
+//
+// The hooks do not depend on the metric API so they can be used by stable
+// modules. An implementation recording metrics is installed with
+// go.opentelemetry.io/otel/sdk/selfobs. Components report to the
+// implementation most recently installed, regardless of whether they were
+// created before or after it was installed. Until one is installed, all
+// hooks do nothing.
+package observ // import "go.opentelemetry.io/otel/internal/observ"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchSpanProcessor receives the events of a batch span processor.
+type BatchSpanProcessor interface {
+	// Dropped is called when a span is dropped because the queue is full.
+	Dropped(ctx context.Context)
+	// Exported is called after a batch of n spans was passed to the
+	// exporter. The export took d and returned err.
+	Exported(ctx context.Context, n int, d time.Duration, err error)
+	// Shutdown is called when the batch span processor is shut down. No
+	// further events are sent after it is called.
+	Shutdown()
+}
+
+// Exporter receives the events of an exporter client.
+type Exporter interface {
+	// Request is called after an export request completed, including all
+	// retries. The request returned err.
+	Request(ctx context.Context, err error)
+	// Retry is called before an export request is retried.
+	Retry(ctx context.Context)
+	// Rejected is called when the receiver reported n items as rejected.
+	Rejected(ctx context.Context, n int64)
+}
+
+// Pipeline receives the events of a metric SDK pipeline.
+type Pipeline interface {
+	// Collected is called after a collection completed. The collection
+	// took d and returned err.
+	Collected(ctx context.Context, d time.Duration, err error)
+	// CallbackError is called when an instrument callback returned an
+	// error during a collection.
+	CallbackError(ctx context.Context)
+}
+
+// Provider creates the receivers of component events.
+type Provider interface {
+	// BatchSpanProcessor returns the receiver for a new batch span
+	// processor. The queue length and capacity of the processor are
+	// returned by queue.
+	BatchSpanProcessor(queue func() (length, capacity int)) BatchSpanProcessor
+	// Exporter returns the receiver for a new exporter client. The client
+	// is identified by scope, and item names what it exports (e.g. "spans"
+	// or "data_points").
+	Exporter(scope, item string) Exporter
+	// Pipeline returns the receiver for a new metric SDK pipeline.
+	Pipeline() Pipeline
+}
+
+// holder holds the installed Provider. The generation is incremented each
+// time a Provider is installed so receivers can tell it changed.
+type holder struct {
+	p   Provider
+	gen uint64
+}
+
+var (
+	global = func() *atomic.Value {
+		v := &atomic.Value{}
+		v.Store(holder{p: noop{}})
+		return v
+	}()
+
+	// mu serializes installing a Provider with the registration of batch
+	// span processors.
+	mu sync.Mutex
+	// bsps are the batch span processor receivers that have not been shut
+	// down. They are bound to a newly installed Provider right away so their
+	// queues are observed even if they receive no events.
+	bsps = make(map[*bspDelegate]struct{})
+)
+
+func installed() holder {
+	return global.Load().(holder)
+}
+
+// GetProvider returns a Provider whose receivers report to the Provider
+// installed with SetProvider. Receivers are not bound to the Provider
+// installed when they are created, they report to the one most recently
+// installed. If none is installed, the receivers do nothing.
+func GetProvider() Provider {
+	return delegate{}
+}
+
+// SetProvider installs p. All receivers, including those already created,
+// report to p. If p is nil, the Provider whose receivers do nothing is
+// installed.
+func SetProvider(p Provider) {
+	if p == nil {
+		p = noop{}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	global.Store(holder{p: p, gen: installed().gen + 1})
+	for b := range bsps {
+		b.bind(p)
+	}
+}
+
+// delegate is the Provider returned by GetProvider.
+type delegate struct{}
+
+func (delegate) BatchSpanProcessor(queue func() (int, int)) BatchSpanProcessor {
+	b := &bspDelegate{queue: queue}
+	mu.Lock()
+	defer mu.Unlock()
+	b.bind(installed().p)
+	bsps[b] = struct{}{}
+	return b
+}
+
+func (delegate) Exporter(scope, item string) Exporter {
+	return &exporterDelegate{lazy: lazy[Exporter]{newFunc: func(p Provider) Exporter {
+		return p.Exporter(scope, item)
+	}}}
+}
+
+func (delegate) Pipeline() Pipeline {
+	return &pipelineDelegate{lazy: lazy[Pipeline]{newFunc: func(p Provider) Pipeline {
+		return p.Pipeline()
+	}}}
+}
+
+// bspDelegate is a BatchSpanProcessor forwarding events to the receiver
+// created by the installed Provider.
+type bspDelegate struct {
+	queue func() (int, int)
+	// r holds a bspHolder with the receiver of the installed Provider.
+	r atomic.Value
+}
+
+type bspHolder struct {
+	r BatchSpanProcessor
+}
+
+// bind replaces the receiver of b with one created by p. It must be called
+// with mu held.
+func (b *bspDelegate) bind(p Provider) {
+	if h, ok := b.r.Load().(bspHolder); ok {
+		h.r.Shutdown()
+	}
+	b.r.Store(bspHolder{r: p.BatchSpanProcessor(b.queue)})
+}
+
+func (b *bspDelegate) get() BatchSpanProcessor {
+	return b.r.Load().(bspHolder).r
+}
+
+func (b *bspDelegate) Dropped(ctx context.Context) {
+	b.get().Dropped(ctx)
+}
+
+func (b *bspDelegate) Exported(ctx context.Context, n int, d time.Duration, err error) {
+	b.get().Exported(ctx, n, d, err)
+}
+
+func (b *bspDelegate) Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := bsps[b]; !ok {
+		return
+	}
+	delete(bsps, b)
+	b.get().Shutdown()
+}
+
+// lazy creates a receiver with the installed Provider when it is first used,
+// and again each time another Provider is installed.
+type lazy[T any] struct {
+	newFunc func(Provider) T
+
+	mu sync.Mutex
+	// cur holds the lazyEntry of the current receiver.
+	cur atomic.Value
+}
+
+type lazyEntry[T any] struct {
+	r   T
+	gen uint64
+}
+
+func (l *lazy[T]) get() T {
+	h := installed()
+	if e, ok := l.cur.Load().(lazyEntry[T]); ok && e.gen == h.gen {
+		return e.r
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.cur.Load().(lazyEntry[T]); ok && e.gen == h.gen {
+		return e.r
+	}
+	r := l.newFunc(h.p)
+	l.cur.Store(lazyEntry[T]{r: r, gen: h.gen})
+	return r
+}
+
+// exporterDelegate is an Exporter forwarding events to the receiver created
+// by the installed Provider.
+type exporterDelegate struct {
+	lazy lazy[Exporter]
+}
+
+func (e *exporterDelegate) Request(ctx context.Context, err error) {
+	e.lazy.get().Request(ctx, err)
+}
+
+func (e *exporterDelegate) Retry(ctx context.Context) {
+	e.lazy.get().Retry(ctx)
+}
+
+func (e *exporterDelegate) Rejected(ctx context.Context, n int64) {
+	e.lazy.get().Rejected(ctx, n)
+}
+
+// pipelineDelegate is a Pipeline forwarding events to the receiver created
+// by the installed Provider.
+type pipelineDelegate struct {
+	lazy lazy[Pipeline]
+}
+
+func (p *pipelineDelegate) Collected(ctx context.Context, d time.Duration, err error) {
+	p.lazy.get().Collected(ctx, d, err)
+}
+
+func (p *pipelineDelegate) CallbackError(ctx context.Context) {
+	p.lazy.get().CallbackError(ctx)
+}
+
+type noop struct{}
+
+func (noop) BatchSpanProcessor(func() (int, int)) BatchSpanProcessor { return noop{} }
+func (noop) Exporter(string, string) Exporter                        { return noop{} }
+func (noop) Pipeline() Pipeline                                      { return noop{} }
+
+func (noop) Dropped(context.Context)                             {}
+func (noop) Exported(context.Context, int, time.Duration, error) {}
+func (noop) Shutdown()                                           {}
+func (noop) Request(context.Context, error)                      {}
+func (noop) Retry(context.Context)                               {}
+func (noop) Rejected(context.Context, int64)                     {}
+func (noop) Collected(context.Context, time.Duration, error)     {}
+func (noop) CallbackError(context.Context)                       {}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observ
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counter is a Provider counting the receivers it creates and the events
+// they receive.
+type counter struct {
+	noop
+
+	created, events, queues int
+}
+
+func (c *counter) BatchSpanProcessor(func() (int, int)) BatchSpanProcessor {
+	c.created++
+	c.queues++
+	return &counterReceiver{c}
+}
+
+func (c *counter) Exporter(string, string) Exporter {
+	c.created++
+	return &counterReceiver{c}
+}
+
+func (c *counter) Pipeline() Pipeline {
+	c.created++
+	return &counterReceiver{c}
+}
+
+type counterReceiver struct {
+	c *counter
+}
+
+func (r *counterReceiver) Dropped(context.Context)                             { r.c.events++ }
+func (r *counterReceiver) Exported(context.Context, int, time.Duration, error) { r.c.events++ }
+func (r *counterReceiver) Shutdown()                                           { r.c.queues-- }
+func (r *counterReceiver) Request(context.Context, error)                      { r.c.events++ }
+func (r *counterReceiver) Retry(context.Context)                               { r.c.events++ }
+func (r *counterReceiver) Rejected(context.Context, int64)                     { r.c.events++ }
+func (r *counterReceiver) Collected(context.Context, time.Duration, error)     { r.c.events++ }
+func (r *counterReceiver) CallbackError(context.Context)                       { r.c.events++ }
+
+func TestReceiversCreatedBeforeSetProvider(t *testing.T) {
+	t.Cleanup(func() { SetProvider(nil) })
+	ctx := context.Background()
+
+	bsp := GetProvider().BatchSpanProcessor(func() (int, int) { return 0, 0 })
+	exp := GetProvider().Exporter("test", "spans")
+	pipe := GetProvider().Pipeline()
+	// Events before a Provider is installed are dropped.
+	bsp.Dropped(ctx)
+	exp.Request(ctx, nil)
+	pipe.CallbackError(ctx)
+
+	c := &counter{}
+	SetProvider(c)
+	assert.Equal(t, 1, c.queues, "batch span processor not bound on install")
+
+	bsp.Dropped(ctx)
+	exp.Request(ctx, nil)
+	exp.Retry(ctx)
+	pipe.CallbackError(ctx)
+	assert.Equal(t, 4, c.events)
+	assert.Equal(t, 3, c.created, "receivers not reused")
+
+	other := &counter{}
+	SetProvider(other)
+	assert.Equal(t, 0, c.queues, "batch span processor not unbound")
+	assert.Equal(t, 1, other.queues)
+	exp.Request(ctx, nil)
+	assert.Equal(t, 4, c.events)
+	assert.Equal(t, 1, other.events)
+
+	bsp.Shutdown()
+	assert.Equal(t, 0, other.queues)
+	// Shutting down again does not call the receiver again.
+	bsp.Shutdown()
+	assert.Equal(t, 0, other.queues)
+
+	// A batch span processor shut down is not bound to another Provider.
+	last := &counter{}
+	SetProvider(last)
+	assert.Equal(t, 0, last.queues)
+}
+
+func TestSetProviderNil(t *testing.T) {
+	c := &counter{}
+	SetProvider(c)
+	SetProvider(nil)
+	assert.Equal(t, noop{}, installed().p)
+
+	GetProvider().Exporter("test", "spans").Request(context.Background(), nil)
+	assert.Equal(t, 0, c.events)
+}
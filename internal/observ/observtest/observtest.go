@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observtest provides an observ.Provider for testing that records
+// the events it receives.
+package observtest // import "go.opentelemetry.io/otel/internal/observ/observtest"
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/observ"
+)
+
+// Counts are the events received by a Recorder.
+type Counts struct {
+	// Dropped is the number of spans dropped by batch span processors.
+	Dropped int
+	// Exported is the number of spans successfully exported by batch span
+	// processors, and ExportFailed the number whose export failed.
+	Exported, ExportFailed int
+	// Exports is the number of batches exported by batch span processors.
+	Exports int
+	// ProcessorShutdown is the number of batch span processors shut down.
+	ProcessorShutdown int
+
+	// Requests is the number of successful export requests of exporters,
+	// and RequestFailed the number of failed ones.
+	Requests, RequestFailed int
+	// Retries is the number of retried export requests.
+	Retries int
+	// Rejected is the number of items reported as rejected.
+	Rejected int64
+
+	// Collections is the number of successful pipeline collections, and
+	// CollectionFailed the number of failed ones.
+	Collections, CollectionFailed int
+	// CallbackErrors is the number of errors returned by callbacks.
+	CallbackErrors int
+}
+
+// Recorder is an observ.Provider that records the events of all components
+// it creates receivers for.
+type Recorder struct {
+	mu        sync.Mutex
+	counts    Counts
+	queues    []func() (int, int)
+	exporters [][2]string
+}
+
+var _ observ.Provider = (*Recorder)(nil)
+
+// Install returns a new Recorder installed as the observ.Provider for the
+// duration of t.
+func Install(t *testing.T) *Recorder {
+	r := &Recorder{}
+	observ.SetProvider(r)
+	t.Cleanup(func() { observ.SetProvider(nil) })
+	return r
+}
+
+// Counts returns the events received so far.
+func (r *Recorder) Counts() Counts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counts
+}
+
+// Queues returns the queue functions of the batch span processors created.
+func (r *Recorder) Queues() []func() (length, capacity int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]func() (int, int){}, r.queues...)
+}
+
+// Exporters returns the scope and item of the exporters created.
+func (r *Recorder) Exporters() [][2]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][2]string{}, r.exporters...)
+}
+
+func (r *Recorder) update(f func(*Counts)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(&r.counts)
+}
+
+// BatchSpanProcessor returns a receiver recording to r.
+func (r *Recorder) BatchSpanProcessor(queue func() (int, int)) observ.BatchSpanProcessor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queues = append(r.queues, queue)
+	return bsp{r}
+}
+
+// Exporter returns a receiver recording to r.
+func (r *Recorder) Exporter(scope, item string) observ.Exporter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exporters = append(r.exporters, [2]string{scope, item})
+	return exporter{r}
+}
+
+// Pipeline returns a receiver recording to r.
+func (r *Recorder) Pipeline() observ.Pipeline { return pipeline{r} }
+
+type bsp struct{ r *Recorder }
+
+func (b bsp) Dropped(context.Context) { b.r.update(func(c *Counts) { c.Dropped++ }) }
+
+func (b bsp) Exported(_ context.Context, n int, _ time.Duration, err error) {
+	b.r.update(func(c *Counts) {
+		c.Exports++
+		if err != nil {
+			c.ExportFailed += n
+		} else {
+			c.Exported += n
+		}
+	})
+}
+
+func (b bsp) Shutdown() { b.r.update(func(c *Counts) { c.ProcessorShutdown++ }) }
+
+type exporter struct{ r *Recorder }
+
+func (e exporter) Request(_ context.Context, err error) {
+	e.r.update(func(c *Counts) {
+		if err != nil {
+			c.RequestFailed++
+		} else {
+			c.Requests++
+		}
+	})
+}
+
+func (e exporter) Retry(context.Context) { e.r.update(func(c *Counts) { c.Retries++ }) }
+
+func (e exporter) Rejected(_ context.Context, n int64) {
+	e.r.update(func(c *Counts) { c.Rejected += n })
+}
+
+type pipeline struct{ r *Recorder }
+
+func (p pipeline) Collected(_ context.Context, _ time.Duration, err error) {
+	p.r.update(func(c *Counts) {
+		if err != nil {
+			c.CollectionFailed++
+		} else {
+			c.Collections++
+		}
+	})
+}
+
+func (p pipeline) CallbackError(context.Context) {
+	p.r.update(func(c *Counts) { c.CallbackErrors++ })
+}
@@ -8,12 +8,13 @@ require (
 	github.com/golangci/golangci-lint v1.50.1
 	github.com/itchyny/gojq v0.12.11
 	github.com/jcchavezs/porto v0.4.0
+	github.com/stretchr/testify v1.8.1
 	github.com/wadey/gocovmerge v0.0.0-20160331181800-b5bfa59ec0ad
 	go.opentelemetry.io/build-tools/crosslink v0.4.0
 	go.opentelemetry.io/build-tools/dbotconf v0.4.0
 	go.opentelemetry.io/build-tools/multimod v0.4.0
-	go.opentelemetry.io/build-tools/semconvgen v0.4.0
 	golang.org/x/tools v0.5.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -168,7 +169,6 @@ require (
 	github.com/ssgreg/nlreturn/v2 v2.2.1 // indirect
 	github.com/stbenjam/no-sprintf-host-port v0.1.1 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
-	github.com/stretchr/testify v1.8.1 // indirect
 	github.com/subosito/gotenv v1.4.1 // indirect
 	github.com/tdakkota/asciicheck v0.1.1 // indirect
 	github.com/tetafro/godot v1.4.11 // indirect
@@ -199,7 +199,6 @@ require (
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	honnef.co/go/tools v0.3.3 // indirect
 	mvdan.cc/gofumpt v0.4.0 // indirect
 	mvdan.cc/interfacer v0.0.0-20180901003855-c20040233aed // indirect
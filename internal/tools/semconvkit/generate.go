@@ -0,0 +1,361 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commentWidth is the width comment text, not including the comment marker,
+// is wrapped at.
+const commentWidth = 76
+
+// attrMethods are the attribute.Key methods and Go types used to create
+// attributes of a primitive semantic convention type.
+var attrMethods = map[string]struct{ method, goType string }{
+	"string":    {"String", "string"},
+	"int":       {"Int", "int"},
+	"double":    {"Float64", "float64"},
+	"boolean":   {"Bool", "bool"},
+	"string[]":  {"StringSlice", "[]string"},
+	"int[]":     {"IntSlice", "[]int"},
+	"double[]":  {"Float64Slice", "[]float64"},
+	"boolean[]": {"BoolSlice", "[]bool"},
+}
+
+// attrGroup is the data rendered for a group of attributes.
+type attrGroup struct {
+	Brief string
+	Attrs []attrDef
+}
+
+// attrDef is the data rendered for an attribute.
+type attrDef struct {
+	FQN  string
+	Name string
+	Doc  string
+	// Constructor is the documentation of the typed constructor of the
+	// attribute. It is empty for enums.
+	Constructor string
+	Method      string
+	GoType      string
+	Enum        []enumValue
+}
+
+// enumValue is the data rendered for a well-known value of an enum.
+type enumValue struct {
+	Name  string
+	Brief string
+	Value string
+}
+
+// metricDef is the data rendered for a metric instrument.
+type metricDef struct {
+	Name        string
+	MetricName  string
+	Instrument  string
+	Unit        string
+	Description string
+}
+
+// modelData is the data the model templates are rendered with.
+type modelData struct {
+	*SemanticConventions
+
+	Groups  []attrGroup
+	Metrics []metricDef
+}
+
+// generateModel renders the attributes and metrics of the semantic
+// convention model in the dir directory tree to the dest directory.
+func generateModel(dir, dest string, sc *SemanticConventions) error {
+	m, err := LoadModel(dir)
+	if err != nil {
+		return err
+	}
+
+	var resource, trace []Group
+	var metrics []metricDef
+	for _, g := range m.Groups {
+		switch g.Type {
+		case groupResource:
+			resource = append(resource, g)
+		case groupMetric:
+			metrics = append(metrics, metricDef{
+				Name:        goName(g.MetricName),
+				MetricName:  g.MetricName,
+				Instrument:  g.Instrument,
+				Unit:        g.Unit,
+				Description: strings.TrimSpace(g.Brief),
+			})
+			// Attributes defined by metrics are rendered with the trace
+			// attributes, they are not specific to the metric.
+			trace = append(trace, g)
+		default:
+			trace = append(trace, g)
+		}
+	}
+
+	seen := newIdentifiers()
+	files := []struct {
+		name, tmpl string
+		data       func() (*modelData, error)
+	}{
+		{"resource.go", "attribute.go.tmpl", func() (*modelData, error) { return attrData(sc, resource, seen) }},
+		{"trace.go", "attribute.go.tmpl", func() (*modelData, error) { return attrData(sc, trace, seen) }},
+		{"metric.go", "metric.go.tmpl", func() (*modelData, error) { return metricData(sc, metrics, seen) }},
+	}
+	for _, f := range files {
+		data, err := f.data()
+		if err != nil {
+			return err
+		}
+		if len(data.Groups) == 0 && len(data.Metrics) == 0 {
+			continue
+		}
+		if err := renderModel(f.tmpl, filepath.Join(dest, f.name), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// identifiers tracks the Go identifiers and attributes already rendered.
+type identifiers struct {
+	names map[string]string
+	fqns  map[string]bool
+}
+
+func newIdentifiers() *identifiers {
+	return &identifiers{names: map[string]string{}, fqns: map[string]bool{}}
+}
+
+// add records the Go identifier name declared for the semantic convention
+// named from. An error is returned if the identifier is already declared.
+func (ids *identifiers) add(name, from string) error {
+	if prev, ok := ids.names[name]; ok {
+		return fmt.Errorf("identifier %s of %q conflicts with %q", name, from, prev)
+	}
+	ids.names[name] = from
+	return nil
+}
+
+// attrData returns the data to render the attributes defined by groups.
+// Attributes already rendered are skipped.
+func attrData(sc *SemanticConventions, groups []Group, ids *identifiers) (*modelData, error) {
+	data := &modelData{SemanticConventions: sc}
+	for _, g := range groups {
+		ag := attrGroup{Brief: strings.TrimSpace(g.Brief)}
+		for _, a := range g.Attributes {
+			if a.Ref != "" {
+				continue
+			}
+			fqn := g.FQN(a)
+			if ids.fqns[fqn] {
+				continue
+			}
+			ids.fqns[fqn] = true
+
+			def, err := newAttrDef(fqn, a, ids)
+			if err != nil {
+				return nil, err
+			}
+			ag.Attrs = append(ag.Attrs, def)
+		}
+		if len(ag.Attrs) > 0 {
+			data.Groups = append(data.Groups, ag)
+		}
+	}
+	return data, nil
+}
+
+// newAttrDef returns the data to render the attribute a named fqn.
+func newAttrDef(fqn string, a Attribute, ids *identifiers) (attrDef, error) {
+	def := attrDef{FQN: fqn, Name: goName(fqn)}
+	if err := ids.add(def.Name+"Key", fqn); err != nil {
+		return def, err
+	}
+
+	typ := "Enum"
+	if a.Type.Enum == nil {
+		typ = a.Type.Primitive
+		m, ok := attrMethods[typ]
+		if !ok {
+			return def, fmt.Errorf("attribute %q: unsupported type %q", fqn, typ)
+		}
+		def.Method, def.GoType = m.method, m.goType
+		if err := ids.add(def.Name, fqn); err != nil {
+			return def, err
+		}
+		def.Constructor = constructorDoc(def.Name, fqn, a)
+	} else {
+		t, err := a.Type.Enum.enumType()
+		if err != nil {
+			return def, fmt.Errorf("attribute %q: %w", fqn, err)
+		}
+		method := attrMethods[t].method
+		for _, m := range a.Type.Enum.Members {
+			v := enumValue{
+				Name:  goName(fqn + "." + m.ID),
+				Brief: strings.TrimSpace(m.Brief),
+			}
+			if s, ok := m.Value.(string); ok {
+				v.Value = fmt.Sprintf("%s(%q)", method, s)
+			} else {
+				v.Value = fmt.Sprintf("%s(%d)", method, m.Value)
+			}
+			if err := ids.add(v.Name, fqn+"."+m.ID); err != nil {
+				return def, err
+			}
+			def.Enum = append(def.Enum, v)
+		}
+	}
+
+	def.Doc = attrDoc(typ, a)
+	return def, nil
+}
+
+// attrDoc returns the documentation of the key of attribute a of type typ.
+func attrDoc(typ string, a Attribute) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(a.Brief))
+	b.WriteString("\n\nType: ")
+	b.WriteString(typ)
+	b.WriteString("\nRequirementLevel: ")
+	switch a.RequirementLevel.Level {
+	case "required":
+		b.WriteString("Required")
+	case "conditionally_required":
+		b.WriteString("ConditionallyRequired")
+	case "recommended":
+		b.WriteString("Recommended")
+	default:
+		b.WriteString("Optional")
+	}
+	if msg := strings.TrimSpace(a.RequirementLevel.Message); msg != "" {
+		fmt.Fprintf(&b, " (%s)", msg)
+	}
+	stability := a.Stability
+	if stability == "" {
+		stability = "stable"
+	}
+	b.WriteString("\nStability: ")
+	b.WriteString(stability)
+	if a.Deprecated != "" {
+		b.WriteString("\nDeprecated: ")
+		b.WriteString(strings.TrimSpace(a.Deprecated))
+	}
+	if ex := formatExamples(a.Examples); ex != "" {
+		b.WriteString("\nExamples: ")
+		b.WriteString(ex)
+	}
+	if note := strings.TrimSpace(a.Note); note != "" {
+		b.WriteString("\nNote: ")
+		b.WriteString(note)
+	}
+	return b.String()
+}
+
+// constructorDoc returns the documentation of the typed constructor name of
+// the attribute a named fqn.
+func constructorDoc(name, fqn string, a Attribute) string {
+	doc := fmt.Sprintf("%s returns an attribute KeyValue conforming to the %q semantic conventions.", name, fqn)
+	if brief := strings.TrimSuffix(strings.TrimSpace(a.Brief), "."); brief != "" {
+		doc += " It represents " + lowerFirst(brief) + "."
+	}
+	if a.Deprecated != "" {
+		doc += "\n\nDeprecated: " + strings.TrimSpace(a.Deprecated)
+	}
+	return doc
+}
+
+// lowerFirst returns s with its first letter lower cased, unless it is part
+// of an initialism (e.g. "HTTP").
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	next, _ := utf8.DecodeRuneInString(s[n:])
+	if unicode.IsUpper(next) {
+		return s
+	}
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// metricData returns the data to render the metric instruments.
+func metricData(sc *SemanticConventions, metrics []metricDef, ids *identifiers) (*modelData, error) {
+	for _, m := range metrics {
+		for _, suffix := range []string{"Name", "Unit", "Description"} {
+			if err := ids.add(m.Name+suffix, m.MetricName); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &modelData{SemanticConventions: sc, Metrics: metrics}, nil
+}
+
+// renderModel renders the model template name to the target file. The
+// output is formatted as Go source code.
+func renderModel(name, target string, data *modelData) error {
+	tmpl, err := template.New(filepath.Base(name)).Funcs(template.FuncMap{
+		"comment": comment,
+	}).ParseFS(rootFS, "templates/model/"+name)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w", target, err)
+	}
+	return os.WriteFile(target, src, 0o600)
+}
+
+// comment returns text as Go line comments indented with indent. Lines are
+// wrapped at commentWidth.
+func comment(indent, text string) string {
+	if text == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			b.WriteString(indent + "//\n")
+			continue
+		}
+		cur := words[0]
+		for _, w := range words[1:] {
+			if len(cur)+1+len(w) > commentWidth {
+				b.WriteString(indent + "// " + cur + "\n")
+				cur = w
+				continue
+			}
+			cur += " " + w
+		}
+		b.WriteString(indent + "// " + cur + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
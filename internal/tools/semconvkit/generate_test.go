@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestRegenerate checks that generating a semconv package from the semantic
+// convention model of the OpenTelemetry specification reproduces the
+// checked-in package. It is run when the OTEL_SPEC_REPO environment variable
+// is set to a checkout of the specification repository. The TAG environment
+// variable selects the version, the newest semconv package by default.
+//
+//	OTEL_SPEC_REPO=/path/to/opentelemetry-specification go test -run TestRegenerate
+func TestRegenerate(t *testing.T) {
+	spec := os.Getenv("OTEL_SPEC_REPO")
+	if spec == "" {
+		t.Skip("OTEL_SPEC_REPO not set")
+	}
+	semconvDir := filepath.Join("..", "..", "..", "semconv")
+	tag := os.Getenv("TAG")
+	if tag == "" {
+		tag = newestVersion(t, semconvDir)
+	}
+
+	dir := t.TempDir()
+	sc := &SemanticConventions{TagVer: tag}
+	model := filepath.Join(spec, "semantic_conventions")
+	if !sc.FromModel() {
+		// Only the opentelemetry-go specific code of these versions is
+		// generated by semconvkit.
+		model = ""
+	}
+	require.NoError(t, generate(model, dir, sc))
+
+	checkedIn := filepath.Join(semconvDir, tag)
+	generated := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		generated[rel] = true
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		want, err := os.ReadFile(filepath.Join(checkedIn, rel))
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s: generated but not checked in", rel)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		assert.Equal(t, string(want), string(got), rel)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// All generated files checked in need to be reproduced.
+	err = filepath.WalkDir(checkedIn, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		rel, err := filepath.Rel(checkedIn, path)
+		if err != nil || generated[rel] || (!sc.FromModel() && modelFiles[rel]) {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(b, []byte("Code generated")) {
+			t.Errorf("%s: checked in but not generated", rel)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+// modelFiles are the files generated from the semantic convention model.
+var modelFiles = map[string]bool{"resource.go": true, "trace.go": true, "metric.go": true}
+
+// newestVersion returns the newest version of the semconv packages in dir.
+func newestVersion(t *testing.T, dir string) string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var newest string
+	var newestParts [3]int
+	for _, e := range entries {
+		var parts [3]int
+		if _, err := fmt.Sscanf(e.Name(), "v%d.%d.%d", &parts[0], &parts[1], &parts[2]); err != nil || !e.IsDir() {
+			continue
+		}
+		for i := range parts {
+			if parts[i] != newestParts[i] {
+				if parts[i] > newestParts[i] {
+					newest, newestParts = e.Name(), parts
+				}
+				break
+			}
+		}
+	}
+	require.NotEmpty(t, newest, "no semconv package found")
+	return newest
+}
+
+func TestGenerateModel(t *testing.T) {
+	dir := t.TempDir()
+	sc := &SemanticConventions{TagVer: "vtest"}
+	require.NoError(t, generateModel("testdata/model", dir, sc))
+
+	for _, name := range []string{"resource.go", "trace.go", "metric.go"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+
+		golden := filepath.Join("testdata", "golden", name+".golden")
+		if *update {
+			require.NoError(t, os.WriteFile(golden, got, 0o600))
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), name)
+	}
+}
+
+func TestGenerateModelConflict(t *testing.T) {
+	model := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(model, "conflict.yaml"), []byte(`groups:
+  - id: a
+    prefix: a
+    brief: A.
+    attributes:
+      - id: b_c
+        type: string
+        brief: A B C.
+  - id: a.b
+    prefix: a.b
+    brief: A B.
+    attributes:
+      - id: c
+        type: string
+        brief: A B C.
+`), 0o600))
+
+	err := generateModel(model, t.TempDir(), &SemanticConventions{TagVer: "vtest"})
+	assert.ErrorContains(t, err, "ABCKey")
+}
+
+func TestGenerateModelVersion(t *testing.T) {
+	err := generate("testdata/model", t.TempDir(), &SemanticConventions{TagVer: "v1.14.0"})
+	assert.ErrorContains(t, err, "not generated from the model")
+
+	dir := t.TempDir()
+	require.NoError(t, generate("testdata/model", dir, &SemanticConventions{TagVer: "v1.15.0"}))
+	assert.FileExists(t, filepath.Join(dir, "trace.go"))
+	assert.FileExists(t, filepath.Join(dir, "metric.go"))
+}
+
+func TestGoName(t *testing.T) {
+	for name, want := range map[string]string{
+		"http.request_content_length": "HTTPRequestContentLength",
+		"k8s.pod.uid":                 "K8SPodUID",
+		"faas.max_memory":             "FaaSMaxMemory",
+		"aws.ecs.task.arn":            "AWSECSTaskARN",
+		"os.type.z_os":                "OSTypeZOS",
+		"db.system.postgresql":        "DBSystemPostgreSQL",
+		"http.flavor.http_1_1":        "HTTPFlavorHTTP11",
+	} {
+		assert.Equal(t, want, goName(name), name)
+	}
+}
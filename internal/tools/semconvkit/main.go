@@ -12,10 +12,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package semconvkit is used to generate opentelemetry-go specific semantic
-// convention code. It is expected to be used in with the semconvgen utility
-// (go.opentelemetry.io/build-tools/semconvgen) to completely generate
-// versioned sub-packages of go.opentelemetry.io/otel/semconv.
+// Package semconvkit is used to generate versioned sub-packages of
+// go.opentelemetry.io/otel/semconv.
+//
+// When the -model flag is set to the semantic conventions YAML model
+// directory of the OpenTelemetry specification (semantic_conventions), the
+// attribute key constants, enum values, and typed attribute constructors are
+// generated in resource.go and trace.go, and the metric instrument name,
+// unit, and description constants in metric.go. This is only supported for
+// versions after v1.14.0, the attributes of older versions were generated
+// with semconvgen. The opentelemetry-go specific code is always generated.
 package main
 
 import (
@@ -30,10 +36,11 @@ import (
 )
 
 var (
-	out = flag.String("output", "./", "output directory")
-	tag = flag.String("tag", "", "OpenTelemetry tagged version")
+	out   = flag.String("output", "./", "output directory")
+	tag   = flag.String("tag", "", "OpenTelemetry tagged version")
+	model = flag.String("model", "", "semantic conventions YAML model directory")
 
-	//go:embed templates/*.tmpl templates/netconv/*.tmpl templates/httpconv/*.tmpl
+	//go:embed templates/*.tmpl templates/netconv/*.tmpl templates/httpconv/*.tmpl templates/rpcconv/*.tmpl templates/messagingconv/*.tmpl templates/dbconv/*.tmpl templates/model/*.tmpl
 	rootFS embed.FS
 )
 
@@ -45,7 +52,30 @@ type SemanticConventions struct {
 }
 
 func (sc SemanticConventions) SemVer() string {
-	return strings.TrimPrefix(*tag, "v")
+	return strings.TrimPrefix(sc.TagVer, "v")
+}
+
+// firstModelVersion is the first version whose attributes and metrics are
+// generated from the semantic convention model. The resource.go and trace.go
+// files of older versions were generated with semconvgen. They use a
+// different documentation format and have no typed attribute constructors or
+// metric constants, so they are not regenerated.
+var firstModelVersion = [3]int{1, 15, 0}
+
+// FromModel reports whether the attributes and metrics of sc are generated
+// from the semantic convention model.
+func (sc SemanticConventions) FromModel() bool {
+	var v [3]int
+	if _, err := fmt.Sscanf(sc.TagVer, "v%d.%d.%d", &v[0], &v[1], &v[2]); err != nil {
+		// Not a release version, e.g. a development build of the model.
+		return true
+	}
+	for i := range v {
+		if v[i] != firstModelVersion[i] {
+			return v[i] > firstModelVersion[i]
+		}
+	}
+	return true
 }
 
 // render renders all templates to the dest directory using the data.
@@ -62,6 +92,9 @@ func render(src, dest string, data *SemanticConventions) error {
 		}
 
 		err = tmpl.Execute(wr, data)
+		if cErr := wr.Close(); err == nil {
+			err = cErr
+		}
 		if err != nil {
 			return err
 		}
@@ -78,26 +111,43 @@ func main() {
 	}
 
 	sc := &SemanticConventions{TagVer: *tag}
-
-	if err := render("templates/*.tmpl", *out, sc); err != nil {
+	if err := generate(*model, *out, sc); err != nil {
 		log.Fatal(err)
 	}
+}
 
-	dest := fmt.Sprintf("%s/netconv", *out)
-	// Ensure the dest dir exists (MkdirAll does nothing if dest is a directory
-	// and already exists).
+// generate renders the semconv package of sc to the dest directory. The
+// attributes and metrics are generated from the semantic convention model in
+// the model directory, unless it is empty.
+func generate(model, dest string, sc *SemanticConventions) error {
+	// Ensure the output directory of a new version exists.
 	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
-		log.Fatal(err)
+		return err
 	}
-	if err := render("templates/netconv/*.tmpl", dest, sc); err != nil {
-		log.Fatal(err)
+
+	if model != "" {
+		if !sc.FromModel() {
+			return fmt.Errorf("%s: attributes of versions before v%d.%d.%d are not generated from the model", sc.TagVer, firstModelVersion[0], firstModelVersion[1], firstModelVersion[2])
+		}
+		if err := generateModel(model, dest, sc); err != nil {
+			return err
+		}
 	}
 
-	dest = fmt.Sprintf("%s/httpconv", *out)
-	if err := os.MkdirAll(dest, os.ModePerm); err != nil {
-		log.Fatal(err)
+	if err := render("templates/*.tmpl", dest, sc); err != nil {
+		return err
 	}
-	if err := render("templates/httpconv/*.tmpl", dest, sc); err != nil {
-		log.Fatal(err)
+
+	for _, pkg := range []string{"netconv", "httpconv", "rpcconv", "messagingconv", "dbconv"} {
+		dir := filepath.Join(dest, pkg)
+		// Ensure the dir exists (MkdirAll does nothing if dir is a directory
+		// and already exists).
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+		if err := render(fmt.Sprintf("templates/%s/*.tmpl", pkg), dir, sc); err != nil {
+			return err
+		}
 	}
+	return nil
 }
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Group types of the semantic convention model.
+const (
+	groupSpan     = "span"
+	groupResource = "resource"
+	groupMetric   = "metric"
+)
+
+// Model is a semantic convention YAML model. It is the combination of all
+// the model files of a semantic conventions directory.
+type Model struct {
+	Groups []Group `yaml:"groups"`
+}
+
+// Group is a semantic convention group.
+type Group struct {
+	ID         string      `yaml:"id"`
+	Type       string      `yaml:"type"`
+	Prefix     string      `yaml:"prefix"`
+	Brief      string      `yaml:"brief"`
+	Attributes []Attribute `yaml:"attributes"`
+
+	// Metric groups only.
+	MetricName string `yaml:"metric_name"`
+	Instrument string `yaml:"instrument"`
+	Unit       string `yaml:"unit"`
+}
+
+// Attribute is an attribute defined, or referenced, by a Group.
+type Attribute struct {
+	ID               string           `yaml:"id"`
+	Ref              string           `yaml:"ref"`
+	Type             AttributeType    `yaml:"type"`
+	Brief            string           `yaml:"brief"`
+	Note             string           `yaml:"note"`
+	Examples         interface{}      `yaml:"examples"`
+	RequirementLevel RequirementLevel `yaml:"requirement_level"`
+	Stability        string           `yaml:"stability"`
+	Deprecated       string           `yaml:"deprecated"`
+}
+
+// AttributeType is the type of an Attribute. It is either a primitive type
+// (e.g. "string" or "int[]") or an enum.
+type AttributeType struct {
+	Primitive string
+	Enum      *Enum
+}
+
+// UnmarshalYAML decodes a primitive type name or an enum definition.
+func (t *AttributeType) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		t.Primitive = n.Value
+		return nil
+	}
+	t.Enum = new(Enum)
+	return n.Decode(t.Enum)
+}
+
+// Enum is an attribute type with a set of well-known values.
+type Enum struct {
+	AllowCustomValues bool     `yaml:"allow_custom_values"`
+	Members           []Member `yaml:"members"`
+}
+
+// Member is a well-known value of an Enum.
+type Member struct {
+	ID    string      `yaml:"id"`
+	Value interface{} `yaml:"value"`
+	Brief string      `yaml:"brief"`
+}
+
+// RequirementLevel is the requirement level of an Attribute, with an
+// optional message describing the condition of the level.
+type RequirementLevel struct {
+	Level   string
+	Message string
+}
+
+// UnmarshalYAML decodes a level (e.g. "required") or a single entry mapping
+// of a level to its message (e.g. "recommended: if available").
+func (r *RequirementLevel) UnmarshalYAML(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		r.Level = n.Value
+		return nil
+	case yaml.MappingNode:
+		if len(n.Content) == 2 {
+			r.Level, r.Message = n.Content[0].Value, n.Content[1].Value
+			return nil
+		}
+	}
+	return fmt.Errorf("line %d: invalid requirement_level", n.Line)
+}
+
+// LoadModel returns the Model of all YAML files in the dir directory tree.
+// Files are read in lexical order.
+func LoadModel(dir string) (*Model, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no semantic convention model files found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	var m Model
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var file Model
+		if err := yaml.Unmarshal(b, &file); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		m.Groups = append(m.Groups, file.Groups...)
+	}
+	return &m, nil
+}
+
+// FQN returns the fully qualified name of the attribute a defined in group
+// g.
+func (g Group) FQN(a Attribute) string {
+	if g.Prefix == "" {
+		return a.ID
+	}
+	return g.Prefix + "." + a.ID
+}
+
+// errEnumType is returned for enums with members of mixed types.
+var errEnumType = errors.New("enum members need to be all strings or all ints")
+
+// enumType returns the primitive type of the members of e.
+func (e Enum) enumType() (string, error) {
+	typ := ""
+	for _, m := range e.Members {
+		var t string
+		switch m.Value.(type) {
+		case string:
+			t = "string"
+		case int:
+			t = "int"
+		default:
+			return "", fmt.Errorf("enum member %s: %w", m.ID, errEnumType)
+		}
+		if typ != "" && typ != t {
+			return "", errEnumType
+		}
+		typ = t
+	}
+	return typ, nil
+}
+
+// formatExamples returns the examples of an attribute in the format used by
+// the attribute documentation (e.g. 'GET', 'POST').
+func formatExamples(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		s := make([]string, len(v))
+		for i, e := range v {
+			if l, ok := e.([]interface{}); ok {
+				s[i] = "[" + formatExamples(l) + "]"
+			} else {
+				s[i] = formatExamples(e)
+			}
+		}
+		return strings.Join(s, ", ")
+	case string:
+		return "'" + v + "'"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// capitalizations are the Go spelling of words that are not title cased
+// when used in identifiers, e.g. initialisms and product names. Words are
+// the lower case parts of a semantic convention name separated by "." or "_".
+var capitalizations = map[string]string{
+	"aix":          "AIX",
+	"aks":          "AKS",
+	"amd64":        "AMD64",
+	"arm32":        "ARM32",
+	"arm64":        "ARM64",
+	"arn":          "ARN",
+	"arns":         "ARNs",
+	"aws":          "AWS",
+	"cosmosdb":     "CosmosDB",
+	"couchdb":      "CouchDB",
+	"cpp":          "CPP",
+	"cpu":          "CPU",
+	"cronjob":      "CronJob",
+	"daemonset":    "DaemonSet",
+	"db":           "DB",
+	"db2":          "DB2",
+	"dc":           "DC",
+	"dns":          "DNS",
+	"dragonflybsd": "DragonflyBSD",
+	"dynamodb":     "DynamoDB",
+	"ec2":          "EC2",
+	"ecs":          "ECS",
+	"edb":          "EDB",
+	"eks":          "EKS",
+	"faas":         "FaaS",
+	"firstsql":     "FirstSQL",
+	"freebsd":      "FreeBSD",
+	"gc":           "GC",
+	"gcp":          "GCP",
+	"grpc":         "GRPC",
+	"hanadb":       "HanaDB",
+	"hbase":        "HBase",
+	"hp":           "HP",
+	"hsqldb":       "HSQLDB",
+	"http":         "HTTP",
+	"https":        "HTTPS",
+	"ia64":         "IA64",
+	"id":           "ID",
+	"instantdb":    "InstantDB",
+	"io":           "IO",
+	"ip":           "IP",
+	"jdbc":         "JDBC",
+	"json":         "JSON",
+	"jvm":          "JVM",
+	"lineno":       "LineNumber",
+	"mariadb":      "MariaDB",
+	"maxdb":        "MaxDB",
+	"mongodb":      "MongoDB",
+	"mssql":        "MSSQL",
+	"mysql":        "MySQL",
+	"netbsd":       "NetBSD",
+	"openbsd":      "OpenBSD",
+	"os":           "OS",
+	"othersql":     "OtherSQL",
+	"php":          "PHP",
+	"pid":          "PID",
+	"postgresql":   "PostgreSQL",
+	"ppc32":        "PPC32",
+	"ppc64":        "PPC64",
+	"quic":         "QUIC",
+	"replicaset":   "ReplicaSet",
+	"rpc":          "RPC",
+	"sdk":          "SDK",
+	"spdy":         "SPDY",
+	"sql":          "SQL",
+	"statefulset":  "StatefulSet",
+	"tcp":          "TCP",
+	"tls":          "TLS",
+	"udp":          "UDP",
+	"uid":          "UID",
+	"ui":           "UI",
+	"uri":          "URI",
+	"url":          "URL",
+	"ux":           "UX",
+	"vm":           "VM",
+	"webengine":    "WebEngine",
+}
+
+// goName returns the exported Go identifier of the semantic convention
+// name, e.g. "HTTPRequestContentLength" for
+// "http.request_content_length".
+func goName(name string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-'
+	}) {
+		w = strings.ToLower(w)
+		if c, ok := capitalizations[w]; ok {
+			b.WriteString(c)
+			continue
+		}
+		b.WriteString(title(w))
+	}
+	return b.String()
+}
+
+// title returns w with the first letter of each sequence of letters upper
+// cased, e.g. "K8S" for "k8s".
+func title(w string) string {
+	rs := []rune(w)
+	prevLetter := false
+	for i, r := range rs {
+		if !prevLetter {
+			rs[i] = unicode.ToUpper(r)
+		}
+		prevLetter = unicode.IsLetter(r)
+	}
+	return string(rs)
+}
@@ -18,6 +18,7 @@ package metricdatatest // import "go.opentelemetry.io/otel/sdk/metric/metricdata
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -44,6 +45,8 @@ type Datatypes interface {
 
 type config struct {
 	ignoreTimestamp bool
+	ignoreValue     bool
+	floatEpsilon    float64
 }
 
 // Option allows for fine grain control over how AssertEqual operates.
@@ -65,8 +68,35 @@ func IgnoreTimestamp() Option {
 	})
 }
 
+// IgnoreValue disables checking if values are different. For DataPoints the
+// Value is ignored, and for HistogramDataPoints the Count, BucketCounts, Min,
+// Max and Sum are ignored. Attributes, timestamps and histogram Bounds are
+// still compared.
+//
+// This is useful when the recorded values are not deterministic, e.g.
+// histograms recording durations.
+func IgnoreValue() Option {
+	return fnOption(func(cfg config) config {
+		cfg.ignoreValue = true
+		return cfg
+	})
+}
+
+// ApproximateFloat considers float64 values equal if the absolute difference
+// between them is not greater than epsilon. It applies to float64 DataPoint
+// values and the Min, Max and Sum of HistogramDataPoints.
+func ApproximateFloat(epsilon float64) Option {
+	return fnOption(func(cfg config) config {
+		cfg.floatEpsilon = math.Abs(epsilon)
+		return cfg
+	})
+}
+
 // AssertEqual asserts that the two concrete data-types from the metricdata
 // package are equal.
+//
+// Slices of ScopeMetrics, Metrics and data points are compared based on
+// containing the same elements, not the order they are stored in.
 func AssertEqual[T Datatypes](t *testing.T, expected, actual T, opts ...Option) bool {
 	t.Helper()
 
@@ -263,6 +263,43 @@ func TestAssertEqualIgnoreTime(t *testing.T) {
 	t.Run("DataPointFloat64", testDatatypeIgnoreTime(dataPointFloat64A, dataPointFloat64C, equalDataPoints[float64]))
 }
 
+func TestAssertEqualIgnoreValue(t *testing.T) {
+	dpA := dataPointFloat64A
+	dpB := dataPointFloat64A
+	dpB.Value = 3.5
+	AssertEqual(t, dpA, dpB, IgnoreValue())
+	assert.NotEmpty(t, equalDataPoints(dpA, dpB, config{}))
+
+	hA := histogramDataPointB
+	hB := histogramDataPointB
+	hB.Count, hB.Sum, hB.BucketCounts, hB.Min = 5, 12, []uint64{0, 2, 3}, nil
+	AssertEqual(t, hA, hB, IgnoreValue())
+	assert.NotEmpty(t, equalHistogramDataPoints(hA, hB, config{}))
+
+	hB.Bounds = []float64{1}
+	assert.NotEmpty(t, equalHistogramDataPoints(hA, hB, config{ignoreValue: true}), "bounds are compared")
+}
+
+func TestAssertEqualApproximateFloat(t *testing.T) {
+	dpA := dataPointFloat64A
+	dpB := dataPointFloat64A
+	dpB.Value += 0.001
+	AssertEqual(t, dpA, dpB, ApproximateFloat(0.01))
+	assert.NotEmpty(t, equalDataPoints(dpA, dpB, config{floatEpsilon: 0.0001}))
+
+	hA := histogramDataPointB
+	hB := histogramDataPointB
+	minB := *hA.Min + 0.001
+	hB.Sum, hB.Min = hA.Sum+0.001, &minB
+	AssertEqual(t, hA, hB, ApproximateFloat(0.01))
+	assert.NotEmpty(t, equalHistogramDataPoints(hA, hB, config{}))
+
+	iA := dataPointInt64A
+	iB := dataPointInt64A
+	iB.Value++
+	assert.NotEmpty(t, equalDataPoints(iA, iB, config{floatEpsilon: 10}), "int64 values are compared exactly")
+}
+
 type unknownAggregation struct {
 	metricdata.Aggregation
 }
@@ -17,6 +17,7 @@ package metricdatatest // import "go.opentelemetry.io/otel/sdk/metric/metricdata
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"reflect"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -234,7 +235,7 @@ func equalDataPoints[N int64 | float64](a, b metricdata.DataPoint[N], cfg config
 		}
 	}
 
-	if a.Value != b.Value {
+	if !cfg.ignoreValue && !equalValues(a.Value, b.Value, cfg) {
 		reasons = append(reasons, notEqualStr("Value", a.Value, b.Value))
 	}
 	return reasons
@@ -258,27 +259,48 @@ func equalHistogramDataPoints(a, b metricdata.HistogramDataPoint, cfg config) (r
 			reasons = append(reasons, notEqualStr("Time", a.Time.UnixNano(), b.Time.UnixNano()))
 		}
 	}
-	if a.Count != b.Count {
-		reasons = append(reasons, notEqualStr("Count", a.Count, b.Count))
-	}
 	if !equalSlices(a.Bounds, b.Bounds) {
 		reasons = append(reasons, notEqualStr("Bounds", a.Bounds, b.Bounds))
 	}
+	if cfg.ignoreValue {
+		return reasons
+	}
+	if a.Count != b.Count {
+		reasons = append(reasons, notEqualStr("Count", a.Count, b.Count))
+	}
 	if !equalSlices(a.BucketCounts, b.BucketCounts) {
 		reasons = append(reasons, notEqualStr("BucketCounts", a.BucketCounts, b.BucketCounts))
 	}
-	if !equalPtrValues(a.Min, b.Min) {
+	if !equalFloatPtrs(a.Min, b.Min, cfg) {
 		reasons = append(reasons, notEqualStr("Min", a.Min, b.Min))
 	}
-	if !equalPtrValues(a.Max, b.Max) {
+	if !equalFloatPtrs(a.Max, b.Max, cfg) {
 		reasons = append(reasons, notEqualStr("Max", a.Max, b.Max))
 	}
-	if a.Sum != b.Sum {
+	if !equalValues(a.Sum, b.Sum, cfg) {
 		reasons = append(reasons, notEqualStr("Sum", a.Sum, b.Sum))
 	}
 	return reasons
 }
 
+// equalValues returns if a and b are equal. Float64 values are compared
+// using the configured epsilon.
+func equalValues[N int64 | float64](a, b N, cfg config) bool {
+	if _, ok := interface{}(a).(float64); ok && cfg.floatEpsilon > 0 {
+		return math.Abs(float64(a)-float64(b)) <= cfg.floatEpsilon
+	}
+	return a == b
+}
+
+// equalFloatPtrs returns if the values a and b point to are equal using the
+// configured epsilon. Two nil pointers are equal.
+func equalFloatPtrs(a, b *float64, cfg config) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalValues(*a, *b, cfg)
+}
+
 func notEqualStr(prefix string, expected, actual interface{}) string {
 	return fmt.Sprintf("%s not equal:\nexpected: %v\nactual: %v", prefix, expected, actual)
 }
@@ -295,14 +317,6 @@ func equalSlices[T comparable](a, b []T) bool {
 	return true
 }
 
-func equalPtrValues[T comparable](a, b *T) bool {
-	if a == nil || b == nil {
-		return a == b
-	}
-
-	return *a == *b
-}
-
 func diffSlices[T any](a, b []T, equal func(T, T) bool) (extraA, extraB []T) {
 	visited := make([]bool, len(b))
 	for i := 0; i < len(a); i++ {
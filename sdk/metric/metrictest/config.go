@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// config contains configuration options for an Exporter.
+type config struct {
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+}
+
+// newConfig creates a validated config configured with options.
+func newConfig(options []Option) config {
+	cfg := config{
+		temporalitySelector: metric.DefaultTemporalitySelector,
+		aggregationSelector: metric.DefaultAggregationSelector,
+	}
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option sets exporter option values.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (o optionFunc) apply(c config) config {
+	return o(c)
+}
+
+// WithTemporalitySelector sets the TemporalitySelector the Exporter will use
+// to determine the Temporality of an instrument based on its kind. If this
+// option is not used, the Exporter will use the DefaultTemporalitySelector
+// from the go.opentelemetry.io/otel/sdk/metric package.
+func WithTemporalitySelector(selector metric.TemporalitySelector) Option {
+	return optionFunc(func(c config) config {
+		if selector != nil {
+			c.temporalitySelector = selector
+		}
+		return c
+	})
+}
+
+// WithAggregationSelector sets the AggregationSelector the Exporter will use
+// to determine the aggregation to use for an instrument based on its kind. If
+// this option is not used, the Exporter will use the
+// DefaultAggregationSelector from the go.opentelemetry.io/otel/sdk/metric
+// package or the aggregation explicitly passed for a view matching an
+// instrument.
+func WithAggregationSelector(selector metric.AggregationSelector) Option {
+	return optionFunc(func(c config) config {
+		if selector != nil {
+			c.aggregationSelector = selector
+		}
+		return c
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import "go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+// copyResourceMetrics returns a deep copy of rm so recorded data is not
+// affected by any later reuse of the original by its producer.
+func copyResourceMetrics(rm metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	out := metricdata.ResourceMetrics{Resource: rm.Resource}
+	if rm.ScopeMetrics == nil {
+		return out
+	}
+	out.ScopeMetrics = make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics))
+	for i, sm := range rm.ScopeMetrics {
+		out.ScopeMetrics[i].Scope = sm.Scope
+		if sm.Metrics == nil {
+			continue
+		}
+		out.ScopeMetrics[i].Metrics = make([]metricdata.Metrics, len(sm.Metrics))
+		for j, m := range sm.Metrics {
+			out.ScopeMetrics[i].Metrics[j] = metricdata.Metrics{
+				Name:        m.Name,
+				Description: m.Description,
+				Unit:        m.Unit,
+				Data:        copyAggregation(m.Data),
+			}
+		}
+	}
+	return out
+}
+
+func copyAggregation(a metricdata.Aggregation) metricdata.Aggregation {
+	switch v := a.(type) {
+	case metricdata.Gauge[int64]:
+		v.DataPoints = copySlice(v.DataPoints)
+		return v
+	case metricdata.Gauge[float64]:
+		v.DataPoints = copySlice(v.DataPoints)
+		return v
+	case metricdata.Sum[int64]:
+		v.DataPoints = copySlice(v.DataPoints)
+		return v
+	case metricdata.Sum[float64]:
+		v.DataPoints = copySlice(v.DataPoints)
+		return v
+	case metricdata.Histogram:
+		v.DataPoints = copySlice(v.DataPoints)
+		for i, dp := range v.DataPoints {
+			v.DataPoints[i].Bounds = copySlice(dp.Bounds)
+			v.DataPoints[i].BucketCounts = copySlice(dp.BucketCounts)
+			v.DataPoints[i].Min = copyPtr(dp.Min)
+			v.DataPoints[i].Max = copyPtr(dp.Max)
+		}
+		return v
+	}
+	// Unknown aggregations are returned as is.
+	return a
+}
+
+func copySlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	copy(out, s)
+	return out
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
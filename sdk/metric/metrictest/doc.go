@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrictest is a testing helper package for the metric SDK. It
+// provides an in-memory Exporter and Reader that record collected metric data
+// so it can be verified by tests of the SDK or of custom instrumentation.
+//
+// The recorded data can be compared with the assertions provided by the
+// go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest package.
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+var _ metric.Exporter = (*Exporter)(nil)
+
+// Exporter is a metric exporter that stores all exported ResourceMetrics
+// in-memory.
+type Exporter struct {
+	temporalitySelector metric.TemporalitySelector
+	aggregationSelector metric.AggregationSelector
+
+	mu       sync.Mutex
+	metrics  []metricdata.ResourceMetrics
+	shutdown bool
+}
+
+// NewExporter returns a new Exporter.
+func NewExporter(opts ...Option) *Exporter {
+	cfg := newConfig(opts)
+	return &Exporter{
+		temporalitySelector: cfg.temporalitySelector,
+		aggregationSelector: cfg.aggregationSelector,
+	}
+}
+
+// Temporality returns the Temporality to use for an instrument kind.
+func (e *Exporter) Temporality(k metric.InstrumentKind) metricdata.Temporality {
+	return e.temporalitySelector(k)
+}
+
+// Aggregation returns the Aggregation to use for an instrument kind.
+func (e *Exporter) Aggregation(k metric.InstrumentKind) aggregation.Aggregation {
+	return e.aggregationSelector(k)
+}
+
+// Export stores a copy of data in memory. An error is returned if the
+// Exporter has been shut down.
+func (e *Exporter) Export(ctx context.Context, data metricdata.ResourceMetrics) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.shutdown {
+		return metric.ErrExporterShutdown
+	}
+	e.metrics = append(e.metrics, copyResourceMetrics(data))
+	return nil
+}
+
+// ForceFlush does nothing, the Exporter holds no state that needs flushing.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Shutdown stops the exporter by clearing the ResourceMetrics held in memory.
+// Any subsequent call to Export will return an error.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	e.metrics = nil
+	return ctx.Err()
+}
+
+// Reset the current in-memory storage.
+func (e *Exporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.metrics = nil
+}
+
+// GetMetrics returns the ResourceMetrics currently stored in memory, in the
+// order they were exported.
+func (e *Exporter) GetMetrics() []metricdata.ResourceMetrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ret := make([]metricdata.ResourceMetrics, len(e.metrics))
+	copy(ret, e.metrics)
+	return ret
+}
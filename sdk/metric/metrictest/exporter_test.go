@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+var (
+	testScope = instrumentation.Scope{Name: "metrictest"}
+
+	testHistogram = metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: testScope,
+			Metrics: []metricdata.Metrics{{
+				Name: "histogram",
+				Data: metricdata.Histogram{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints: []metricdata.HistogramDataPoint{{
+						Count:        1,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{0, 1},
+						Sum:          2,
+					}},
+				},
+			}},
+		}},
+	}
+)
+
+func TestExporterExport(t *testing.T) {
+	exp := NewExporter()
+	ctx := context.Background()
+
+	rm := copyResourceMetrics(testHistogram)
+	require.NoError(t, exp.Export(ctx, rm))
+
+	// Mutating the exported data must not change what was recorded.
+	h := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram)
+	h.DataPoints[0].BucketCounts[1] = 10
+
+	got := exp.GetMetrics()
+	require.Len(t, got, 1)
+	metricdatatest.AssertEqual(t, testHistogram, got[0])
+
+	exp.Reset()
+	assert.Len(t, exp.GetMetrics(), 0)
+}
+
+func TestExporterShutdown(t *testing.T) {
+	exp := NewExporter()
+	ctx := context.Background()
+
+	require.NoError(t, exp.Export(ctx, testHistogram))
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Len(t, exp.GetMetrics(), 0)
+	assert.ErrorIs(t, exp.Export(ctx, testHistogram), metric.ErrExporterShutdown)
+}
+
+func TestExporterSelectors(t *testing.T) {
+	exp := NewExporter()
+	assert.Equal(t, metric.DefaultTemporalitySelector(metric.InstrumentKindCounter), exp.Temporality(metric.InstrumentKindCounter))
+	assert.Equal(t, metric.DefaultAggregationSelector(metric.InstrumentKindCounter), exp.Aggregation(metric.InstrumentKindCounter))
+
+	exp = NewExporter(
+		WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}),
+		WithAggregationSelector(func(metric.InstrumentKind) aggregation.Aggregation {
+			return aggregation.Drop{}
+		}),
+	)
+	assert.Equal(t, metricdata.DeltaTemporality, exp.Temporality(metric.InstrumentKindCounter))
+	assert.Equal(t, aggregation.Drop{}, exp.Aggregation(metric.InstrumentKindCounter))
+}
+
+func TestExporterWithPeriodicReader(t *testing.T) {
+	exp := NewExporter()
+	r := metric.NewPeriodicReader(exp)
+	mp := metric.NewMeterProvider(metric.WithReader(r))
+	ctr, err := mp.Meter(testScope.Name).Int64Counter("requests")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ctr.Add(ctx, 3)
+	require.NoError(t, r.ForceFlush(ctx))
+
+	got := exp.GetMetrics()
+	require.Len(t, got, 1)
+	m, ok := FindMetric(got[0], testScope, "requests")
+	require.True(t, ok)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(3), sum.DataPoints[0].Value)
+
+	require.NoError(t, mp.Shutdown(ctx))
+}
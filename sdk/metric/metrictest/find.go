@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// FindScopeMetrics returns the ScopeMetrics in rm produced by scope and true.
+// If no such ScopeMetrics exist, false is returned.
+func FindScopeMetrics(rm metricdata.ResourceMetrics, scope instrumentation.Scope) (metricdata.ScopeMetrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope == scope {
+			return sm, true
+		}
+	}
+	return metricdata.ScopeMetrics{}, false
+}
+
+// FindMetric returns the Metrics named name that were produced by scope in
+// rm and true. If no such Metrics exist, false is returned.
+func FindMetric(rm metricdata.ResourceMetrics, scope instrumentation.Scope, name string) (metricdata.Metrics, bool) {
+	sm, ok := FindScopeMetrics(rm, scope)
+	if !ok {
+		return metricdata.Metrics{}, false
+	}
+	for _, m := range sm.Metrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return metricdata.Metrics{}, false
+}
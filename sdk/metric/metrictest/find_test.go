@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+func TestFindMetric(t *testing.T) {
+	sm, ok := FindScopeMetrics(testHistogram, testScope)
+	assert.True(t, ok)
+	assert.Equal(t, testScope, sm.Scope)
+
+	m, ok := FindMetric(testHistogram, testScope, "histogram")
+	assert.True(t, ok)
+	assert.Equal(t, "histogram", m.Name)
+
+	_, ok = FindMetric(testHistogram, testScope, "unknown")
+	assert.False(t, ok)
+
+	other := instrumentation.Scope{Name: "other"}
+	_, ok = FindScopeMetrics(testHistogram, other)
+	assert.False(t, ok)
+	_, ok = FindMetric(testHistogram, other, "histogram")
+	assert.False(t, ok)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest // import "go.opentelemetry.io/otel/sdk/metric/metrictest"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Reader is a metric.Reader that records the result of every successful
+// Collect call in-memory.
+type Reader struct {
+	metric.Reader
+
+	mu          sync.Mutex
+	collections []metricdata.ResourceMetrics
+}
+
+// NewReader returns a new Reader backed by a manual reader configured with
+// opts.
+func NewReader(opts ...metric.ManualReaderOption) *Reader {
+	return &Reader{Reader: metric.NewManualReader(opts...)}
+}
+
+// Collect gathers all metric data from the SDK, records a copy of it, and
+// returns it.
+func (r *Reader) Collect(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	rm, err := r.Reader.Collect(ctx)
+	if err != nil {
+		return rm, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collections = append(r.collections, copyResourceMetrics(rm))
+	return rm, nil
+}
+
+// Collections returns the ResourceMetrics recorded from all Collect calls
+// since the Reader was created or last Reset, in the order they were
+// collected.
+func (r *Reader) Collections() []metricdata.ResourceMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]metricdata.ResourceMetrics, len(r.collections))
+	copy(ret, r.collections)
+	return ret
+}
+
+// Reset the recorded collections.
+func (r *Reader) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collections = nil
+}
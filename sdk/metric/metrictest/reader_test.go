@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestReaderRecordsCollections(t *testing.T) {
+	r := NewReader(metric.WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}))
+	mp := metric.NewMeterProvider(metric.WithReader(r))
+	ctr, err := mp.Meter(testScope.Name).Int64Counter("requests")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ctr.Add(ctx, 3)
+	_, err = r.Collect(ctx)
+	require.NoError(t, err)
+	ctr.Add(ctx, 2)
+	_, err = r.Collect(ctx)
+	require.NoError(t, err)
+
+	got := r.Collections()
+	require.Len(t, got, 2)
+	for i, want := range []int64{3, 2} {
+		m, ok := FindMetric(got[i], testScope, "requests")
+		require.True(t, ok)
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, want, sum.DataPoints[0].Value, "collection %d", i)
+	}
+
+	r.Reset()
+	assert.Len(t, r.Collections(), 0)
+
+	require.NoError(t, mp.Shutdown(ctx))
+	_, err = r.Collect(ctx)
+	assert.ErrorIs(t, err, metric.ErrReaderShutdown)
+	assert.Len(t, r.Collections(), 0, "failed collections should not be recorded")
+}
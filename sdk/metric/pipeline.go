@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/internal/observ"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/unit"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -61,6 +63,7 @@ func newPipeline(res *resource.Resource, reader Reader, views []View) *pipeline
 		reader:       reader,
 		views:        views,
 		aggregations: make(map[instrumentation.Scope][]instrumentSync),
+		observ:       observ.GetProvider().Pipeline(),
 	}
 }
 
@@ -79,6 +82,8 @@ type pipeline struct {
 	aggregations   map[instrumentation.Scope][]instrumentSync
 	callbacks      []func(context.Context) error
 	multiCallbacks list.List
+
+	observ observ.Pipeline
 }
 
 // addSync adds the instrumentSync to pipeline p with scope. This method is not
@@ -128,9 +133,16 @@ const produceKey callbackKey = 0
 // produce returns aggregated metrics from a single collection.
 //
 // This method is safe to call concurrently.
-func (p *pipeline) produce(ctx context.Context) (metricdata.ResourceMetrics, error) {
+func (p *pipeline) produce(ctx context.Context) (rm metricdata.ResourceMetrics, err error) {
 	ctx = context.WithValue(ctx, produceKey, struct{}{})
 
+	if p.observ != nil {
+		// Deferred before locking so the collection is reported after p is
+		// unlocked.
+		start := time.Now()
+		defer func() { p.observ.Collected(ctx, time.Since(start), err) }()
+	}
+
 	p.Lock()
 	defer p.Unlock()
 
@@ -139,6 +151,9 @@ func (p *pipeline) produce(ctx context.Context) (metricdata.ResourceMetrics, err
 		// TODO make the callbacks parallel. ( #3034 )
 		if err := c(ctx); err != nil {
 			errs.append(err)
+			if p.observ != nil {
+				p.observ.CallbackError(ctx)
+			}
 		}
 		if err := ctx.Err(); err != nil {
 			return metricdata.ResourceMetrics{}, err
@@ -460,6 +475,7 @@ func newPipelines(res *resource.Resource, readers []Reader, views []View) pipeli
 			resource: res,
 			reader:   r,
 			views:    views,
+			observ:   observ.GetProvider().Pipeline(),
 		}
 		r.register(p)
 		pipes = append(pipes, p)
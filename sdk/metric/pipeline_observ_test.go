@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/internal/observ/observtest"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+func TestPipelineObserv(t *testing.T) {
+	r := observtest.Install(t)
+
+	rdr := NewManualReader()
+	mp := NewMeterProvider(WithReader(rdr))
+
+	errCallback := errors.New("callback failed")
+	_, err := mp.Meter("test").Int64ObservableGauge(
+		"gauge",
+		instrument.WithInt64Callback(func(context.Context, instrument.Int64Observer) error {
+			return errCallback
+		}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = rdr.Collect(ctx)
+	assert.ErrorContains(t, err, errCallback.Error())
+	_, err = rdr.Collect(ctx)
+	assert.ErrorContains(t, err, errCallback.Error())
+
+	assert.Equal(t, observtest.Counts{
+		CollectionFailed: 2,
+		CallbackErrors:   2,
+	}, r.Counts())
+}
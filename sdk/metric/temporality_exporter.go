@@ -0,0 +1,401 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// defaultIdleStreamTimeout is the default time a stream is retained by a
+// temporality converting Exporter without receiving new data.
+const defaultIdleStreamTimeout = 5 * time.Minute
+
+// now returns the current time. It is a variable so tests can override it.
+var now = time.Now
+
+// temporalityExporterConfig contains configuration options for a temporality
+// converting Exporter.
+type temporalityExporterConfig struct {
+	inputTemporality  TemporalitySelector
+	idleStreamTimeout time.Duration
+}
+
+// newTemporalityExporterConfig returns a temporalityExporterConfig configured
+// with options. The input defaults to the opposite of target.
+func newTemporalityExporterConfig(target metricdata.Temporality, options []TemporalityExporterOption) temporalityExporterConfig {
+	input := metricdata.CumulativeTemporality
+	if target == metricdata.CumulativeTemporality {
+		input = metricdata.DeltaTemporality
+	}
+	c := temporalityExporterConfig{
+		inputTemporality:  func(InstrumentKind) metricdata.Temporality { return input },
+		idleStreamTimeout: defaultIdleStreamTimeout,
+	}
+	for _, o := range options {
+		c = o.applyTemporalityExporter(c)
+	}
+	return c
+}
+
+// TemporalityExporterOption applies a configuration option value to an
+// Exporter returned from NewDeltaExporter or NewCumulativeExporter.
+type TemporalityExporterOption interface {
+	applyTemporalityExporter(temporalityExporterConfig) temporalityExporterConfig
+}
+
+// temporalityExporterOptionFunc applies a set of options to a
+// temporalityExporterConfig.
+type temporalityExporterOptionFunc func(temporalityExporterConfig) temporalityExporterConfig
+
+// applyTemporalityExporter returns a temporalityExporterConfig with
+// option(s) applied.
+func (o temporalityExporterOptionFunc) applyTemporalityExporter(conf temporalityExporterConfig) temporalityExporterConfig {
+	return o(conf)
+}
+
+// WithInputTemporality sets the TemporalitySelector the converting Exporter
+// reports to the Reader it is used with. This determines the Temporality of
+// the data the Exporter receives. Data that already has the target
+// Temporality is passed through unchanged.
+//
+// If this option is not used, a NewDeltaExporter will request
+// CumulativeTemporality and a NewCumulativeExporter will request
+// DeltaTemporality for all instrument kinds.
+func WithInputTemporality(selector TemporalitySelector) TemporalityExporterOption {
+	return temporalityExporterOptionFunc(func(conf temporalityExporterConfig) temporalityExporterConfig {
+		if selector != nil {
+			conf.inputTemporality = selector
+		}
+		return conf
+	})
+}
+
+// WithIdleStreamTimeout configures how long a converting Exporter retains the
+// state of a stream that has not received any new data. Once evicted, the
+// next data point of a stream is treated as the start of a new stream.
+//
+// If this option is not used or d is less than or equal to zero, 5 minutes
+// is used as the default.
+func WithIdleStreamTimeout(d time.Duration) TemporalityExporterOption {
+	return temporalityExporterOptionFunc(func(conf temporalityExporterConfig) temporalityExporterConfig {
+		if d <= 0 {
+			return conf
+		}
+		conf.idleStreamTimeout = d
+		return conf
+	})
+}
+
+// NewDeltaExporter returns an Exporter that converts cumulative Sum and
+// Histogram data into DeltaTemporality before passing it to exporter.
+//
+// The returned Exporter keeps the last cumulative value of each stream to
+// compute the next delta. A change in the StartTime of a stream, a decrease
+// in value of a monotonic Sum, or a change in Histogram bounds is treated as
+// a reset of the stream. Because the minimum and maximum value of a
+// cumulative Histogram cannot be converted into their delta counterparts,
+// they are dropped unless the stream was reset.
+func NewDeltaExporter(exporter Exporter, options ...TemporalityExporterOption) Exporter {
+	return newTemporalityExporter(exporter, metricdata.DeltaTemporality, options)
+}
+
+// NewCumulativeExporter returns an Exporter that converts delta Sum and
+// Histogram data into CumulativeTemporality before passing it to exporter.
+//
+// The returned Exporter accumulates the delta values of each stream. A delta
+// data point that starts before the end of the last accumulated data point,
+// or a change in Histogram bounds, is treated as a reset of the stream.
+func NewCumulativeExporter(exporter Exporter, options ...TemporalityExporterOption) Exporter {
+	return newTemporalityExporter(exporter, metricdata.CumulativeTemporality, options)
+}
+
+func newTemporalityExporter(exporter Exporter, target metricdata.Temporality, options []TemporalityExporterOption) *temporalityExporter {
+	conf := newTemporalityExporterConfig(target, options)
+	return &temporalityExporter{
+		exporter:          exporter,
+		target:            target,
+		inputTemporality:  conf.inputTemporality,
+		idleStreamTimeout: conf.idleStreamTimeout,
+		streams:           make(map[streamID]*streamState),
+	}
+}
+
+// temporalityExporter is an Exporter that converts the Temporality of the
+// data it exports.
+type temporalityExporter struct {
+	exporter          Exporter
+	target            metricdata.Temporality
+	inputTemporality  TemporalitySelector
+	idleStreamTimeout time.Duration
+
+	mu      sync.Mutex
+	streams map[streamID]*streamState
+}
+
+var _ Exporter = (*temporalityExporter)(nil)
+
+// streamID uniquely identifies a timeseries of a temporalityExporter.
+type streamID struct {
+	scope instrumentation.Scope
+	name  string
+	unit  unit.Unit
+	attrs attribute.Distinct
+}
+
+// streamState is the last known state of a timeseries.
+type streamState struct {
+	// start is the start time of the cumulative value.
+	start time.Time
+	// last is the end time of the last data point seen.
+	last time.Time
+	// seen is when the stream was last updated.
+	seen time.Time
+
+	// value is the last cumulative value of a Sum. It is either an int64 or
+	// a float64.
+	value interface{}
+	// hist is the last cumulative value of a Histogram.
+	hist *metricdata.HistogramDataPoint
+}
+
+// Temporality returns the Temporality the exporter requests for the
+// instrument kind. This is the configured input Temporality, not the
+// Temporality of the wrapped exporter.
+func (e *temporalityExporter) Temporality(k InstrumentKind) metricdata.Temporality {
+	return e.inputTemporality(k)
+}
+
+// Aggregation returns the Aggregation of the wrapped exporter.
+func (e *temporalityExporter) Aggregation(k InstrumentKind) aggregation.Aggregation {
+	return e.exporter.Aggregation(k)
+}
+
+// Export converts the Temporality of all Sum and Histogram data in rm and
+// exports the result with the wrapped exporter. The passed rm is not
+// modified.
+func (e *temporalityExporter) Export(ctx context.Context, rm metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	out := e.convert(rm)
+	e.evict()
+	e.mu.Unlock()
+
+	return e.exporter.Export(ctx, out)
+}
+
+// ForceFlush flushes the wrapped exporter.
+func (e *temporalityExporter) ForceFlush(ctx context.Context) error {
+	return e.exporter.ForceFlush(ctx)
+}
+
+// Shutdown releases all stream state and shuts down the wrapped exporter.
+func (e *temporalityExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	e.streams = make(map[streamID]*streamState)
+	e.mu.Unlock()
+
+	return e.exporter.Shutdown(ctx)
+}
+
+// evict removes all streams that have been idle longer than the configured
+// timeout.
+func (e *temporalityExporter) evict() {
+	cutoff := now().Add(-e.idleStreamTimeout)
+	for id, s := range e.streams {
+		if s.seen.Before(cutoff) {
+			delete(e.streams, id)
+		}
+	}
+}
+
+// convert returns a copy of rm with all Sum and Histogram data converted to
+// the target Temporality.
+func (e *temporalityExporter) convert(rm metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	out := metricdata.ResourceMetrics{
+		Resource:     rm.Resource,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, len(rm.ScopeMetrics)),
+	}
+	seen := now()
+	for i, sm := range rm.ScopeMetrics {
+		out.ScopeMetrics[i] = metricdata.ScopeMetrics{
+			Scope:   sm.Scope,
+			Metrics: make([]metricdata.Metrics, len(sm.Metrics)),
+		}
+		for j, m := range sm.Metrics {
+			id := streamID{scope: sm.Scope, name: m.Name, unit: m.Unit}
+			cp := m
+			switch a := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				cp.Data = convertSum(e, id, seen, a)
+			case metricdata.Sum[float64]:
+				cp.Data = convertSum(e, id, seen, a)
+			case metricdata.Histogram:
+				cp.Data = e.convertHistogram(id, seen, a)
+			}
+			out.ScopeMetrics[i].Metrics[j] = cp
+		}
+	}
+	return out
+}
+
+// convertSum returns a copy of s in the target Temporality of e.
+func convertSum[N int64 | float64](e *temporalityExporter, id streamID, seen time.Time, s metricdata.Sum[N]) metricdata.Sum[N] {
+	if s.Temporality == e.target {
+		return s
+	}
+
+	out := metricdata.Sum[N]{
+		DataPoints:  make([]metricdata.DataPoint[N], len(s.DataPoints)),
+		Temporality: e.target,
+		IsMonotonic: s.IsMonotonic,
+	}
+	for i, dp := range s.DataPoints {
+		id.attrs = dp.Attributes.Equivalent()
+		prev, ok := e.streams[id]
+		var prevVal N
+		if ok {
+			// A stream of a different number type is not the same stream.
+			prevVal, ok = prev.value.(N)
+		}
+
+		cp := dp
+		state := &streamState{last: dp.Time, seen: seen}
+		switch e.target {
+		case metricdata.DeltaTemporality:
+			reset := !ok || !prev.start.Equal(dp.StartTime) || (s.IsMonotonic && dp.Value < prevVal)
+			if !reset {
+				cp.StartTime = prev.last
+				cp.Value = dp.Value - prevVal
+			}
+			state.start = dp.StartTime
+			state.value = dp.Value
+		case metricdata.CumulativeTemporality:
+			reset := !ok || dp.StartTime.Before(prev.last)
+			if !reset {
+				cp.StartTime = prev.start
+				cp.Value = prevVal + dp.Value
+			}
+			state.start = cp.StartTime
+			state.value = cp.Value
+		}
+		e.streams[id] = state
+		out.DataPoints[i] = cp
+	}
+	return out
+}
+
+// convertHistogram returns a copy of h in the target Temporality of e.
+func (e *temporalityExporter) convertHistogram(id streamID, seen time.Time, h metricdata.Histogram) metricdata.Histogram {
+	if h.Temporality == e.target {
+		return h
+	}
+
+	out := metricdata.Histogram{
+		DataPoints:  make([]metricdata.HistogramDataPoint, len(h.DataPoints)),
+		Temporality: e.target,
+	}
+	for i, dp := range h.DataPoints {
+		id.attrs = dp.Attributes.Equivalent()
+		prev, ok := e.streams[id]
+		if ok {
+			ok = prev.hist != nil && equalBounds(prev.hist.Bounds, dp.Bounds)
+		}
+
+		cp := copyHistogramDataPoint(dp)
+		state := &streamState{last: dp.Time, seen: seen}
+		switch e.target {
+		case metricdata.DeltaTemporality:
+			reset := !ok || !prev.start.Equal(dp.StartTime) || dp.Count < prev.hist.Count
+			if !reset {
+				cp.StartTime = prev.last
+				cp.Count -= prev.hist.Count
+				cp.Sum -= prev.hist.Sum
+				for j := range cp.BucketCounts {
+					cp.BucketCounts[j] -= prev.hist.BucketCounts[j]
+				}
+				// The extrema of the delta interval are unknown.
+				cp.Min, cp.Max = nil, nil
+			}
+			state.start = dp.StartTime
+			state.hist = copyHistogramDataPoint(dp)
+		case metricdata.CumulativeTemporality:
+			reset := !ok || dp.StartTime.Before(prev.last)
+			if !reset {
+				cp.StartTime = prev.start
+				cp.Count += prev.hist.Count
+				cp.Sum += prev.hist.Sum
+				for j := range cp.BucketCounts {
+					cp.BucketCounts[j] += prev.hist.BucketCounts[j]
+				}
+				cp.Min = minPtr(prev.hist.Min, dp.Min, func(a, b float64) bool { return a < b })
+				cp.Max = minPtr(prev.hist.Max, dp.Max, func(a, b float64) bool { return a > b })
+			}
+			state.start = cp.StartTime
+			state.hist = copyHistogramDataPoint(*cp)
+		}
+		e.streams[id] = state
+		out.DataPoints[i] = *cp
+	}
+	return out
+}
+
+// copyHistogramDataPoint returns a deep copy of dp.
+func copyHistogramDataPoint(dp metricdata.HistogramDataPoint) *metricdata.HistogramDataPoint {
+	cp := dp
+	cp.Bounds = append([]float64(nil), dp.Bounds...)
+	cp.BucketCounts = append([]uint64(nil), dp.BucketCounts...)
+	if dp.Min != nil {
+		v := *dp.Min
+		cp.Min = &v
+	}
+	if dp.Max != nil {
+		v := *dp.Max
+		cp.Max = &v
+	}
+	return &cp
+}
+
+// minPtr returns a pointer to the lesser of a and b according to less. If
+// either is nil, nil is returned.
+func minPtr(a, b *float64, less func(float64, float64) bool) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	v := *b
+	if less(*a, *b) {
+		v = *a
+	}
+	return &v
+}
+
+// equalBounds returns if a and b contain the same boundaries.
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
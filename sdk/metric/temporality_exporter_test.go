@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+var (
+	t0 = time.Unix(0, 0)
+	t1 = t0.Add(time.Second)
+	t2 = t1.Add(time.Second)
+	t3 = t2.Add(time.Second)
+
+	tempAttrs = attribute.NewSet(attribute.String("user", "alice"))
+	tempScope = instrumentation.Scope{Name: "temporality"}
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func sumRM(temporality metricdata.Temporality, mono bool, dps ...metricdata.DataPoint[int64]) metricdata.ResourceMetrics {
+	return metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: tempScope,
+			Metrics: []metricdata.Metrics{{
+				Name: "sum",
+				Data: metricdata.Sum[int64]{
+					DataPoints:  dps,
+					Temporality: temporality,
+					IsMonotonic: mono,
+				},
+			}},
+		}},
+	}
+}
+
+func histRM(temporality metricdata.Temporality, dps ...metricdata.HistogramDataPoint) metricdata.ResourceMetrics {
+	return metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: tempScope,
+			Metrics: []metricdata.Metrics{{
+				Name: "histogram",
+				Data: metricdata.Histogram{
+					DataPoints:  dps,
+					Temporality: temporality,
+				},
+			}},
+		}},
+	}
+}
+
+func intDP(start, end time.Time, v int64) metricdata.DataPoint[int64] {
+	return metricdata.DataPoint[int64]{Attributes: tempAttrs, StartTime: start, Time: end, Value: v}
+}
+
+func histDP(start, end time.Time, count uint64, sum float64, counts []uint64, min, max float64) metricdata.HistogramDataPoint {
+	return metricdata.HistogramDataPoint{
+		Attributes:   tempAttrs,
+		StartTime:    start,
+		Time:         end,
+		Count:        count,
+		Bounds:       []float64{1, 5},
+		BucketCounts: counts,
+		Min:          float64Ptr(min),
+		Max:          float64Ptr(max),
+		Sum:          sum,
+	}
+}
+
+// recordingExporter returns an Exporter that stores the last exported
+// ResourceMetrics in got.
+func recordingExporter(got *metricdata.ResourceMetrics) *fnExporter {
+	return &fnExporter{
+		exportFunc: func(_ context.Context, rm metricdata.ResourceMetrics) error {
+			*got = rm
+			return nil
+		},
+	}
+}
+
+func TestTemporalityExporterDefaultInput(t *testing.T) {
+	delta := NewDeltaExporter(new(fnExporter))
+	assert.Equal(t, metricdata.CumulativeTemporality, delta.Temporality(InstrumentKindCounter))
+
+	cumulative := NewCumulativeExporter(new(fnExporter))
+	assert.Equal(t, metricdata.DeltaTemporality, cumulative.Temporality(InstrumentKindCounter))
+
+	sel := func(InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality }
+	delta = NewDeltaExporter(new(fnExporter), WithInputTemporality(sel))
+	assert.Equal(t, metricdata.DeltaTemporality, delta.Temporality(InstrumentKindHistogram))
+}
+
+func TestWithIdleStreamTimeout(t *testing.T) {
+	test := func(d time.Duration) time.Duration {
+		opts := []TemporalityExporterOption{WithIdleStreamTimeout(d)}
+		return newTemporalityExporterConfig(metricdata.DeltaTemporality, opts).idleStreamTimeout
+	}
+
+	assert.Equal(t, testDur, test(testDur))
+	assert.Equal(t, defaultIdleStreamTimeout, newTemporalityExporterConfig(metricdata.DeltaTemporality, nil).idleStreamTimeout)
+	assert.Equal(t, defaultIdleStreamTimeout, test(time.Duration(0)), "invalid timeout should use default")
+	assert.Equal(t, defaultIdleStreamTimeout, test(time.Duration(-1)), "invalid timeout should use default")
+}
+
+func TestDeltaExporterSum(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewDeltaExporter(recordingExporter(&got))
+	ctx := context.Background()
+
+	steps := []struct {
+		name string
+		in   metricdata.DataPoint[int64]
+		want metricdata.DataPoint[int64]
+	}{
+		{"first", intDP(t0, t1, 5), intDP(t0, t1, 5)},
+		{"increase", intDP(t0, t2, 8), intDP(t1, t2, 3)},
+		{"decrease resets", intDP(t0, t3, 2), intDP(t0, t3, 2)},
+		{"start time resets", intDP(t2, t3, 4), intDP(t2, t3, 4)},
+	}
+	for _, s := range steps {
+		require.NoError(t, exp.Export(ctx, sumRM(metricdata.CumulativeTemporality, true, s.in)), s.name)
+		metricdatatest.AssertEqual(t, sumRM(metricdata.DeltaTemporality, true, s.want), got)
+	}
+}
+
+func TestCumulativeExporterSum(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewCumulativeExporter(recordingExporter(&got))
+	ctx := context.Background()
+
+	steps := []struct {
+		name string
+		in   metricdata.DataPoint[int64]
+		want metricdata.DataPoint[int64]
+	}{
+		{"first", intDP(t0, t1, 5), intDP(t0, t1, 5)},
+		{"accumulate", intDP(t1, t2, -3), intDP(t0, t2, 2)},
+		{"overlap resets", intDP(t1, t3, 4), intDP(t1, t3, 4)},
+	}
+	for _, s := range steps {
+		require.NoError(t, exp.Export(ctx, sumRM(metricdata.DeltaTemporality, false, s.in)), s.name)
+		metricdatatest.AssertEqual(t, sumRM(metricdata.CumulativeTemporality, false, s.want), got)
+	}
+}
+
+func TestTemporalityExporterPassThrough(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewDeltaExporter(recordingExporter(&got))
+
+	in := sumRM(metricdata.DeltaTemporality, true, intDP(t0, t1, 5))
+	require.NoError(t, exp.Export(context.Background(), in))
+	metricdatatest.AssertEqual(t, in, got)
+}
+
+func TestDeltaExporterHistogram(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewDeltaExporter(recordingExporter(&got))
+	ctx := context.Background()
+
+	in := histDP(t0, t1, 2, 7, []uint64{1, 0, 1}, 1, 6)
+	require.NoError(t, exp.Export(ctx, histRM(metricdata.CumulativeTemporality, in)))
+	metricdatatest.AssertEqual(t, histRM(metricdata.DeltaTemporality, in), got)
+
+	in = histDP(t0, t2, 5, 10, []uint64{2, 2, 1}, 1, 6)
+	require.NoError(t, exp.Export(ctx, histRM(metricdata.CumulativeTemporality, in)))
+	want := histDP(t1, t2, 3, 3, []uint64{1, 2, 0}, 0, 0)
+	want.Min, want.Max = nil, nil
+	metricdatatest.AssertEqual(t, histRM(metricdata.DeltaTemporality, want), got)
+
+	// The input must not be modified.
+	assert.Equal(t, []uint64{2, 2, 1}, in.BucketCounts)
+}
+
+func TestCumulativeExporterHistogram(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewCumulativeExporter(recordingExporter(&got))
+	ctx := context.Background()
+
+	in := histDP(t0, t1, 2, 7, []uint64{1, 0, 1}, 1, 6)
+	require.NoError(t, exp.Export(ctx, histRM(metricdata.DeltaTemporality, in)))
+	metricdatatest.AssertEqual(t, histRM(metricdata.CumulativeTemporality, in), got)
+
+	in = histDP(t1, t2, 3, 10, []uint64{0, 2, 1}, 2, 8)
+	require.NoError(t, exp.Export(ctx, histRM(metricdata.DeltaTemporality, in)))
+	want := histDP(t0, t2, 5, 17, []uint64{1, 2, 2}, 1, 8)
+	metricdatatest.AssertEqual(t, histRM(metricdata.CumulativeTemporality, want), got)
+
+	// A change in bounds resets the stream.
+	in = histDP(t2, t3, 1, 1, []uint64{1, 0}, 1, 1)
+	in.Bounds = []float64{2}
+	require.NoError(t, exp.Export(ctx, histRM(metricdata.DeltaTemporality, in)))
+	metricdatatest.AssertEqual(t, histRM(metricdata.CumulativeTemporality, in), got)
+}
+
+func TestTemporalityExporterEviction(t *testing.T) {
+	current := t0
+	orig := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = orig })
+
+	var got metricdata.ResourceMetrics
+	exp := NewCumulativeExporter(recordingExporter(&got), WithIdleStreamTimeout(time.Minute))
+	ctx := context.Background()
+
+	require.NoError(t, exp.Export(ctx, sumRM(metricdata.DeltaTemporality, true, intDP(t0, t1, 5))))
+
+	// Exporting an unrelated stream after the timeout evicts the idle one.
+	current = current.Add(2 * time.Minute)
+	require.NoError(t, exp.Export(ctx, metricdata.ResourceMetrics{}))
+	assert.Len(t, exp.(*temporalityExporter).streams, 0)
+
+	require.NoError(t, exp.Export(ctx, sumRM(metricdata.DeltaTemporality, true, intDP(t1, t2, 3))))
+	metricdatatest.AssertEqual(t, sumRM(metricdata.CumulativeTemporality, true, intDP(t1, t2, 3)), got)
+}
+
+func TestTemporalityExporterDelegates(t *testing.T) {
+	var flushed, shutdown bool
+	exp := NewDeltaExporter(&fnExporter{
+		flushFunc:    func(context.Context) error { flushed = true; return nil },
+		shutdownFunc: func(context.Context) error { shutdown = true; return assert.AnError },
+	})
+
+	assert.NoError(t, exp.ForceFlush(context.Background()))
+	assert.True(t, flushed, "ForceFlush not delegated")
+	assert.ErrorIs(t, exp.Shutdown(context.Background()), assert.AnError)
+	assert.True(t, shutdown, "Shutdown not delegated")
+	assert.Equal(t, DefaultAggregationSelector(InstrumentKindHistogram), exp.Aggregation(InstrumentKindHistogram))
+}
+
+func TestTemporalityExporterWithPeriodicReader(t *testing.T) {
+	var got metricdata.ResourceMetrics
+	exp := NewDeltaExporter(recordingExporter(&got))
+	r := NewPeriodicReader(exp)
+	mp := NewMeterProvider(WithReader(r))
+	ctr, err := mp.Meter("test").Int64Counter("requests")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ctr.Add(ctx, 3)
+	require.NoError(t, r.ForceFlush(ctx))
+	ctr.Add(ctx, 2)
+	require.NoError(t, r.ForceFlush(ctx))
+
+	require.Len(t, got.ScopeMetrics, 1)
+	require.Len(t, got.ScopeMetrics[0].Metrics, 1)
+	sum, ok := got.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.Equal(t, metricdata.DeltaTemporality, sum.Temporality)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+
+	require.NoError(t, mp.Shutdown(ctx))
+}
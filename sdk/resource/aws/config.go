@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/aws"
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+)
+
+// Option applies a configuration option to a metadata service detector.
+type Option interface {
+	apply(metadata.Config) metadata.Config
+}
+
+type optionFunc metadata.Setting
+
+func (fn optionFunc) apply(cfg metadata.Config) metadata.Config {
+	return fn(cfg)
+}
+
+// newConfig returns the Config of a detector querying the metadata service at
+// endpoint by default, configured with options.
+func newConfig(endpoint string, options []Option) metadata.Config {
+	cfg := metadata.NewConfig(endpoint)
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// WithEndpoint sets the base URL of the metadata service queried by the
+// detector. This is useful to query a stand-in metadata service when testing.
+//
+// By default, the EC2 detector uses "http://169.254.169.254" and the ECS
+// detector uses the value of the ECS_CONTAINER_METADATA_URI_V4 environment
+// variable.
+func WithEndpoint(endpoint string) Option {
+	return optionFunc(metadata.WithEndpoint(endpoint))
+}
+
+// WithTimeout sets the time limit for the detector to query the metadata
+// service. Queries not complete within it are canceled.
+//
+// By default, a timeout of 2 seconds is used.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(metadata.WithTimeout(d))
+}
+
+// WithHTTPClient sets the HTTP client used to query the metadata service.
+//
+// By default, a shared client that does not use a proxy is used.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(metadata.WithHTTPClient(client))
+}
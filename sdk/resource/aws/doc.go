@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aws provides resource detectors for Amazon Web Services.
+//
+// NewEC2Detector queries the EC2 instance metadata service (IMDSv2),
+// NewECSDetector the ECS task metadata endpoint (version 4), and
+// NewLambdaDetector the environment of a Lambda function. Each detector
+// returns an empty Resource when the application is not running in the
+// environment it detects.
+//
+// The detectors are registered with the resource package:
+//
+//	res, err := resource.New(ctx,
+//		resource.WithDetectors(aws.NewEC2Detector()),
+//	)
+package aws // import "go.opentelemetry.io/otel/sdk/resource/aws"
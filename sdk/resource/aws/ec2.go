@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/aws"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	ec2Endpoint = "http://169.254.169.254"
+
+	ec2TokenPath    = "/latest/api/token"
+	ec2IdentityPath = "/latest/dynamic/instance-identity/document"
+	ec2HostnamePath = "/latest/meta-data/hostname"
+
+	ec2TokenHeader    = "X-aws-ec2-metadata-token"
+	ec2TokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	// ec2TokenTTL is the lifetime, in seconds, of the session token. It is
+	// only used for the few requests of a detection.
+	ec2TokenTTL = "60"
+)
+
+// ec2Arch maps the architecture of the instance identity document to the
+// host.arch value.
+var ec2Arch = map[string]attribute.KeyValue{
+	"x86_64": semconv.HostArchAMD64,
+	"arm64":  semconv.HostArchARM64,
+	"i386":   semconv.HostArchX86,
+}
+
+// ec2Identity is the instance identity document of an EC2 instance.
+type ec2Identity struct {
+	AccountID        string `json:"accountId"`
+	Architecture     string `json:"architecture"`
+	AvailabilityZone string `json:"availabilityZone"`
+	ImageID          string `json:"imageId"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	Region           string `json:"region"`
+}
+
+type ec2Detector struct {
+	cfg metadata.Config
+}
+
+var _ resource.Detector = ec2Detector{}
+
+// NewEC2Detector returns a resource.Detector that describes the EC2 instance
+// the application is running on. The instance metadata service is queried
+// using a session token (IMDSv2).
+func NewEC2Detector(options ...Option) resource.Detector {
+	return ec2Detector{cfg: newConfig(ec2Endpoint, options)}
+}
+
+// Detect returns a *Resource that describes the EC2 instance. If the
+// instance metadata service is not available an empty resource is returned.
+func (d ec2Detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	ctx, cancel := d.cfg.Context(ctx)
+	defer cancel()
+	client := d.cfg.Client()
+
+	token, err := client.Do(ctx, http.MethodPut, ec2TokenPath, http.Header{
+		ec2TokenTTLHeader: []string{ec2TokenTTL},
+	})
+	if err != nil {
+		// Not running on EC2, or IMDSv2 is not enabled.
+		return resource.Empty(), nil
+	}
+	header := http.Header{ec2TokenHeader: []string{string(token)}}
+
+	var doc ec2Identity
+	if err := client.GetJSON(ctx, ec2IdentityPath, header, &doc); err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+	}
+	attrs = appendNonEmpty(attrs,
+		semconv.CloudRegionKey.String(doc.Region),
+		semconv.CloudAvailabilityZoneKey.String(doc.AvailabilityZone),
+		semconv.CloudAccountIDKey.String(doc.AccountID),
+		semconv.HostIDKey.String(doc.InstanceID),
+		semconv.HostTypeKey.String(doc.InstanceType),
+		semconv.HostImageIDKey.String(doc.ImageID),
+	)
+	if arch, ok := ec2Arch[doc.Architecture]; ok {
+		attrs = append(attrs, arch)
+	}
+
+	hostname, err := client.Get(ctx, ec2HostnamePath, header)
+	if err != nil {
+		return nil, err
+	}
+	attrs = appendNonEmpty(attrs, semconv.HostNameKey.String(hostname))
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// appendNonEmpty appends the attributes of kvs with a non-empty value to
+// attrs.
+func appendNonEmpty(attrs []attribute.KeyValue, kvs ...attribute.KeyValue) []attribute.KeyValue {
+	for _, kv := range kvs {
+		if kv.Value.AsString() != "" {
+			attrs = append(attrs, kv)
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/aws"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	testToken    = "AQAEAFk3Yp2V8Nx0w=="
+	testIdentity = `{
+  "accountId" : "123456789012",
+  "architecture" : "x86_64",
+  "availabilityZone" : "us-west-2b",
+  "imageId" : "ami-5fb8c835",
+  "instanceId" : "i-1234567890abcdef0",
+  "instanceType" : "t2.micro",
+  "pendingTime" : "2016-11-19T16:32:11Z",
+  "privateIp" : "10.158.112.84",
+  "region" : "us-west-2",
+  "version" : "2017-09-30"
+}`
+	testHostname = "ip-10-158-112-84.us-west-2.compute.internal"
+)
+
+func newIMDS(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(testToken))
+	})
+	withToken := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-aws-ec2-metadata-token") != testToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		}
+	}
+	mux.HandleFunc("/latest/dynamic/instance-identity/document", withToken(testIdentity))
+	mux.HandleFunc("/latest/meta-data/hostname", withToken(testHostname))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestEC2Detector(t *testing.T) {
+	srv := newIMDS(t)
+
+	res, err := aws.NewEC2Detector(aws.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2b"),
+		semconv.CloudAccountIDKey.String("123456789012"),
+		semconv.HostIDKey.String("i-1234567890abcdef0"),
+		semconv.HostTypeKey.String("t2.micro"),
+		semconv.HostImageIDKey.String("ami-5fb8c835"),
+		semconv.HostArchAMD64,
+		semconv.HostNameKey.String(testHostname),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestEC2DetectorNotOnEC2(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	res, err := aws.NewEC2Detector(aws.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestEC2DetectorTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(block)
+		srv.Close()
+	})
+
+	d := aws.NewEC2Detector(
+		aws.WithEndpoint(srv.URL),
+		aws.WithTimeout(10*time.Millisecond),
+	)
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestEC2DetectorInvalidIdentity(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testToken))
+	})
+	mux.HandleFunc("/latest/dynamic/instance-identity/document", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	_, err := aws.NewEC2Detector(aws.WithEndpoint(srv.URL)).Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/aws"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// ecsEndpointEnv is set by the ECS container agent to the task metadata
+// endpoint of the container.
+const ecsEndpointEnv = "ECS_CONTAINER_METADATA_URI_V4"
+
+// ecsContainer is the container metadata of the task metadata endpoint.
+type ecsContainer struct {
+	DockerID     string `json:"DockerId"`
+	Name         string `json:"Name"`
+	Image        string `json:"Image"`
+	ContainerARN string `json:"ContainerARN"`
+	LogDriver    string `json:"LogDriver"`
+	LogOptions   struct {
+		Group  string `json:"awslogs-group"`
+		Region string `json:"awslogs-region"`
+		Stream string `json:"awslogs-stream"`
+	} `json:"LogOptions"`
+}
+
+// ecsTask is the task metadata of the task metadata endpoint.
+type ecsTask struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+type ecsDetector struct {
+	cfg metadata.Config
+}
+
+var _ resource.Detector = ecsDetector{}
+
+// NewECSDetector returns a resource.Detector that describes the ECS task and
+// container the application is running in. The task metadata endpoint
+// (version 4) is queried.
+func NewECSDetector(options ...Option) resource.Detector {
+	return ecsDetector{cfg: newConfig("", options)}
+}
+
+// Detect returns a *Resource that describes the ECS task and container. If
+// the task metadata endpoint is not known an empty resource is returned.
+func (d ecsDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	cfg := d.cfg
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv(ecsEndpointEnv)
+		if cfg.Endpoint == "" {
+			// Not running on ECS.
+			return resource.Empty(), nil
+		}
+	}
+
+	ctx, cancel := cfg.Context(ctx)
+	defer cancel()
+	client := cfg.Client()
+
+	var container ecsContainer
+	if err := client.GetJSON(ctx, "", nil, &container); err != nil {
+		return nil, err
+	}
+	var task ecsTask
+	if err := client.GetJSON(ctx, "/task", nil, &task); err != nil {
+		return nil, err
+	}
+
+	// arn:aws:ecs:<region>:<account>:task/<cluster>/<id>
+	arn := strings.SplitN(task.TaskARN, ":", 6)
+	if len(arn) != 6 {
+		return nil, fmt.Errorf("invalid ECS task ARN: %q", task.TaskARN)
+	}
+	region, account := arn[3], arn[4]
+
+	cluster := task.Cluster
+	if cluster != "" && !strings.HasPrefix(cluster, "arn:") {
+		cluster = fmt.Sprintf("arn:aws:ecs:%s:%s:cluster/%s", region, account, cluster)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+	}
+	attrs = appendNonEmpty(attrs,
+		semconv.CloudRegionKey.String(region),
+		semconv.CloudAccountIDKey.String(account),
+		semconv.CloudAvailabilityZoneKey.String(task.AvailabilityZone),
+		semconv.AWSECSClusterARNKey.String(cluster),
+		semconv.AWSECSTaskARNKey.String(task.TaskARN),
+		semconv.AWSECSTaskFamilyKey.String(task.Family),
+		semconv.AWSECSTaskRevisionKey.String(task.Revision),
+		semconv.AWSECSLaunchtypeKey.String(strings.ToLower(task.LaunchType)),
+		semconv.AWSECSContainerARNKey.String(container.ContainerARN),
+		semconv.ContainerIDKey.String(container.DockerID),
+		semconv.ContainerNameKey.String(container.Name),
+	)
+
+	if container.Image != "" {
+		name, tag := splitImage(container.Image)
+		attrs = appendNonEmpty(attrs,
+			semconv.ContainerImageNameKey.String(name),
+			semconv.ContainerImageTagKey.String(tag),
+		)
+	}
+
+	if container.LogDriver == "awslogs" && container.LogOptions.Group != "" {
+		logRegion := container.LogOptions.Region
+		if logRegion == "" {
+			logRegion = region
+		}
+		group := container.LogOptions.Group
+		groupARN := fmt.Sprintf("arn:aws:logs:%s:%s:log-group:%s", logRegion, account, group)
+		attrs = append(attrs,
+			semconv.AWSLogGroupNamesKey.StringSlice([]string{group}),
+			semconv.AWSLogGroupARNsKey.StringSlice([]string{groupARN}),
+		)
+		if stream := container.LogOptions.Stream; stream != "" {
+			attrs = append(attrs,
+				semconv.AWSLogStreamNamesKey.StringSlice([]string{stream}),
+				semconv.AWSLogStreamARNsKey.StringSlice([]string{groupARN + ":log-stream:" + stream}),
+			)
+		}
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// splitImage splits a container image reference into its name and tag.
+func splitImage(image string) (name, tag string) {
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
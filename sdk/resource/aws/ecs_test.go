@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/aws"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	testContainer = `{
+  "DockerId": "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66",
+  "Name": "curl",
+  "Image": "111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:latest",
+  "ContainerARN": "arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9",
+  "LogDriver": "awslogs",
+  "LogOptions": {
+    "awslogs-create-group": "true",
+    "awslogs-group": "/ecs/metadata",
+    "awslogs-region": "us-west-2",
+    "awslogs-stream": "ecs/curl/8f03e41243824aea923aca126495f665"
+  }
+}`
+	testTask = `{
+  "Cluster": "default",
+  "TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c",
+  "Family": "curltest",
+  "Revision": "26",
+  "AvailabilityZone": "us-west-2d",
+  "LaunchType": "FARGATE"
+}`
+)
+
+func newTaskMetadataEndpoint(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4/container", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testContainer))
+	})
+	mux.HandleFunc("/v4/container/task", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTask))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestECSDetector(t *testing.T) {
+	srv := newTaskMetadataEndpoint(t)
+	t.Setenv("ECS_CONTAINER_METADATA_URI_V4", srv.URL+"/v4/container")
+
+	res, err := aws.NewECSDetector().Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAccountIDKey.String("111122223333"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2d"),
+		semconv.AWSECSClusterARNKey.String("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
+		semconv.AWSECSTaskARNKey.String("arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c"),
+		semconv.AWSECSTaskFamilyKey.String("curltest"),
+		semconv.AWSECSTaskRevisionKey.String("26"),
+		semconv.AWSECSLaunchtypeFargate,
+		semconv.AWSECSContainerARNKey.String("arn:aws:ecs:us-west-2:111122223333:container/0206b271-b33f-47ab-86c6-a0ba208a70a9"),
+		semconv.ContainerIDKey.String("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		semconv.ContainerNameKey.String("curl"),
+		semconv.ContainerImageNameKey.String("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		semconv.ContainerImageTagKey.String("latest"),
+		semconv.AWSLogGroupNamesKey.StringSlice([]string{"/ecs/metadata"}),
+		semconv.AWSLogGroupARNsKey.StringSlice([]string{"arn:aws:logs:us-west-2:111122223333:log-group:/ecs/metadata"}),
+		semconv.AWSLogStreamNamesKey.StringSlice([]string{"ecs/curl/8f03e41243824aea923aca126495f665"}),
+		semconv.AWSLogStreamARNsKey.StringSlice([]string{"arn:aws:logs:us-west-2:111122223333:log-group:/ecs/metadata:log-stream:ecs/curl/8f03e41243824aea923aca126495f665"}),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestECSDetectorEndpointOption(t *testing.T) {
+	srv := newTaskMetadataEndpoint(t)
+	t.Setenv("ECS_CONTAINER_METADATA_URI_V4", "")
+
+	res, err := aws.NewECSDetector(aws.WithEndpoint(srv.URL + "/v4/container")).Detect(context.Background())
+	require.NoError(t, err)
+	v, ok := res.Set().Value(semconv.AWSECSTaskFamilyKey)
+	assert.True(t, ok)
+	assert.Equal(t, "curltest", v.AsString())
+}
+
+func TestECSDetectorNotOnECS(t *testing.T) {
+	t.Setenv("ECS_CONTAINER_METADATA_URI_V4", "")
+
+	res, err := aws.NewECSDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestECSDetectorError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	_, err := aws.NewECSDetector(aws.WithEndpoint(srv.URL)).Detect(context.Background())
+	assert.Error(t, err)
+}
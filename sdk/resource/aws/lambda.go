@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/aws"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// Environment variables set by the Lambda runtime.
+const (
+	lambdaFunctionNameEnv    = "AWS_LAMBDA_FUNCTION_NAME"
+	lambdaFunctionVersionEnv = "AWS_LAMBDA_FUNCTION_VERSION"
+	lambdaFunctionMemoryEnv  = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	lambdaLogGroupEnv        = "AWS_LAMBDA_LOG_GROUP_NAME"
+	lambdaLogStreamEnv       = "AWS_LAMBDA_LOG_STREAM_NAME"
+	lambdaRegionEnv          = "AWS_REGION"
+)
+
+type lambdaDetector struct{}
+
+var _ resource.Detector = lambdaDetector{}
+
+// NewLambdaDetector returns a resource.Detector that describes the Lambda
+// function the application is running as. Lambda does not provide a metadata
+// service, the function is described by the environment of its runtime.
+func NewLambdaDetector() resource.Detector {
+	return lambdaDetector{}
+}
+
+// Detect returns a *Resource that describes the Lambda function. If the
+// application is not running as a Lambda function an empty resource is
+// returned.
+func (lambdaDetector) Detect(context.Context) (*resource.Resource, error) {
+	name := os.Getenv(lambdaFunctionNameEnv)
+	if name == "" {
+		// Not running on Lambda.
+		return resource.Empty(), nil
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+		semconv.FaaSNameKey.String(name),
+	}
+	attrs = appendNonEmpty(attrs,
+		semconv.CloudRegionKey.String(os.Getenv(lambdaRegionEnv)),
+		semconv.FaaSVersionKey.String(os.Getenv(lambdaFunctionVersionEnv)),
+		// The log stream name is unique to the execution environment.
+		semconv.FaaSInstanceKey.String(os.Getenv(lambdaLogStreamEnv)),
+	)
+
+	if v := os.Getenv(lambdaFunctionMemoryEnv); v != "" {
+		mem, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", lambdaFunctionMemoryEnv, err)
+		}
+		attrs = append(attrs, semconv.FaaSMaxMemoryKey.Int(mem))
+	}
+	if group := os.Getenv(lambdaLogGroupEnv); group != "" {
+		attrs = append(attrs, semconv.AWSLogGroupNamesKey.StringSlice([]string{group}))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
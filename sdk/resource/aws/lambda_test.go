@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/aws"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func TestLambdaDetector(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "/aws/lambda/checkout")
+	t.Setenv("AWS_LAMBDA_LOG_STREAM_NAME", "2022/12/01/[$LATEST]3f2a0c5e8d7b4f0a9e6c1b2d3a4f5e6d")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	res, err := aws.NewLambdaDetector().Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+		semconv.FaaSNameKey.String("checkout"),
+		semconv.CloudRegionKey.String("eu-west-1"),
+		semconv.FaaSVersionKey.String("$LATEST"),
+		semconv.FaaSInstanceKey.String("2022/12/01/[$LATEST]3f2a0c5e8d7b4f0a9e6c1b2d3a4f5e6d"),
+		semconv.FaaSMaxMemoryKey.Int(128),
+		semconv.AWSLogGroupNamesKey.StringSlice([]string{"/aws/lambda/checkout"}),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestLambdaDetectorNotOnLambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+
+	res, err := aws.NewLambdaDetector().Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestLambdaDetectorInvalidMemory(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "lots")
+
+	_, err := aws.NewLambdaDetector().Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure // import "go.opentelemetry.io/otel/sdk/resource/azure"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	defaultEndpoint = "http://169.254.169.254"
+	computePath     = "/metadata/instance/compute?api-version=2021-12-13&format=json"
+)
+
+// metadataHeader needs to be sent with every request to the metadata service.
+var metadataHeader = http.Header{"Metadata": []string{"true"}}
+
+// compute is the compute metadata of a virtual machine.
+type compute struct {
+	Location       string `json:"location"`
+	Name           string `json:"name"`
+	VMID           string `json:"vmId"`
+	VMSize         string `json:"vmSize"`
+	SubscriptionID string `json:"subscriptionId"`
+	Zone           string `json:"zone"`
+	Version        string `json:"version"`
+}
+
+type detector struct {
+	cfg metadata.Config
+}
+
+var _ resource.Detector = detector{}
+
+// NewDetector returns a resource.Detector that describes the Azure virtual
+// machine the application is running on.
+func NewDetector(options ...Option) resource.Detector {
+	return detector{cfg: newConfig(defaultEndpoint, options)}
+}
+
+// Detect returns a *Resource that describes the Azure virtual machine. If the
+// Instance Metadata Service is not available an empty resource is returned.
+func (d detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	ctx, cancel := d.cfg.Context(ctx)
+	defer cancel()
+
+	var vm compute
+	if err := d.cfg.Client().GetJSON(ctx, computePath, metadataHeader, &vm); err != nil {
+		// Not running on Azure.
+		return resource.Empty(), nil
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+	}
+	for _, kv := range []attribute.KeyValue{
+		semconv.CloudRegionKey.String(vm.Location),
+		semconv.CloudAvailabilityZoneKey.String(vm.Zone),
+		semconv.CloudAccountIDKey.String(vm.SubscriptionID),
+		semconv.HostIDKey.String(vm.VMID),
+		semconv.HostNameKey.String(vm.Name),
+		semconv.HostTypeKey.String(vm.VMSize),
+		semconv.HostImageVersionKey.String(vm.Version),
+	} {
+		if kv.Value.AsString() != "" {
+			attrs = append(attrs, kv)
+		}
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/azure"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const testCompute = `{
+  "location": "westeurope",
+  "name": "vm-1",
+  "osType": "Linux",
+  "resourceGroupName": "production",
+  "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+  "version": "20.04.202211151",
+  "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+  "vmSize": "Standard_D2s_v3",
+  "zone": "1"
+}`
+
+func TestDetector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		_, _ = w.Write([]byte(testCompute))
+	}))
+	t.Cleanup(srv.Close)
+
+	res, err := azure.NewDetector(azure.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.CloudProviderAzure,
+		semconv.CloudPlatformAzureVM,
+		semconv.CloudRegionKey.String("westeurope"),
+		semconv.CloudAvailabilityZoneKey.String("1"),
+		semconv.CloudAccountIDKey.String("8d10da13-8125-4ba9-a717-bf7490507b3d"),
+		semconv.HostIDKey.String("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		semconv.HostNameKey.String("vm-1"),
+		semconv.HostTypeKey.String("Standard_D2s_v3"),
+		semconv.HostImageVersionKey.String("20.04.202211151"),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestDetectorNotOnAzure(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	res, err := azure.NewDetector(azure.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azure provides a resource detector for Microsoft Azure.
+//
+// NewDetector queries the Azure Instance Metadata Service to describe the
+// virtual machine the application is running on. It returns an empty
+// Resource when the application is not running on an Azure virtual machine.
+//
+// The detector is registered with the resource package:
+//
+//	res, err := resource.New(ctx,
+//		resource.WithDetectors(azure.NewDetector()),
+//	)
+package azure // import "go.opentelemetry.io/otel/sdk/resource/azure"
@@ -197,3 +197,42 @@ func WithContainer() Option {
 func WithContainerID() Option {
 	return WithDetectors(cgroupContainerIDDetector{})
 }
+
+// WithHostID adds an attribute with the unique id of the host to the
+// configured Resource. The id is read from the machine-id file
+// (/etc/machine-id or /var/lib/dbus/machine-id).
+func WithHostID() Option {
+	return WithDetectors(hostIDDetector{})
+}
+
+// WithKubernetes adds the Kubernetes Pod attributes to the configured
+// Resource. Nothing is added when the process is not running in Kubernetes.
+//
+// The Pod name, UID, namespace and node name are read from the K8S_POD_NAME,
+// K8S_POD_UID, K8S_NAMESPACE_NAME and K8S_NODE_NAME environment variables.
+// These are expected to be set using the downward API:
+//
+//	env:
+//	- name: K8S_POD_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.name
+//	- name: K8S_POD_UID
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.uid
+//	- name: K8S_NAMESPACE_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.namespace
+//	- name: K8S_NODE_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: spec.nodeName
+//
+// If they are not set, the Pod name falls back to the container hostname, the
+// Pod UID to the kubelet volume mounts, and the namespace to the service
+// account namespace file.
+func WithKubernetes() Option {
+	return WithDetectors(k8sDetector{})
+}
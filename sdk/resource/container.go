@@ -28,13 +28,32 @@ import (
 type containerIDProvider func() (string, error)
 
 var (
-	containerID         containerIDProvider = getContainerIDFromCGroup
+	containerID         containerIDProvider = getContainerID
 	cgroupContainerIDRe                     = regexp.MustCompile(`^.*/(?:.*-)?([0-9a-f]+)(?:\.|\s*$)`)
+	// mountinfoContainerIDRe matches the container directory of the
+	// container runtime that is bind mounted into the container (e.g. for
+	// /etc/hostname). This is used for cgroup v2 hosts where the cgroup file
+	// no longer contains the container ID. The matched directories are:
+	//
+	//   - Docker: /var/lib/docker/containers/<id>/
+	//   - Podman: .../overlay-containers/<id>/
+	//   - containerd (nerdctl): .../containers/<namespace>/<id>/
+	//   - containerd task bundle: .../io.containerd.runtime.v2.task/<namespace>/<id>/
+	//
+	// Kubernetes with the containerd CRI plugin only bind mounts files of
+	// the pod sandbox (.../io.containerd.grpc.v1.cri/sandboxes/<id>/) and of
+	// the kubelet (/var/lib/kubelet/pods/<uid>/containers/<name>/). Neither
+	// holds the container ID, the sandbox ID is shared by all containers of
+	// the pod, so these are not matched.
+	mountinfoContainerIDRe = regexp.MustCompile(`/(?:containers/(?:[^/]+/)?|overlay-containers/|io\.containerd\.runtime\.v[12]\.(?:task|linux)/[^/]+/)([0-9a-f]{64})/`)
 )
 
 type cgroupContainerIDDetector struct{}
 
-const cgroupPath = "/proc/self/cgroup"
+const (
+	cgroupPath    = "/proc/self/cgroup"
+	mountinfoPath = "/proc/self/mountinfo"
+)
 
 // Detect returns a *Resource that describes the id of the container.
 // If no container id found, an empty resource will be returned.
@@ -60,31 +79,54 @@ var (
 	osOpen = defaultOSOpen
 )
 
+// getContainerID returns the id of the container this process is running
+// in. The cgroup file is used first, falling back to the mountinfo file for
+// cgroup v2 hosts. If no container id found, an empty string will be
+// returned.
+func getContainerID() (string, error) {
+	id, err := getContainerIDFromCGroup()
+	if err != nil || id != "" {
+		return id, err
+	}
+	return getContainerIDFromMountinfo()
+}
+
 // getContainerIDFromCGroup returns the id of the container from the cgroup file.
 // If no container id found, an empty string will be returned.
 func getContainerIDFromCGroup() (string, error) {
-	if _, err := osStat(cgroupPath); errors.Is(err, os.ErrNotExist) {
+	return scanFile(cgroupPath, getContainerIDFromLine)
+}
+
+// getContainerIDFromMountinfo returns the id of the container from the
+// mountinfo file. If no container id found, an empty string will be returned.
+func getContainerIDFromMountinfo() (string, error) {
+	return scanFile(mountinfoPath, getContainerIDFromMountinfoLine)
+}
+
+// scanFile returns the first non-empty value match returns for a line of the
+// file at path. If the file does not exist, an empty string is returned.
+func scanFile(path string, match func(string) string) (string, error) {
+	if _, err := osStat(path); errors.Is(err, os.ErrNotExist) {
 		// File does not exist, skip
 		return "", nil
 	}
 
-	file, err := osOpen(cgroupPath)
+	file, err := osOpen(path)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
 
-	return getContainerIDFromReader(file), nil
+	return scanReader(file, match), nil
 }
 
-// getContainerIDFromReader returns the id of the container from reader.
-func getContainerIDFromReader(reader io.Reader) string {
+// scanReader returns the first non-empty value match returns for a line read
+// from reader.
+func scanReader(reader io.Reader, match func(string) string) string {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
-		line := scanner.Text()
-
-		if id := getContainerIDFromLine(line); id != "" {
-			return id
+		if v := match(scanner.Text()); v != "" {
+			return v
 		}
 	}
 	return ""
@@ -98,3 +140,13 @@ func getContainerIDFromLine(line string) string {
 	}
 	return matches[1]
 }
+
+// getContainerIDFromMountinfoLine returns the id of the container from one
+// line of the mountinfo file.
+func getContainerIDFromMountinfoLine(line string) string {
+	matches := mountinfoContainerIDRe.FindStringSubmatch(line)
+	if len(matches) <= 1 {
+		return ""
+	}
+	return matches[1]
+}
@@ -26,7 +26,7 @@ import (
 
 func setDefaultContainerProviders() {
 	setContainerProviders(
-		getContainerIDFromCGroup,
+		getContainerID,
 	)
 }
 
@@ -109,7 +109,7 @@ func TestGetContainerIDFromReader(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			containerID := getContainerIDFromReader(tc.reader)
+			containerID := scanReader(tc.reader, getContainerIDFromLine)
 			assert.Equal(t, tc.expectedContainerID, containerID)
 		})
 	}
@@ -167,3 +167,117 @@ func TestGetContainerIDFromCGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainerIDFromMountinfoLine(t *testing.T) {
+	testCases := []struct {
+		name                string
+		line                string
+		expectedContainerID string
+	}{
+		{
+			name:                "docker",
+			line:                "1009 1002 8:1 /var/lib/docker/containers/d86d75589bf6cc254f3e2cc29debdf85dde404998aa128997a819ff991827356/hostname /etc/hostname rw,relatime - ext4 /dev/sda1 rw",
+			expectedContainerID: "d86d75589bf6cc254f3e2cc29debdf85dde404998aa128997a819ff991827356",
+		},
+		{
+			name:                "podman",
+			line:                "1090 1078 0:26 /containers/storage/overlay-containers/2a33efc76e519c137fe6093179653788bed6162d4a15e5131c8e835c968afbe6/userdata/hostname /etc/hostname rw,nosuid,nodev - tmpfs tmpfs rw",
+			expectedContainerID: "2a33efc76e519c137fe6093179653788bed6162d4a15e5131c8e835c968afbe6",
+		},
+		{
+			name:                "containerd nerdctl",
+			line:                "1424 1416 253:1 /var/lib/nerdctl/1935db59/containers/default/0e2b6d7a6ec2a0a6e4fbe87fcb3f0c2e3d15a7b0bd7c1c3d7f0a1c39a2f5e6d1/hostname /etc/hostname rw,relatime - ext4 /dev/vda1 rw",
+			expectedContainerID: "0e2b6d7a6ec2a0a6e4fbe87fcb3f0c2e3d15a7b0bd7c1c3d7f0a1c39a2f5e6d1",
+		},
+		{
+			name:                "containerd task bundle",
+			line:                "1512 1503 0:25 /containerd/io.containerd.runtime.v2.task/k8s.io/9b1e2f4c7d3a5e8f0b6c2d4a1e3f5b7c9d0a2e4f6b8c1d3e5f7a9b0c2d4e6f8a/rootfs/etc/hostname /etc/hostname rw,nosuid,nodev - tmpfs tmpfs rw",
+			expectedContainerID: "9b1e2f4c7d3a5e8f0b6c2d4a1e3f5b7c9d0a2e4f6b8c1d3e5f7a9b0c2d4e6f8a",
+		},
+		{
+			// The sandbox ID identifies the pod, not the container.
+			name: "containerd sandbox",
+			line: "2045 2036 253:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/1b1a1c5e5d3cd1b4dfa4d7f7e18d1f1b96f4a5fb3bbc1bd92f6a3d2bbd8f2a3e/hostname /etc/hostname rw,relatime - ext4 /dev/vda1 rw",
+		},
+		{
+			name: "kubelet container directory",
+			line: "2050 2036 253:1 /var/lib/kubelet/pods/0f3c5e8a-7d2b-4a61-9c4e-1b2d3e4f5a6b/containers/app/5c2f8e1a /dev/termination-log rw,relatime - ext4 /dev/vda1 rw",
+		},
+		{
+			name: "no container id",
+			line: "1001 1000 0:56 / / rw,relatime master:1 - overlay overlay rw",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			containerID := getContainerIDFromMountinfoLine(tc.line)
+			assert.Equal(t, tc.expectedContainerID, containerID)
+		})
+	}
+}
+
+func TestGetContainerID(t *testing.T) {
+	t.Cleanup(func() {
+		osStat = defaultOSStat
+		osOpen = defaultOSOpen
+	})
+
+	const id = "d86d75589bf6cc254f3e2cc29debdf85dde404998aa128997a819ff991827356"
+	testCases := []struct {
+		name                string
+		files               map[string]string
+		expectedContainerID string
+	}{
+		{
+			name: "cgroup v1",
+			files: map[string]string{
+				cgroupPath:    "1:name=systemd:/docker/" + id,
+				mountinfoPath: "1009 1002 8:1 /var/lib/docker/containers/" + strings.Repeat("0", 64) + "/hostname /etc/hostname rw - ext4 /dev/sda1 rw",
+			},
+			expectedContainerID: id,
+		},
+		{
+			name: "cgroup v2",
+			files: map[string]string{
+				cgroupPath:    "0::/",
+				mountinfoPath: "1009 1002 8:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw - ext4 /dev/sda1 rw",
+			},
+			expectedContainerID: id,
+		},
+		{
+			name: "no mountinfo file",
+			files: map[string]string{
+				cgroupPath: "0::/",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setFakeFiles(tc.files)
+
+			containerID, err := getContainerID()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedContainerID, containerID)
+		})
+	}
+}
+
+// setFakeFiles replaces the file system used by detectors with files, a map of
+// file path to content.
+func setFakeFiles(files map[string]string) {
+	osStat = func(name string) (os.FileInfo, error) {
+		if _, ok := files[name]; !ok {
+			return nil, os.ErrNotExist
+		}
+		return nil, nil
+	}
+	osOpen = func(name string) (io.ReadCloser, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(content)), nil
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp // import "go.opentelemetry.io/otel/sdk/resource/gcp"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	defaultEndpoint = "http://metadata.google.internal"
+
+	projectIDPath       = "/computeMetadata/v1/project/project-id"
+	instanceIDPath      = "/computeMetadata/v1/instance/id"
+	instanceNamePath    = "/computeMetadata/v1/instance/name"
+	instanceZonePath    = "/computeMetadata/v1/instance/zone"
+	machineTypePath     = "/computeMetadata/v1/instance/machine-type"
+	clusterNamePath     = "/computeMetadata/v1/instance/attributes/cluster-name"
+	clusterLocationPath = "/computeMetadata/v1/instance/attributes/cluster-location"
+
+	// k8sServiceHostEnv is set by the kubelet for every container run in a
+	// Kubernetes Pod.
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+)
+
+// metadataHeader needs to be sent with every request to the metadata server.
+var metadataHeader = http.Header{"Metadata-Flavor": []string{"Google"}}
+
+type detector struct {
+	cfg metadata.Config
+}
+
+var _ resource.Detector = detector{}
+
+// NewDetector returns a resource.Detector that describes the Compute Engine
+// instance the application is running on. If the application is running in a
+// Kubernetes Pod, the Google Kubernetes Engine cluster is described instead.
+func NewDetector(options ...Option) resource.Detector {
+	return detector{cfg: newConfig(defaultEndpoint, options)}
+}
+
+// Detect returns a *Resource that describes the Compute Engine instance or
+// Google Kubernetes Engine cluster. If the metadata server is not available
+// an empty resource is returned.
+func (d detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	ctx, cancel := d.cfg.Context(ctx)
+	defer cancel()
+	client := d.cfg.Client()
+
+	projectID, err := client.Get(ctx, projectIDPath, metadataHeader)
+	if err != nil {
+		// Not running on Google Cloud.
+		return resource.Empty(), nil
+	}
+
+	zone, err := get(ctx, client, instanceZonePath)
+	if err != nil {
+		return nil, err
+	}
+	// projects/<project number>/zones/<zone>
+	zone = lastSegment(zone)
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountIDKey.String(projectID),
+	}
+
+	if os.Getenv(k8sServiceHostEnv) != "" {
+		return d.detectGKE(ctx, client, attrs, zone)
+	}
+
+	attrs = append(attrs,
+		semconv.CloudPlatformGCPComputeEngine,
+		semconv.CloudAvailabilityZoneKey.String(zone),
+		semconv.CloudRegionKey.String(zoneRegion(zone)),
+	)
+	for _, m := range []struct {
+		key  attribute.Key
+		path string
+	}{
+		{semconv.HostIDKey, instanceIDPath},
+		{semconv.HostNameKey, instanceNamePath},
+		{semconv.HostTypeKey, machineTypePath},
+	} {
+		v, err := get(ctx, client, m.path)
+		if err != nil {
+			return nil, err
+		}
+		if m.key == semconv.HostTypeKey {
+			// projects/<project number>/machineTypes/<machine type>
+			v = lastSegment(v)
+		}
+		if v != "" {
+			attrs = append(attrs, m.key.String(v))
+		}
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// detectGKE returns a *Resource that describes the Google Kubernetes Engine
+// cluster of the node in zone.
+func (d detector) detectGKE(ctx context.Context, client *metadata.Client, attrs []attribute.KeyValue, zone string) (*resource.Resource, error) {
+	attrs = append(attrs, semconv.CloudPlatformGCPKubernetesEngine)
+
+	name, err := get(ctx, client, clusterNamePath)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		attrs = append(attrs, semconv.K8SClusterNameKey.String(name))
+	}
+
+	location, err := get(ctx, client, clusterLocationPath)
+	if err != nil {
+		return nil, err
+	}
+	if location == "" {
+		location = zone
+	}
+	// Regional clusters are located in a region, zonal clusters in a zone.
+	if region := zoneRegion(location); region != location {
+		attrs = append(attrs,
+			semconv.CloudAvailabilityZoneKey.String(location),
+			semconv.CloudRegionKey.String(region),
+		)
+	} else {
+		attrs = append(attrs, semconv.CloudRegionKey.String(location))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// get returns the value of the metadata at path. An empty string is returned
+// if it is not defined.
+func get(ctx context.Context, client *metadata.Client, path string) (string, error) {
+	v, err := client.Get(ctx, path, metadataHeader)
+	var se *metadata.StatusError
+	if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	return v, err
+}
+
+// lastSegment returns the last segment of the slash separated path.
+func lastSegment(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
+
+// zoneRegion returns the region of zone. If zone is not a zone, e.g. it is a
+// region, zone is returned.
+func zoneRegion(zone string) string {
+	// <region>-<zone letter>, e.g. us-central1-a. Regions end in a digit.
+	i := strings.LastIndexByte(zone, '-')
+	if i < 0 || i+2 != len(zone) {
+		return zone
+	}
+	return zone[:i]
+}
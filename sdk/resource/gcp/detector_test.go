@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/gcp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func newMetadataServer(t *testing.T, values map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		v, ok := values[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Metadata-Flavor", "Google")
+		_, _ = w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var instance = map[string]string{
+	"/computeMetadata/v1/project/project-id":    "my-project",
+	"/computeMetadata/v1/instance/id":           "4520031799277581759",
+	"/computeMetadata/v1/instance/name":         "instance-1",
+	"/computeMetadata/v1/instance/zone":         "projects/123456789012/zones/us-central1-a",
+	"/computeMetadata/v1/instance/machine-type": "projects/123456789012/machineTypes/e2-medium",
+}
+
+func TestDetectorComputeEngine(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	srv := newMetadataServer(t, instance)
+
+	res, err := gcp.NewDetector(gcp.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+
+	want := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudAccountIDKey.String("my-project"),
+		semconv.CloudPlatformGCPComputeEngine,
+		semconv.CloudAvailabilityZoneKey.String("us-central1-a"),
+		semconv.CloudRegionKey.String("us-central1"),
+		semconv.HostIDKey.String("4520031799277581759"),
+		semconv.HostNameKey.String("instance-1"),
+		semconv.HostTypeKey.String("e2-medium"),
+	)
+	assert.Equal(t, want, res)
+}
+
+func TestDetectorKubernetesEngine(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+
+	testCases := []struct {
+		name     string
+		location string
+		want     *resource.Resource
+	}{
+		{
+			name:     "regional cluster",
+			location: "us-central1",
+			want: resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountIDKey.String("my-project"),
+				semconv.CloudPlatformGCPKubernetesEngine,
+				semconv.K8SClusterNameKey.String("production"),
+				semconv.CloudRegionKey.String("us-central1"),
+			),
+		},
+		{
+			name:     "zonal cluster",
+			location: "us-central1-a",
+			want: resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.CloudProviderGCP,
+				semconv.CloudAccountIDKey.String("my-project"),
+				semconv.CloudPlatformGCPKubernetesEngine,
+				semconv.K8SClusterNameKey.String("production"),
+				semconv.CloudAvailabilityZoneKey.String("us-central1-a"),
+				semconv.CloudRegionKey.String("us-central1"),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values := map[string]string{
+				"/computeMetadata/v1/instance/attributes/cluster-name":     "production",
+				"/computeMetadata/v1/instance/attributes/cluster-location": tc.location,
+			}
+			for k, v := range instance {
+				values[k] = v
+			}
+			srv := newMetadataServer(t, values)
+
+			res, err := gcp.NewDetector(gcp.WithEndpoint(srv.URL)).Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestDetectorNotOnGCP(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	res, err := gcp.NewDetector(gcp.WithEndpoint(srv.URL)).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.Empty(), res)
+}
+
+func TestDetectorError(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/computeMetadata/v1/project/project-id" {
+			_, _ = w.Write([]byte("my-project"))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := gcp.NewDetector(gcp.WithEndpoint(srv.URL)).Detect(context.Background())
+	assert.Error(t, err)
+}
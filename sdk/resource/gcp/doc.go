@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcp provides a resource detector for Google Cloud Platform.
+//
+// NewDetector queries the Compute Engine metadata server to describe the
+// Compute Engine instance, or Google Kubernetes Engine cluster, the
+// application is running on. It returns an empty Resource when the
+// application is not running on Google Cloud.
+//
+// The detector is registered with the resource package:
+//
+//	res, err := resource.New(ctx,
+//		resource.WithDetectors(gcp.NewDetector()),
+//	)
+package gcp // import "go.opentelemetry.io/otel/sdk/resource/gcp"
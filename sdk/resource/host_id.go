@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"strings"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+type hostIDProvider func() (string, error)
+
+var hostID hostIDProvider = getHostIDFromMachineID
+
+// machineIDPaths are the locations of the machine-id file, in order of
+// precedence.
+var machineIDPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+type hostIDDetector struct{}
+
+var _ Detector = hostIDDetector{}
+
+// Detect returns a *Resource that describes the unique id of the host.
+// If no host id found, an empty resource will be returned.
+func (hostIDDetector) Detect(ctx context.Context) (*Resource, error) {
+	hostID, err := hostID()
+	if err != nil {
+		return nil, err
+	}
+
+	if hostID == "" {
+		return Empty(), nil
+	}
+	return NewWithAttributes(semconv.SchemaURL, semconv.HostIDKey.String(hostID)), nil
+}
+
+// getHostIDFromMachineID returns the id of the host from the machine-id file.
+// If no host id found, an empty string will be returned.
+func getHostIDFromMachineID() (string, error) {
+	for _, path := range machineIDPaths {
+		id, err := scanFile(path, strings.TrimSpace)
+		if err != nil || id != "" {
+			return id, err
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setDefaultHostIDProvider() {
+	setHostIDProvider(
+		getHostIDFromMachineID,
+	)
+}
+
+func setHostIDProvider(
+	idProvider hostIDProvider,
+) {
+	hostID = idProvider
+}
+
+func TestGetHostIDFromMachineID(t *testing.T) {
+	t.Cleanup(func() {
+		osStat = defaultOSStat
+		osOpen = defaultOSOpen
+	})
+
+	testCases := []struct {
+		name           string
+		files          map[string]string
+		expectedHostID string
+	}{
+		{
+			name: "etc machine-id",
+			files: map[string]string{
+				"/etc/machine-id":          "5b2a3e9f6d1c4f0e8a7b6c5d4e3f2a1b\n",
+				"/var/lib/dbus/machine-id": "ffffffffffffffffffffffffffffffff\n",
+			},
+			expectedHostID: "5b2a3e9f6d1c4f0e8a7b6c5d4e3f2a1b",
+		},
+		{
+			name: "dbus machine-id",
+			files: map[string]string{
+				"/var/lib/dbus/machine-id": "ffffffffffffffffffffffffffffffff\n",
+			},
+			expectedHostID: "ffffffffffffffffffffffffffffffff",
+		},
+		{
+			name: "empty etc machine-id",
+			files: map[string]string{
+				"/etc/machine-id":          "",
+				"/var/lib/dbus/machine-id": "ffffffffffffffffffffffffffffffff\n",
+			},
+			expectedHostID: "ffffffffffffffffffffffffffffffff",
+		},
+		{
+			name: "no machine-id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setFakeFiles(tc.files)
+
+			hostID, err := getHostIDFromMachineID()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedHostID, hostID)
+		})
+	}
+}
+
+func TestGetHostIDFromMachineIDError(t *testing.T) {
+	t.Cleanup(func() {
+		osStat = defaultOSStat
+		osOpen = defaultOSOpen
+	})
+
+	setFakeFiles(map[string]string{"/etc/machine-id": ""})
+	osOpen = func(string) (io.ReadCloser, error) {
+		return nil, errors.New("permission denied")
+	}
+
+	_, err := getHostIDFromMachineID()
+	assert.Error(t, err)
+}
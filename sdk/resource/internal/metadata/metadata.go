@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata provides a client for the instance metadata services of
+// cloud providers.
+package metadata // import "go.opentelemetry.io/otel/sdk/resource/internal/metadata"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/internal"
+)
+
+// DefaultTimeout is the default time limit for a detector to query a
+// metadata service. Detectors are run when an application starts, and the
+// metadata service of a cloud provider is not reachable outside of it, so it
+// needs to be short.
+const DefaultTimeout = 2 * time.Second
+
+// maxResponseSize is the largest response body read from a metadata service.
+const maxResponseSize = 1 << 20
+
+// Config is the configuration of a Client and the detection it is used for.
+type Config struct {
+	// Endpoint is the base URL of the metadata service.
+	Endpoint string
+	// Timeout is the time limit of the detection.
+	Timeout time.Duration
+	// HTTPClient is used to send requests. If nil, a shared client that
+	// does not use a proxy is used.
+	HTTPClient *http.Client
+}
+
+// defaultClient is the client used by all detectors that are not configured
+// with one. It shares a single Transport so connections are reused.
+var defaultClient = &http.Client{Transport: newTransport()}
+
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// Metadata services are link-local, never use a proxy to reach them.
+	t.Proxy = nil
+	return t
+}
+
+// NewConfig returns the default Config of a detector querying the metadata
+// service at endpoint.
+func NewConfig(endpoint string) Config {
+	return Config{Endpoint: endpoint, Timeout: DefaultTimeout}
+}
+
+// Setting updates a Config. Each detector package wraps Settings in its own
+// Option type so options of one provider cannot be passed to the detectors
+// of another.
+type Setting func(Config) Config
+
+// WithEndpoint returns a Setting that sets the Endpoint of a Config.
+func WithEndpoint(endpoint string) Setting {
+	return func(cfg Config) Config {
+		cfg.Endpoint = endpoint
+		return cfg
+	}
+}
+
+// WithTimeout returns a Setting that sets the Timeout of a Config.
+func WithTimeout(d time.Duration) Setting {
+	return func(cfg Config) Config {
+		cfg.Timeout = d
+		return cfg
+	}
+}
+
+// WithHTTPClient returns a Setting that sets the HTTPClient of a Config.
+func WithHTTPClient(client *http.Client) Setting {
+	return func(cfg Config) Config {
+		cfg.HTTPClient = client
+		return cfg
+	}
+}
+
+// Client returns a Client for the metadata service c configures.
+func (c Config) Client() *Client {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = defaultClient
+	}
+	return &Client{endpoint: strings.TrimSuffix(c.Endpoint, "/"), client: hc}
+}
+
+// Context returns a copy of parent that is canceled when the Timeout of c
+// elapses.
+func (c Config) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, c.Timeout)
+}
+
+// Client sends requests to a metadata service.
+type Client struct {
+	endpoint string
+	client   *http.Client
+}
+
+// StatusError is returned when a metadata service responds with a
+// non-successful status code.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("metadata request to %s failed: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Do sends a request with method for path and returns the response body.
+func (c *Client) Do(ctx context.Context, method, path string, header http.Header) ([]byte, error) {
+	url := c.endpoint + path
+	req, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", internal.UserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode}
+	}
+	return body, nil
+}
+
+// Get returns the response body of a GET request for path as a string with
+// surrounding white space removed.
+func (c *Client) Get(ctx context.Context, path string, header http.Header) (string, error) {
+	body, err := c.Do(ctx, http.MethodGet, path, header)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// GetJSON decodes the JSON response body of a GET request for path into v.
+func (c *Client) GetJSON(ctx context.Context, path string, header http.Header, v interface{}) error {
+	body, err := c.Do(ctx, http.MethodGet, path, header)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid metadata response from %s%s: %w", c.endpoint, path, err)
+	}
+	return nil
+}
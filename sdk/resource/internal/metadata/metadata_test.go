@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/internal"
+)
+
+func TestClientGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/path", r.URL.Path)
+		assert.Equal(t, "value", r.Header.Get("X-Test"))
+		assert.Equal(t, internal.UserAgent, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(" body\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := Config{Endpoint: srv.URL + "/"}.Client()
+	got, err := client.Get(context.Background(), "/path", http.Header{"X-Test": []string{"value"}})
+	require.NoError(t, err)
+	assert.Equal(t, "body", got)
+}
+
+func TestNewConfig(t *testing.T) {
+	assert.Equal(t, Config{Endpoint: "http://default", Timeout: DefaultTimeout}, NewConfig("http://default"))
+}
+
+func TestSettings(t *testing.T) {
+	hc := &http.Client{}
+	cfg := NewConfig("http://default")
+	for _, s := range []Setting{
+		WithEndpoint("http://other"),
+		WithTimeout(time.Second),
+		WithHTTPClient(hc),
+	} {
+		cfg = s(cfg)
+	}
+	assert.Equal(t, Config{Endpoint: "http://other", Timeout: time.Second, HTTPClient: hc}, cfg)
+}
+
+func TestConfigClientSharesTransport(t *testing.T) {
+	a := Config{Endpoint: "http://a"}.Client()
+	b := Config{Endpoint: "http://b"}.Client()
+	assert.Same(t, a.client.Transport, b.client.Transport)
+
+	tr, ok := a.client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Nil(t, tr.Proxy, "metadata services must not be reached through a proxy")
+}
+
+func TestClientGetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"test"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	client := Config{Endpoint: srv.URL}.Client()
+	require.NoError(t, client.GetJSON(context.Background(), "/", nil, &v))
+	assert.Equal(t, "test", v.Name)
+}
+
+func TestClientStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	client := Config{Endpoint: srv.URL}.Client()
+	_, err := client.Get(context.Background(), "/missing", nil)
+
+	var se *StatusError
+	require.True(t, errors.As(err, &se))
+	assert.Equal(t, http.StatusNotFound, se.StatusCode)
+	assert.Equal(t, srv.URL+"/missing", se.URL)
+}
+
+func TestConfigContextTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := Config{Endpoint: srv.URL, Timeout: 10 * time.Millisecond}
+	ctx, cancel := cfg.Context(context.Background())
+	defer cancel()
+
+	_, err := cfg.Client().Get(ctx, "/", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	// k8sServiceHostEnv is set by the kubelet for every container run in a
+	// Kubernetes Pod.
+	k8sServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+
+	// Environment variables expected to be set using the downward API.
+	k8sPodNameEnv       = "K8S_POD_NAME"
+	k8sPodUIDEnv        = "K8S_POD_UID"
+	k8sNamespaceNameEnv = "K8S_NAMESPACE_NAME"
+	k8sNodeNameEnv      = "K8S_NODE_NAME"
+
+	// k8sNamespacePath is the namespace file of the service account token
+	// mounted into the Pod.
+	k8sNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// mountinfoPodUIDRe matches the kubelet Pod directory volumes are mounted
+// from.
+var mountinfoPodUIDRe = regexp.MustCompile(`/pods/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})/`)
+
+type k8sDetector struct{}
+
+var _ Detector = k8sDetector{}
+
+// Detect returns a *Resource that describes the Kubernetes Pod this process
+// is running in. If the process is not running in Kubernetes, an empty
+// resource will be returned.
+func (k8sDetector) Detect(ctx context.Context) (*Resource, error) {
+	if os.Getenv(k8sServiceHostEnv) == "" {
+		return Empty(), nil
+	}
+
+	podName := os.Getenv(k8sPodNameEnv)
+	if podName == "" {
+		// The hostname of a container defaults to the Pod name.
+		podName = os.Getenv("HOSTNAME")
+	}
+
+	podUID := os.Getenv(k8sPodUIDEnv)
+	if podUID == "" {
+		var err error
+		if podUID, err = scanFile(mountinfoPath, getPodUIDFromMountinfoLine); err != nil {
+			return nil, err
+		}
+	}
+
+	namespace := os.Getenv(k8sNamespaceNameEnv)
+	if namespace == "" {
+		var err error
+		if namespace, err = scanFile(k8sNamespacePath, strings.TrimSpace); err != nil {
+			return nil, err
+		}
+	}
+
+	var attrs []attribute.KeyValue
+	for _, kv := range []struct {
+		key   attribute.Key
+		value string
+	}{
+		{semconv.K8SPodNameKey, podName},
+		{semconv.K8SPodUIDKey, podUID},
+		{semconv.K8SNamespaceNameKey, namespace},
+		{semconv.K8SNodeNameKey, os.Getenv(k8sNodeNameEnv)},
+	} {
+		if kv.value != "" {
+			attrs = append(attrs, kv.key.String(kv.value))
+		}
+	}
+	if len(attrs) == 0 {
+		return Empty(), nil
+	}
+	return NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
+
+// getPodUIDFromMountinfoLine returns the UID of the Pod from one line of the
+// mountinfo file.
+func getPodUIDFromMountinfoLine(line string) string {
+	matches := mountinfoPodUIDRe.FindStringSubmatch(line)
+	if len(matches) <= 1 {
+		return ""
+	}
+	return matches[1]
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+const (
+	testPodUID     = "8a3e1c7d-5b2f-4e6a-9d0c-1f2e3d4c5b6a"
+	testMountinfo  = "1013 1002 8:1 /var/lib/kubelet/pods/" + testPodUID + "/etc-hosts /etc/hosts rw,relatime - ext4 /dev/sda1 rw"
+	testNamespace  = "checkout\n"
+	testPodName    = "checkout-7d9f8b6c5-x2x4z"
+	testNodeName   = "node-1"
+	testPodNameEnv = "checkout-7d9f8b6c5-abcde"
+)
+
+func TestK8sDetector(t *testing.T) {
+	t.Cleanup(func() {
+		osStat = defaultOSStat
+		osOpen = defaultOSOpen
+	})
+
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		files    map[string]string
+		expected *Resource
+	}{
+		{
+			name: "not in kubernetes",
+			env: map[string]string{
+				k8sPodNameEnv: testPodNameEnv,
+			},
+			expected: Empty(),
+		},
+		{
+			name: "downward API",
+			env: map[string]string{
+				k8sServiceHostEnv:   "10.0.0.1",
+				k8sPodNameEnv:       testPodNameEnv,
+				k8sPodUIDEnv:        "4b2c0d9e-3f1a-4c5b-8e7d-6a5b4c3d2e1f",
+				k8sNamespaceNameEnv: "payments",
+				k8sNodeNameEnv:      testNodeName,
+			},
+			files: map[string]string{
+				mountinfoPath:    testMountinfo,
+				k8sNamespacePath: testNamespace,
+			},
+			expected: NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.K8SPodNameKey.String(testPodNameEnv),
+				semconv.K8SPodUIDKey.String("4b2c0d9e-3f1a-4c5b-8e7d-6a5b4c3d2e1f"),
+				semconv.K8SNamespaceNameKey.String("payments"),
+				semconv.K8SNodeNameKey.String(testNodeName),
+			),
+		},
+		{
+			name: "fallbacks",
+			env: map[string]string{
+				k8sServiceHostEnv: "10.0.0.1",
+				"HOSTNAME":        testPodName,
+			},
+			files: map[string]string{
+				mountinfoPath:    testMountinfo,
+				k8sNamespacePath: testNamespace,
+			},
+			expected: NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.K8SPodNameKey.String(testPodName),
+				semconv.K8SPodUIDKey.String(testPodUID),
+				semconv.K8SNamespaceNameKey.String("checkout"),
+			),
+		},
+		{
+			name: "nothing found",
+			env: map[string]string{
+				k8sServiceHostEnv: "10.0.0.1",
+			},
+			expected: Empty(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{
+				k8sServiceHostEnv,
+				k8sPodNameEnv,
+				k8sPodUIDEnv,
+				k8sNamespaceNameEnv,
+				k8sNodeNameEnv,
+				"HOSTNAME",
+			} {
+				t.Setenv(k, tc.env[k])
+			}
+			setFakeFiles(tc.files)
+
+			res, err := k8sDetector{}.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
@@ -165,8 +165,12 @@ func (r *Resource) Equal(eq *Resource) bool {
 //
 // The SchemaURL of the resources will be merged according to the spec rules:
 // https://github.com/open-telemetry/opentelemetry-specification/blob/bad49c714a62da5493f2d1d9bafd7ebe8c8ce7eb/specification/resource/sdk.md#merge
-// If the resources have different non-empty schemaURL an empty resource and an error
-// will be returned.
+// If the resources have different non-empty schemaURL that are both known
+// OpenTelemetry schema versions, the resource with the older schema is first
+// upgraded to the newer one by applying the attribute renames of the schema,
+// and the merged resource has the newer schemaURL. Otherwise, if the resources
+// have different non-empty schemaURL an empty resource and an error will be
+// returned.
 func Merge(a, b *Resource) (*Resource, error) {
 	if a == nil && b == nil {
 		return Empty(), nil
@@ -188,7 +192,11 @@ func Merge(a, b *Resource) (*Resource, error) {
 	case a.schemaURL == b.schemaURL:
 		schemaURL = a.schemaURL
 	default:
-		return Empty(), errMergeConflictSchemaURL
+		var err error
+		if a, b, err = upgradeSchemas(a, b); err != nil {
+			return Empty(), err
+		}
+		schemaURL = a.schemaURL
 	}
 
 	// Note: 'b' attributes will overwrite 'a' with last-value-wins in attribute.Key()
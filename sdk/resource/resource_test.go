@@ -185,6 +185,27 @@ func TestMerge(t *testing.T) {
 			want:  nil,
 			isErr: true,
 		},
+		{
+			name:      "Merge with older first schema",
+			a:         resource.NewWithAttributes("https://opentelemetry.io/schemas/1.12.0", kv11),
+			b:         resource.NewWithAttributes("https://opentelemetry.io/schemas/1.14.0", kv21),
+			want:      []attribute.KeyValue{kv11, kv21},
+			schemaURL: "https://opentelemetry.io/schemas/1.14.0",
+		},
+		{
+			name:      "Merge with older second schema",
+			a:         resource.NewWithAttributes("https://opentelemetry.io/schemas/1.14.0", kv41),
+			b:         resource.NewWithAttributes("https://opentelemetry.io/schemas/1.4.0", kv42),
+			want:      []attribute.KeyValue{kv42},
+			schemaURL: "https://opentelemetry.io/schemas/1.14.0",
+		},
+		{
+			name:  "Merge with unknown schema",
+			a:     resource.NewWithAttributes("https://opentelemetry.io/schemas/1.14.0", kv41),
+			b:     resource.NewWithAttributes("https://example.com/schemas/1.0.0", kv42),
+			want:  nil,
+			isErr: true,
+		},
 	}
 	for _, c := range cases {
 		t.Run(fmt.Sprintf("case-%s", c.name), func(t *testing.T) {
@@ -409,6 +430,20 @@ func TestNew(t *testing.T) {
 			schemaURL:      "",
 			isErr:          true,
 		},
+		{
+			name:   "With upgraded schema url",
+			envars: "",
+			options: []resource.Option{
+				resource.WithDetectors(
+					resource.StringDetector("https://opentelemetry.io/schemas/1.12.0", semconv.HostNameKey, func() (string, error) { return "host", nil }),
+				),
+				resource.WithSchemaURL("https://opentelemetry.io/schemas/1.14.0"),
+			},
+			resourceValues: map[string]string{
+				string(semconv.HostNameKey): "host",
+			},
+			schemaURL: "https://opentelemetry.io/schemas/1.14.0",
+		},
 		{
 			name:   "With conflicting detector schema urls",
 			envars: "",
@@ -703,6 +738,24 @@ func TestWithContainerID(t *testing.T) {
 	}
 }
 
+func TestWithHostID(t *testing.T) {
+	t.Cleanup(restoreAttributesProviders)
+
+	fakeHostID := "fake-host-id"
+	resource.SetHostIDProvider(func() (string, error) {
+		return fakeHostID, nil
+	})
+
+	res, err := resource.New(context.Background(),
+		resource.WithHostID(),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		string(semconv.HostIDKey): fakeHostID,
+	}, toMap(res))
+}
+
 func TestWithContainer(t *testing.T) {
 	t.Cleanup(restoreAttributesProviders)
 
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// otelSchemaURLPrefix is the prefix of the OpenTelemetry schema URLs. It is
+// followed by the schema version.
+const otelSchemaURLPrefix = "https://opentelemetry.io/schemas/"
+
+// schemaVersion is a version of the OpenTelemetry schema.
+type schemaVersion struct {
+	version string
+	// renames maps the resource attribute keys of the previous version to
+	// their key in this version. These are the rename_attributes changes of
+	// the "all" and "resources" sections of the schema file.
+	renames map[attribute.Key]attribute.Key
+}
+
+// schemaVersions are the OpenTelemetry schema versions resources can be
+// upgraded between, in ascending order.
+//
+// None of these versions renames resource attributes, only span attributes
+// are renamed (https://opentelemetry.io/schemas/1.14.0).
+var schemaVersions = []schemaVersion{
+	{version: "1.4.0"},
+	{version: "1.5.0"},
+	{version: "1.6.1"},
+	{version: "1.7.0"},
+	{version: "1.8.0"},
+	{version: "1.9.0"},
+	{version: "1.10.0"},
+	{version: "1.11.0"},
+	{version: "1.12.0"},
+	{version: "1.13.0"},
+	{version: "1.14.0"},
+}
+
+// schemaIndex returns the index in schemaVersions of the OpenTelemetry schema
+// identified by schemaURL. If schemaURL does not identify a known version, -1
+// is returned.
+func schemaIndex(schemaURL string) int {
+	if !strings.HasPrefix(schemaURL, otelSchemaURLPrefix) {
+		return -1
+	}
+	version := strings.TrimPrefix(schemaURL, otelSchemaURLPrefix)
+	for i, v := range schemaVersions {
+		if v.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// upgradeSchemas returns a and b transformed to the newest of their schemas.
+// If either schema is not a known OpenTelemetry schema version,
+// errMergeConflictSchemaURL is returned.
+func upgradeSchemas(a, b *Resource) (*Resource, *Resource, error) {
+	ia, ib := schemaIndex(a.schemaURL), schemaIndex(b.schemaURL)
+	if ia < 0 || ib < 0 {
+		return nil, nil, errMergeConflictSchemaURL
+	}
+	if ia < ib {
+		return upgrade(a, ia, ib), b, nil
+	}
+	return a, upgrade(b, ib, ia), nil
+}
+
+// upgrade returns r transformed from the schema version at index from to the
+// one at index to in schemaVersions. If an attribute is renamed to a key r
+// already contains, the value of the existing attribute is kept.
+func upgrade(r *Resource, from, to int) *Resource {
+	attrs := r.Attributes()
+	for _, v := range schemaVersions[from+1 : to+1] {
+		if len(v.renames) == 0 {
+			continue
+		}
+		renamed := make([]attribute.KeyValue, 0, len(attrs))
+		kept := make([]attribute.KeyValue, 0, len(attrs))
+		for _, kv := range attrs {
+			if k, ok := v.renames[kv.Key]; ok {
+				renamed = append(renamed, attribute.KeyValue{Key: k, Value: kv.Value})
+			} else {
+				kept = append(kept, kv)
+			}
+		}
+		// Last value wins, existing attributes take precedence.
+		attrs = append(renamed, kept...)
+	}
+	return NewWithAttributes(otelSchemaURLPrefix+schemaVersions[to].version, attrs...)
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv1100 "go.opentelemetry.io/otel/semconv/v1.10.0"
+	semconv1110 "go.opentelemetry.io/otel/semconv/v1.11.0"
+	semconv1120 "go.opentelemetry.io/otel/semconv/v1.12.0"
+	semconv1130 "go.opentelemetry.io/otel/semconv/v1.13.0"
+	semconv1140 "go.opentelemetry.io/otel/semconv/v1.14.0"
+	semconv140 "go.opentelemetry.io/otel/semconv/v1.4.0"
+	semconv150 "go.opentelemetry.io/otel/semconv/v1.5.0"
+	semconv161 "go.opentelemetry.io/otel/semconv/v1.6.1"
+	semconv170 "go.opentelemetry.io/otel/semconv/v1.7.0"
+	semconv180 "go.opentelemetry.io/otel/semconv/v1.8.0"
+	semconv190 "go.opentelemetry.io/otel/semconv/v1.9.0"
+)
+
+func TestSchemaIndex(t *testing.T) {
+	assert.Equal(t, 0, schemaIndex("https://opentelemetry.io/schemas/1.4.0"))
+	assert.Equal(t, len(schemaVersions)-1, schemaIndex("https://opentelemetry.io/schemas/1.14.0"))
+	assert.Equal(t, -1, schemaIndex("https://opentelemetry.io/schemas/1.3.0"))
+	assert.Equal(t, -1, schemaIndex("https://example.com/schemas/1.4.0"))
+	assert.Equal(t, -1, schemaIndex(""))
+}
+
+// TestSchemaVersionsCoverSemconv ensures every semconv package can be
+// upgraded. Add the version to schemaVersions, and the package to this test,
+// when a semconv package is added.
+func TestSchemaVersionsCoverSemconv(t *testing.T) {
+	urls := []string{
+		semconv140.SchemaURL,
+		semconv150.SchemaURL,
+		semconv161.SchemaURL,
+		semconv170.SchemaURL,
+		semconv180.SchemaURL,
+		semconv190.SchemaURL,
+		semconv1100.SchemaURL,
+		semconv1110.SchemaURL,
+		semconv1120.SchemaURL,
+		semconv1130.SchemaURL,
+		semconv1140.SchemaURL,
+	}
+	for _, u := range urls {
+		assert.GreaterOrEqualf(t, schemaIndex(u), 0, "%s missing from schemaVersions", u)
+	}
+
+	dirs, err := filepath.Glob(filepath.Join("..", "..", "semconv", "v*"))
+	require.NoError(t, err)
+	var versions []string
+	for _, d := range dirs {
+		if _, err := os.Stat(filepath.Join(d, "schema.go")); err == nil {
+			versions = append(versions, strings.TrimPrefix(filepath.Base(d), "v"))
+		}
+	}
+	var known []string
+	for _, v := range schemaVersions {
+		known = append(known, v.version)
+	}
+	assert.ElementsMatch(t, versions, known, "semconv packages and schemaVersions differ")
+}
+
+func TestUpgrade(t *testing.T) {
+	orig := schemaVersions
+	t.Cleanup(func() { schemaVersions = orig })
+	schemaVersions = []schemaVersion{
+		{version: "1.0.0"},
+		{version: "1.1.0", renames: map[attribute.Key]attribute.Key{"a": "b", "m": "n"}},
+		{version: "1.2.0"},
+		{version: "1.3.0", renames: map[attribute.Key]attribute.Key{"b": "c"}},
+	}
+
+	testCases := []struct {
+		name     string
+		from, to int
+		attrs    []attribute.KeyValue
+		want     []attribute.KeyValue
+	}{
+		{
+			name:  "single rename",
+			from:  0,
+			to:    1,
+			attrs: []attribute.KeyValue{attribute.String("a", "1"), attribute.String("k", "1")},
+			want:  []attribute.KeyValue{attribute.String("b", "1"), attribute.String("k", "1")},
+		},
+		{
+			name:  "chained renames",
+			from:  0,
+			to:    3,
+			attrs: []attribute.KeyValue{attribute.String("a", "1"), attribute.String("m", "1")},
+			want:  []attribute.KeyValue{attribute.String("c", "1"), attribute.String("n", "1")},
+		},
+		{
+			name:  "skips earlier versions",
+			from:  1,
+			to:    3,
+			attrs: []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")},
+			want:  []attribute.KeyValue{attribute.String("a", "1"), attribute.String("c", "2")},
+		},
+		{
+			name:  "existing key kept",
+			from:  0,
+			to:    1,
+			attrs: []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")},
+			want:  []attribute.KeyValue{attribute.String("b", "2")},
+		},
+		{
+			name:  "same version",
+			from:  3,
+			to:    3,
+			attrs: []attribute.KeyValue{attribute.String("b", "1")},
+			want:  []attribute.KeyValue{attribute.String("b", "1")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewWithAttributes(otelSchemaURLPrefix+schemaVersions[tc.from].version, tc.attrs...)
+			got := upgrade(r, tc.from, tc.to)
+			assert.Equal(t, otelSchemaURLPrefix+schemaVersions[tc.to].version, got.SchemaURL())
+			assert.Equal(t, tc.want, got.Attributes())
+		})
+	}
+}
+
+func TestMergeUpgradesSchema(t *testing.T) {
+	orig := schemaVersions
+	t.Cleanup(func() { schemaVersions = orig })
+	schemaVersions = []schemaVersion{
+		{version: "1.0.0"},
+		{version: "1.1.0", renames: map[attribute.Key]attribute.Key{"a": "b"}},
+		{version: "1.2.0"},
+		{version: "1.3.0", renames: map[attribute.Key]attribute.Key{"b": "c", "x": "y"}},
+	}
+
+	const (
+		v100 = "https://opentelemetry.io/schemas/1.0.0"
+		v120 = "https://opentelemetry.io/schemas/1.2.0"
+		v130 = "https://opentelemetry.io/schemas/1.3.0"
+	)
+
+	testCases := []struct {
+		name string
+		a, b *Resource
+		want *Resource
+	}{
+		{
+			name: "upgrade first",
+			a:    NewWithAttributes(v100, attribute.String("a", "1"), attribute.String("k", "1")),
+			b:    NewWithAttributes(v130, attribute.String("k", "2")),
+			want: NewWithAttributes(v130, attribute.String("c", "1"), attribute.String("k", "2")),
+		},
+		{
+			name: "upgrade second",
+			a:    NewWithAttributes(v130, attribute.String("c", "2")),
+			b:    NewWithAttributes(v120, attribute.String("b", "1")),
+			want: NewWithAttributes(v130, attribute.String("c", "1")),
+		},
+		{
+			name: "no renames",
+			a:    NewWithAttributes(v120, attribute.String("b", "1")),
+			b:    NewWithAttributes(v100, attribute.String("k", "1")),
+			want: NewWithAttributes(v120, attribute.String("b", "1"), attribute.String("k", "1")),
+		},
+		{
+			name: "existing key kept",
+			a:    NewWithAttributes(v120, attribute.String("x", "1"), attribute.String("y", "2")),
+			b:    NewWithAttributes(v130, attribute.String("k", "1")),
+			want: NewWithAttributes(v130, attribute.String("y", "2"), attribute.String("k", "1")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Merge(tc.a, tc.b)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want.SchemaURL(), got.SchemaURL())
+			assert.Equal(t, tc.want.Attributes(), got.Attributes())
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfobs // import "go.opentelemetry.io/otel/sdk/selfobs"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const bspMeterName = "go.opentelemetry.io/otel/sdk/trace"
+
+// bspInstruments are the instruments shared by all batch span processors
+// reporting to a provider.
+type bspInstruments struct {
+	dropped        syncint64.Counter
+	exported       syncint64.Counter
+	exportDuration syncfloat64.Histogram
+
+	mu     sync.Mutex
+	queues map[*bsp]func() (int, int)
+}
+
+func newBSPInstruments(mp metric.MeterProvider) *bspInstruments {
+	meter := mp.Meter(bspMeterName, metric.WithInstrumentationVersion(otel.Version()))
+	i := &bspInstruments{queues: make(map[*bsp]func() (int, int))}
+
+	var err error
+	handle := func(e error) {
+		if e != nil && err == nil {
+			err = e
+		}
+	}
+
+	_, e := meter.Int64ObservableUpDownCounter(
+		"otel.sdk.span_processor.queue.size",
+		instrument.WithUnit("{span}"),
+		instrument.WithDescription("The number of spans in the queues waiting to be exported."),
+		instrument.WithInt64Callback(func(ctx context.Context, o instrument.Int64Observer) error {
+			length, _ := i.queueSums()
+			o.Observe(ctx, length)
+			return nil
+		}),
+	)
+	handle(e)
+	_, e = meter.Int64ObservableUpDownCounter(
+		"otel.sdk.span_processor.queue.capacity",
+		instrument.WithUnit("{span}"),
+		instrument.WithDescription("The maximum number of spans the queues can hold."),
+		instrument.WithInt64Callback(func(ctx context.Context, o instrument.Int64Observer) error {
+			_, capacity := i.queueSums()
+			o.Observe(ctx, capacity)
+			return nil
+		}),
+	)
+	handle(e)
+	i.dropped, e = meter.Int64Counter(
+		"otel.sdk.span_processor.spans.dropped",
+		instrument.WithUnit("{span}"),
+		instrument.WithDescription("The number of spans dropped because the queue was full."),
+	)
+	handle(e)
+	i.exported, e = meter.Int64Counter(
+		"otel.sdk.span_processor.spans.exported",
+		instrument.WithUnit("{span}"),
+		instrument.WithDescription("The number of spans passed to the exporter, by the outcome of the export."),
+	)
+	handle(e)
+	i.exportDuration, e = meter.Float64Histogram(
+		"otel.sdk.span_processor.export.duration",
+		instrument.WithUnit(unit.Unit("s")),
+		instrument.WithDescription("The duration of exporting a batch of spans, by the outcome of the export."),
+	)
+	handle(e)
+
+	if err != nil {
+		otel.Handle(err)
+	}
+	return i
+}
+
+// queueSums returns the sum of the lengths and capacities of the queues of
+// all batch span processors not yet shut down.
+func (i *bspInstruments) queueSums() (length, capacity int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, q := range i.queues {
+		l, c := q()
+		length += int64(l)
+		capacity += int64(c)
+	}
+	return length, capacity
+}
+
+// add returns the receiver of a batch span processor with queue.
+func (i *bspInstruments) add(queue func() (int, int)) *bsp {
+	b := &bsp{i: i}
+	i.mu.Lock()
+	i.queues[b] = queue
+	i.mu.Unlock()
+	return b
+}
+
+// bsp records the events of a batch span processor.
+type bsp struct {
+	i *bspInstruments
+}
+
+var _ observ.BatchSpanProcessor = (*bsp)(nil)
+
+func (b *bsp) Dropped(ctx context.Context) {
+	if b.i.dropped != nil {
+		b.i.dropped.Add(ctx, 1)
+	}
+}
+
+func (b *bsp) Exported(ctx context.Context, n int, d time.Duration, err error) {
+	o := outcome(err)
+	if b.i.exported != nil {
+		b.i.exported.Add(ctx, int64(n), o)
+	}
+	if b.i.exportDuration != nil {
+		b.i.exportDuration.Record(ctx, d.Seconds(), o)
+	}
+}
+
+// Shutdown stops the queue of the batch span processor from being observed.
+func (b *bsp) Shutdown() {
+	b.i.mu.Lock()
+	delete(b.i.queues, b)
+	b.i.mu.Unlock()
+}
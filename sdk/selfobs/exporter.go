@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfobs // import "go.opentelemetry.io/otel/sdk/selfobs"
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// exporter records the events of an exporter client.
+type exporter struct {
+	requests syncint64.Counter
+	retries  syncint64.Counter
+	rejected syncint64.Counter
+}
+
+var _ observ.Exporter = (*exporter)(nil)
+
+// newExporter returns an exporter recording with a meter from mp named
+// scope. Rejected items are counted as item, e.g. "spans" or "data_points".
+func newExporter(mp metric.MeterProvider, scope, item string) *exporter {
+	meter := mp.Meter(scope, metric.WithInstrumentationVersion(otel.Version()))
+
+	var (
+		e   exporter
+		err error
+	)
+	e.requests, err = meter.Int64Counter(
+		"otel.exporter.otlp.requests",
+		instrument.WithUnit("{request}"),
+		instrument.WithDescription("The number of export requests, by the outcome of the request after all retries."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	e.retries, err = meter.Int64Counter(
+		"otel.exporter.otlp.retries",
+		instrument.WithUnit("{request}"),
+		instrument.WithDescription("The number of times an export request was retried."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	e.rejected, err = meter.Int64Counter(
+		"otel.exporter.otlp."+item+".rejected",
+		// Units are singular, e.g. "{span}" for "spans".
+		instrument.WithUnit(unit.Unit("{"+strings.TrimSuffix(item, "s")+"}")),
+		instrument.WithDescription("The number of "+strings.ReplaceAll(item, "_", " ")+" the receiver reported as rejected in a partial success response."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &e
+}
+
+func (e *exporter) Request(ctx context.Context, err error) {
+	if e.requests != nil {
+		e.requests.Add(ctx, 1, outcome(err))
+	}
+}
+
+func (e *exporter) Retry(ctx context.Context) {
+	if e.retries != nil {
+		e.retries.Add(ctx, 1)
+	}
+}
+
+func (e *exporter) Rejected(ctx context.Context, n int64) {
+	if e.rejected != nil && n > 0 {
+		e.rejected.Add(ctx, n)
+	}
+}
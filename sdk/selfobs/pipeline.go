@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfobs // import "go.opentelemetry.io/otel/sdk/selfobs"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const pipelineMeterName = "go.opentelemetry.io/otel/sdk/metric"
+
+// pipeline records the events of a metric SDK pipeline.
+type pipeline struct {
+	collectionDuration syncfloat64.Histogram
+	callbackErrors     syncint64.Counter
+}
+
+var _ observ.Pipeline = (*pipeline)(nil)
+
+func newPipeline(mp metric.MeterProvider) *pipeline {
+	meter := mp.Meter(pipelineMeterName, metric.WithInstrumentationVersion(otel.Version()))
+
+	var (
+		p   pipeline
+		err error
+	)
+	p.collectionDuration, err = meter.Float64Histogram(
+		"otel.sdk.metric_reader.collection.duration",
+		instrument.WithUnit(unit.Unit("s")),
+		instrument.WithDescription("The duration of a Reader collection, by the outcome of the collection."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	p.callbackErrors, err = meter.Int64Counter(
+		"otel.sdk.metric_reader.callback.errors",
+		instrument.WithUnit("{error}"),
+		instrument.WithDescription("The number of errors returned by instrument callbacks during collection."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &p
+}
+
+func (p *pipeline) Collected(ctx context.Context, d time.Duration, err error) {
+	if p.collectionDuration != nil {
+		p.collectionDuration.Record(ctx, d.Seconds(), outcome(err))
+	}
+}
+
+func (p *pipeline) CallbackError(ctx context.Context) {
+	if p.callbackErrors != nil {
+		p.callbackErrors.Add(ctx, 1)
+	}
+}
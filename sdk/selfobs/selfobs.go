@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfobs records metrics about the operation of the OpenTelemetry
+// SDK and exporters.
+//
+// Self-observability is opt-in. Call SetMeterProvider with the MeterProvider
+// the metrics are to be recorded with. Components created before it is called
+// are observed as well.
+//
+// The following components are observed.
+//
+// Batch span processors from go.opentelemetry.io/otel/sdk/trace record
+//   - otel.sdk.span_processor.queue.size: spans waiting to be exported
+//   - otel.sdk.span_processor.queue.capacity: spans the queues can hold
+//   - otel.sdk.span_processor.spans.dropped: spans dropped from full queues
+//   - otel.sdk.span_processor.spans.exported: spans passed to exporters, by outcome
+//   - otel.sdk.span_processor.export.duration: export duration, by outcome
+//
+// OTLP exporters from go.opentelemetry.io/otel/exporters/otlp record
+//   - otel.exporter.otlp.requests: export requests after all retries, by outcome
+//   - otel.exporter.otlp.retries: retried export requests
+//   - otel.exporter.otlp.spans.rejected: spans rejected by the receiver
+//   - otel.exporter.otlp.data_points.rejected: data points rejected by the receiver
+//
+// MeterProviders from go.opentelemetry.io/otel/sdk/metric record
+//   - otel.sdk.metric_reader.collection.duration: collection duration, by outcome
+//   - otel.sdk.metric_reader.callback.errors: errors returned by callbacks
+//
+// The outcome of an operation is recorded as the "outcome" attribute with
+// the value "success" or "failure".
+package selfobs // import "go.opentelemetry.io/otel/sdk/selfobs"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	outcomeSuccess = attribute.String("outcome", "success")
+	outcomeFailure = attribute.String("outcome", "failure")
+)
+
+// outcome returns the outcome attribute for an operation that returned err.
+func outcome(err error) attribute.KeyValue {
+	if err != nil {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
+
+// SetMeterProvider sets mp as the MeterProvider self-observability metrics
+// are recorded with.
+//
+// All components record metrics with the MeterProvider most recently set,
+// whether they were created before or after this is called. If mp is nil,
+// components stop recording metrics.
+//
+// If mp is a metric SDK MeterProvider, it records the metrics of its own
+// collections as well.
+func SetMeterProvider(mp metric.MeterProvider) {
+	if mp == nil {
+		observ.SetProvider(nil)
+		return
+	}
+	observ.SetProvider(newProvider(mp))
+}
+
+// provider is the observ.Provider recording metrics with a MeterProvider.
+type provider struct {
+	mp metric.MeterProvider
+
+	// bsp are created with the first batch span processor so their
+	// observable instruments are only reported when there is one.
+	bspOnce sync.Once
+	bsp     *bspInstruments
+}
+
+var _ observ.Provider = (*provider)(nil)
+
+func newProvider(mp metric.MeterProvider) *provider {
+	return &provider{mp: mp}
+}
+
+func (p *provider) BatchSpanProcessor(queue func() (int, int)) observ.BatchSpanProcessor {
+	p.bspOnce.Do(func() { p.bsp = newBSPInstruments(p.mp) })
+	return p.bsp.add(queue)
+}
+
+func (p *provider) Exporter(scope, item string) observ.Exporter {
+	return newExporter(p.mp, scope, item)
+}
+
+func (p *provider) Pipeline() observ.Pipeline {
+	return newPipeline(p.mp)
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/metric/instrument"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T) sdkmetric.Reader {
+	r := sdkmetric.NewManualReader()
+	SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(r)))
+	t.Cleanup(func() { SetMeterProvider(nil) })
+	return r
+}
+
+// collect returns the int64 sums and histogram counts collected by r, keyed
+// by metric name and attributes.
+func collect(t *testing.T, r sdkmetric.Reader) map[string]map[attribute.Set]int64 {
+	rm, err := r.Collect(context.Background())
+	require.NoError(t, err)
+	got := make(map[string]map[attribute.Set]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			values := make(map[attribute.Set]int64)
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[dp.Attributes] += dp.Value
+				}
+			case metricdata.Histogram:
+				for _, dp := range data.DataPoints {
+					values[dp.Attributes] += int64(dp.Count)
+				}
+			default:
+				t.Fatalf("unexpected data type %T for %s", m.Data, m.Name)
+			}
+			got[m.Name] = values
+		}
+	}
+	return got
+}
+
+var (
+	none    = attribute.NewSet()
+	success = attribute.NewSet(outcomeSuccess)
+	failure = attribute.NewSet(outcomeFailure)
+)
+
+type errExporter struct{ err error }
+
+func (e *errExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error { return e.err }
+func (e *errExporter) Shutdown(context.Context) error                             { return nil }
+
+func TestBatchSpanProcessor(t *testing.T) {
+	r := setup(t)
+	ctx := context.Background()
+
+	exp := &errExporter{}
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithMaxQueueSize(10))
+	other := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithMaxQueueSize(5))
+
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	spans := tracetest.SpanStubs{{SpanContext: sampled}, {SpanContext: sampled}}.Snapshots()
+	for _, s := range spans {
+		bsp.OnEnd(s)
+	}
+	require.NoError(t, bsp.ForceFlush(ctx))
+	exp.err = assert.AnError
+	bsp.OnEnd(spans[0])
+	assert.ErrorIs(t, bsp.ForceFlush(ctx), assert.AnError)
+
+	got := collect(t, r)
+	assert.Equal(t, map[attribute.Set]int64{none: 15}, got["otel.sdk.span_processor.queue.capacity"])
+	assert.Equal(t, map[attribute.Set]int64{none: 0}, got["otel.sdk.span_processor.queue.size"])
+	assert.Equal(t, map[attribute.Set]int64{success: 2, failure: 1}, got["otel.sdk.span_processor.spans.exported"])
+	assert.Equal(t, map[attribute.Set]int64{success: 1, failure: 1}, got["otel.sdk.span_processor.export.duration"])
+
+	require.NoError(t, other.Shutdown(ctx))
+	got = collect(t, r)
+	assert.Equal(t, map[attribute.Set]int64{none: 10}, got["otel.sdk.span_processor.queue.capacity"])
+	require.NoError(t, bsp.Shutdown(ctx))
+}
+
+func TestExporter(t *testing.T) {
+	r := setup(t)
+	ctx := context.Background()
+
+	e := observ.GetProvider().Exporter("test", "data_points")
+	e.Request(ctx, nil)
+	e.Retry(ctx)
+	e.Request(ctx, assert.AnError)
+	e.Rejected(ctx, 3)
+	e.Rejected(ctx, 0)
+
+	assert.Equal(t, map[string]map[attribute.Set]int64{
+		"otel.exporter.otlp.requests":             {success: 1, failure: 1},
+		"otel.exporter.otlp.retries":              {none: 1},
+		"otel.exporter.otlp.data_points.rejected": {none: 3},
+	}, collect(t, r))
+}
+
+func TestPipeline(t *testing.T) {
+	r := setup(t)
+
+	rdr := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(rdr))
+	errCallback := errors.New("callback failed")
+	_, err := mp.Meter("test").Int64ObservableGauge(
+		"gauge",
+		instrument.WithInt64Callback(func(context.Context, instrument.Int64Observer) error {
+			return errCallback
+		}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = rdr.Collect(ctx)
+	assert.ErrorContains(t, err, errCallback.Error())
+
+	assert.Equal(t, map[string]map[attribute.Set]int64{
+		"otel.sdk.metric_reader.collection.duration": {failure: 1},
+		"otel.sdk.metric_reader.callback.errors":     {none: 1},
+	}, collect(t, r))
+}
+
+func TestSetMeterProviderAfterCreation(t *testing.T) {
+	ctx := context.Background()
+	e := observ.GetProvider().Exporter("test", "spans")
+	e.Request(ctx, nil)
+
+	r := setup(t)
+	e.Request(ctx, assert.AnError)
+	assert.Equal(t, map[string]map[attribute.Set]int64{
+		"otel.exporter.otlp.requests": {failure: 1},
+	}, collect(t, r))
+}
+
+func TestSetMeterProviderNil(t *testing.T) {
+	r := setup(t)
+	ctx := context.Background()
+	e := observ.GetProvider().Exporter("test", "spans")
+	e.Request(ctx, nil)
+
+	SetMeterProvider(nil)
+	e.Request(ctx, assert.AnError)
+	assert.Equal(t, map[string]map[attribute.Set]int64{
+		"otel.exporter.otlp.requests": {success: 1},
+	}, collect(t, r))
+}
@@ -23,6 +23,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/internal/observ"
 	"go.opentelemetry.io/otel/sdk/internal/env"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -77,6 +78,7 @@ type batchSpanProcessor struct {
 
 	queue   chan ReadOnlySpan
 	dropped uint32
+	observ  observ.BatchSpanProcessor
 
 	batch      []ReadOnlySpan
 	batchMutex sync.Mutex
@@ -121,6 +123,9 @@ func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorO
 		queue:  make(chan ReadOnlySpan, o.MaxQueueSize),
 		stopCh: make(chan struct{}),
 	}
+	bsp.observ = observ.GetProvider().BatchSpanProcessor(func() (int, int) {
+		return len(bsp.queue), cap(bsp.queue)
+	})
 
 	bsp.stopWait.Add(1)
 	go func() {
@@ -153,6 +158,7 @@ func (bsp *batchSpanProcessor) Shutdown(ctx context.Context) error {
 		go func() {
 			close(bsp.stopCh)
 			bsp.stopWait.Wait()
+			bsp.observ.Shutdown()
 			if bsp.e != nil {
 				if err := bsp.e.Shutdown(ctx); err != nil {
 					otel.Handle(err)
@@ -266,7 +272,9 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 
 	if l := len(bsp.batch); l > 0 {
 		global.Debug("exporting spans", "count", len(bsp.batch), "total_dropped", atomic.LoadUint32(&bsp.dropped))
+		start := time.Now()
 		err := bsp.e.ExportSpans(ctx, bsp.batch)
+		bsp.observ.Exported(ctx, l, time.Since(start), err)
 
 		// A new batch is always created after exporting, even if the batch failed to be exported.
 		//
@@ -414,6 +422,7 @@ func (bsp *batchSpanProcessor) enqueueDrop(ctx context.Context, sd ReadOnlySpan)
 		return true
 	default:
 		atomic.AddUint32(&bsp.dropped, 1)
+		bsp.observ.Dropped(ctx)
 	}
 	return false
 }
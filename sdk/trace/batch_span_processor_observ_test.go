@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/internal/observ"
+	"go.opentelemetry.io/otel/internal/observ/observtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type errExporter struct{ err error }
+
+func (e errExporter) ExportSpans(context.Context, []ReadOnlySpan) error { return e.err }
+func (e errExporter) Shutdown(context.Context) error                    { return nil }
+
+func sampledSpan() ReadOnlySpan {
+	return &recordingSpan{spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})}
+}
+
+func TestBatchSpanProcessorObserv(t *testing.T) {
+	r := observtest.Install(t)
+	exp := &errExporter{}
+	bsp := NewBatchSpanProcessor(exp, WithMaxQueueSize(10))
+	ctx := context.Background()
+
+	queues := r.Queues()
+	require.Len(t, queues, 1)
+	_, capacity := queues[0]()
+	assert.Equal(t, 10, capacity)
+
+	bsp.OnEnd(sampledSpan())
+	bsp.OnEnd(sampledSpan())
+	require.NoError(t, bsp.ForceFlush(ctx))
+
+	exp.err = assert.AnError
+	bsp.OnEnd(sampledSpan())
+	assert.ErrorIs(t, bsp.ForceFlush(ctx), assert.AnError)
+
+	require.NoError(t, bsp.Shutdown(ctx))
+
+	assert.Equal(t, observtest.Counts{
+		Exported:          2,
+		ExportFailed:      1,
+		Exports:           2,
+		ProcessorShutdown: 1,
+	}, r.Counts())
+}
+
+func TestBatchSpanProcessorObservDropped(t *testing.T) {
+	r := observtest.Install(t)
+	bsp := &batchSpanProcessor{
+		queue:  make(chan ReadOnlySpan, 1),
+		stopCh: make(chan struct{}),
+	}
+	bsp.observ = observ.GetProvider().BatchSpanProcessor(func() (int, int) {
+		return len(bsp.queue), cap(bsp.queue)
+	})
+
+	ctx := context.Background()
+	assert.True(t, bsp.enqueueDrop(ctx, sampledSpan()))
+	length, _ := r.Queues()[0]()
+	assert.Equal(t, 1, length)
+	assert.False(t, bsp.enqueueDrop(ctx, sampledSpan()))
+	assert.False(t, bsp.enqueueDrop(ctx, sampledSpan()))
+	assert.Equal(t, 2, r.Counts().Dropped)
+}
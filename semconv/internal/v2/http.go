@@ -210,6 +210,83 @@ func (c *HTTPConv) ServerRequest(req *http.Request) []attribute.KeyValue {
 	return attrs
 }
 
+// ClientRequestMetrics returns the low-cardinality attributes used for HTTP
+// client metrics of req that received a response with the status code. The
+// following attributes are always returned: "http.method", "http.flavor",
+// "net.peer.name". The following attributes are returned if the related
+// values are defined: "net.peer.port", "http.status_code".
+//
+// A code of 0 means no response was received.
+func (c *HTTPConv) ClientRequestMetrics(req *http.Request, code int) []attribute.KeyValue {
+	n := 3 // Method, proto, and peer name.
+	var h string
+	if req.URL != nil {
+		h = req.URL.Host
+	}
+	peer, p := firstHostPort(h, req.Header.Get("Host"))
+	port := requiredHTTPPort(req.TLS != nil, p)
+	if port > 0 {
+		n++
+	}
+	if code > 0 {
+		n++
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+
+	attrs = append(attrs, c.method(req.Method))
+	attrs = append(attrs, c.proto(req.Proto))
+	attrs = append(attrs, c.NetConv.PeerName(peer))
+	if port > 0 {
+		attrs = append(attrs, c.NetConv.PeerPort(port))
+	}
+	if code > 0 {
+		attrs = append(attrs, c.HTTPStatusCodeKey.Int(code))
+	}
+	return attrs
+}
+
+// ServerRequestMetrics returns the low-cardinality attributes used for HTTP
+// server metrics of req that matched route and was responded to with the
+// status code. The following attributes are always returned: "http.method",
+// "http.scheme", "http.flavor", "net.host.name". The following attributes
+// are returned if the related values are defined: "net.host.port",
+// "http.route", "http.status_code".
+//
+// The route is the matched route template (e.g. "/users/{id}"), never the
+// request path. An empty route means it is not known, and a code of 0 means
+// no response has been sent, or it is not yet known (i.e. for the active
+// requests metric).
+func (c *HTTPConv) ServerRequestMetrics(req *http.Request, code int, route string) []attribute.KeyValue {
+	n := 4 // Method, scheme, proto, and host name.
+	host, p := splitHostPort(req.Host)
+	hostPort := requiredHTTPPort(req.TLS != nil, p)
+	if hostPort > 0 {
+		n++
+	}
+	if route != "" {
+		n++
+	}
+	if code > 0 {
+		n++
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+
+	attrs = append(attrs, c.method(req.Method))
+	attrs = append(attrs, c.scheme(req.TLS != nil))
+	attrs = append(attrs, c.proto(req.Proto))
+	attrs = append(attrs, c.NetConv.HostName(host))
+	if hostPort > 0 {
+		attrs = append(attrs, c.NetConv.HostPort(hostPort))
+	}
+	if route != "" {
+		attrs = append(attrs, c.HTTPRouteKey.String(route))
+	}
+	if code > 0 {
+		attrs = append(attrs, c.HTTPStatusCodeKey.Int(code))
+	}
+	return attrs
+}
+
 func (c *HTTPConv) method(method string) attribute.KeyValue {
 	if method == "" {
 		return c.HTTPMethodKey.String(http.MethodGet)
@@ -170,6 +170,66 @@ func TestHTTPServerRequestFailsGracefully(t *testing.T) {
 	assert.ElementsMatch(t, want, got)
 }
 
+func TestHTTPClientRequestMetrics(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com:8443/resource?id=1", nil)
+	require.NoError(t, err)
+
+	got := hc.ClientRequestMetrics(req, http.StatusCreated)
+	assert.Equal(t, 5, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.method", "POST"),
+		attribute.String("http.flavor", "1.1"),
+		attribute.String("net.peer.name", "example.com"),
+		attribute.Int("net.peer.port", 8443),
+		attribute.Int("http.status_code", http.StatusCreated),
+	}, got)
+}
+
+func TestHTTPClientRequestMetricsRequired(t *testing.T) {
+	req := new(http.Request)
+	var got []attribute.KeyValue
+	assert.NotPanics(t, func() { got = hc.ClientRequestMetrics(req, 0) })
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.flavor", ""),
+		attribute.String("net.peer.name", ""),
+	}, got)
+}
+
+func TestHTTPServerRequestMetrics(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com:8080/users/123?x=y", nil)
+	req.RemoteAddr = "10.0.0.1:52342"
+	req.Header.Set("User-Agent", "Go-http-client/1.1")
+
+	got := hc.ServerRequestMetrics(req, http.StatusOK, "/users/{id}")
+	assert.Equal(t, 7, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.scheme", "http"),
+		attribute.String("http.flavor", "1.1"),
+		attribute.String("net.host.name", "example.com"),
+		attribute.Int("net.host.port", 8080),
+		attribute.String("http.route", "/users/{id}"),
+		attribute.Int("http.status_code", http.StatusOK),
+	}, got)
+
+	got = hc.ServerRequestMetrics(req, http.StatusOK, "")
+	assert.Equal(t, 6, cap(got), "slice capacity")
+	assert.NotContains(t, got, attribute.String("http.route", ""))
+}
+
+func TestHTTPServerRequestMetricsFailsGracefully(t *testing.T) {
+	req := new(http.Request)
+	var got []attribute.KeyValue
+	assert.NotPanics(t, func() { got = hc.ServerRequestMetrics(req, 0, "") })
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.scheme", "http"),
+		attribute.String("http.flavor", ""),
+		attribute.String("net.host.name", ""),
+	}, got)
+}
+
 func TestMethod(t *testing.T) {
 	assert.Equal(t, attribute.String("http.method", "POST"), hc.method("POST"))
 	assert.Equal(t, attribute.String("http.method", "GET"), hc.method(""))
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/semconv/internal/v2"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MessagingDestinationKind is the kind of a message destination.
+type MessagingDestinationKind uint8
+
+const (
+	// MessagingDestinationKindUnknown is used when the kind of the message
+	// destination is not known.
+	MessagingDestinationKindUnknown MessagingDestinationKind = iota
+	// MessagingDestinationKindQueue is a message queue.
+	MessagingDestinationKindQueue
+	// MessagingDestinationKindTopic is a message topic.
+	MessagingDestinationKindTopic
+)
+
+// MessagingDestination describes where a message is sent to or received
+// from.
+type MessagingDestination struct {
+	// Name is the message destination name. An empty name means the
+	// destination is anonymous.
+	Name string
+	// Kind is the kind of the message destination.
+	Kind MessagingDestinationKind
+	// Temporary is true if the destination is temporary and will not exist
+	// once the messages it contains are processed.
+	Temporary bool
+}
+
+// MessagingConv are the messaging semantic convention attributes defined for
+// a version of the OpenTelemetry specification.
+type MessagingConv struct {
+	MessagingConversationIDKey          attribute.Key
+	MessagingDestinationKey             attribute.Key
+	MessagingDestinationKindQueue       attribute.KeyValue
+	MessagingDestinationKindTopic       attribute.KeyValue
+	MessagingMessageIDKey               attribute.Key
+	MessagingMessagePayloadSizeBytesKey attribute.Key
+	MessagingOperationProcess           attribute.KeyValue
+	MessagingOperationReceive           attribute.KeyValue
+	MessagingSystemKey                  attribute.Key
+	MessagingTempDestinationKey         attribute.Key
+}
+
+// Span name operations of messaging spans.
+const (
+	messagingSend    = "send"
+	messagingReceive = "receive"
+	messagingProcess = "process"
+)
+
+// Send returns the span name and attributes for a message sent by a producer
+// of the messaging system to dest. The following attributes are returned if
+// the related values are defined: "messaging.system",
+// "messaging.destination", "messaging.destination_kind",
+// "messaging.temp_destination".
+func (c *MessagingConv) Send(system string, dest MessagingDestination) (string, []attribute.KeyValue) {
+	return c.operation(system, dest, messagingSend, attribute.KeyValue{})
+}
+
+// Receive returns the span name and attributes for messages received by a
+// consumer of the messaging system from dest. The "messaging.operation"
+// attribute is always returned. The following attributes are returned if the
+// related values are defined: "messaging.system", "messaging.destination",
+// "messaging.destination_kind", "messaging.temp_destination".
+func (c *MessagingConv) Receive(system string, dest MessagingDestination) (string, []attribute.KeyValue) {
+	return c.operation(system, dest, messagingReceive, c.MessagingOperationReceive)
+}
+
+// Process returns the span name and attributes for messages processed by a
+// consumer of the messaging system that were received from dest. The
+// "messaging.operation" attribute is always returned. The following
+// attributes are returned if the related values are defined:
+// "messaging.system", "messaging.destination", "messaging.destination_kind",
+// "messaging.temp_destination".
+func (c *MessagingConv) Process(system string, dest MessagingDestination) (string, []attribute.KeyValue) {
+	return c.operation(system, dest, messagingProcess, c.MessagingOperationProcess)
+}
+
+func (c *MessagingConv) operation(system string, dest MessagingDestination, op string, opAttr attribute.KeyValue) (string, []attribute.KeyValue) {
+	n := nonZeroStr(system, dest.Name)
+	if opAttr.Valid() {
+		n++
+	}
+	if dest.Kind != MessagingDestinationKindUnknown {
+		n++
+	}
+	if dest.Temporary {
+		n++
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+
+	if system != "" {
+		attrs = append(attrs, c.MessagingSystemKey.String(system))
+	}
+	if dest.Name != "" {
+		attrs = append(attrs, c.MessagingDestinationKey.String(dest.Name))
+	}
+	switch dest.Kind {
+	case MessagingDestinationKindQueue:
+		attrs = append(attrs, c.MessagingDestinationKindQueue)
+	case MessagingDestinationKindTopic:
+		attrs = append(attrs, c.MessagingDestinationKindTopic)
+	}
+	if dest.Temporary {
+		attrs = append(attrs, c.MessagingTempDestinationKey.Bool(true))
+	}
+	if opAttr.Valid() {
+		attrs = append(attrs, opAttr)
+	}
+
+	return messagingSpanName(dest) + " " + op, attrs
+}
+
+// messagingSpanName returns the destination part of a messaging span name.
+// Temporary and anonymous destination names are replaced with generic
+// placeholders to ensure a low cardinality.
+func messagingSpanName(dest MessagingDestination) string {
+	switch {
+	case dest.Temporary:
+		return "(temporary)"
+	case dest.Name == "":
+		return "(anonymous)"
+	}
+	return dest.Name
+}
+
+// Message returns attributes for a single message. The following attributes
+// are returned if the related values are defined: "messaging.message_id",
+// "messaging.conversation_id", "messaging.message_payload_size_bytes". A
+// negative payloadSize means the payload size is unknown.
+func (c *MessagingConv) Message(id, conversationID string, payloadSize int) []attribute.KeyValue {
+	n := nonZeroStr(id, conversationID)
+	if payloadSize >= 0 {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, n)
+	if id != "" {
+		attrs = append(attrs, c.MessagingMessageIDKey.String(id))
+	}
+	if conversationID != "" {
+		attrs = append(attrs, c.MessagingConversationIDKey.String(conversationID))
+	}
+	if payloadSize >= 0 {
+		attrs = append(attrs, c.MessagingMessagePayloadSizeBytesKey.Int(payloadSize))
+	}
+	return attrs
+}
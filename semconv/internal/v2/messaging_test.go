@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var mc = &MessagingConv{
+	MessagingConversationIDKey:          attribute.Key("messaging.conversation_id"),
+	MessagingDestinationKey:             attribute.Key("messaging.destination"),
+	MessagingDestinationKindQueue:       attribute.String("messaging.destination_kind", "queue"),
+	MessagingDestinationKindTopic:       attribute.String("messaging.destination_kind", "topic"),
+	MessagingMessageIDKey:               attribute.Key("messaging.message_id"),
+	MessagingMessagePayloadSizeBytesKey: attribute.Key("messaging.message_payload_size_bytes"),
+	MessagingOperationProcess:           attribute.String("messaging.operation", "process"),
+	MessagingOperationReceive:           attribute.String("messaging.operation", "receive"),
+	MessagingSystemKey:                  attribute.Key("messaging.system"),
+	MessagingTempDestinationKey:         attribute.Key("messaging.temp_destination"),
+}
+
+func TestMessagingSend(t *testing.T) {
+	name, got := mc.Send("kafka", MessagingDestination{
+		Name: "orders",
+		Kind: MessagingDestinationKindTopic,
+	})
+	assert.Equal(t, "orders send", name)
+	assert.Equal(t, 3, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.system", "kafka"),
+		attribute.String("messaging.destination", "orders"),
+		attribute.String("messaging.destination_kind", "topic"),
+	}, got)
+}
+
+func TestMessagingReceive(t *testing.T) {
+	name, got := mc.Receive("rabbitmq", MessagingDestination{
+		Name:      "amq.gen-JzTY20BRgKO",
+		Kind:      MessagingDestinationKindQueue,
+		Temporary: true,
+	})
+	assert.Equal(t, "(temporary) receive", name)
+	assert.Equal(t, 5, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.destination", "amq.gen-JzTY20BRgKO"),
+		attribute.String("messaging.destination_kind", "queue"),
+		attribute.Bool("messaging.temp_destination", true),
+		attribute.String("messaging.operation", "receive"),
+	}, got)
+}
+
+func TestMessagingProcess(t *testing.T) {
+	name, got := mc.Process("", MessagingDestination{})
+	assert.Equal(t, "(anonymous) process", name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.operation", "process"),
+	}, got)
+}
+
+func TestMessagingMessage(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("messaging.message_id", "452a7c7c7c7048c2f887f61572b18fc2"),
+		attribute.String("messaging.conversation_id", "MyConversationId"),
+		attribute.Int("messaging.message_payload_size_bytes", 2738),
+	}, mc.Message("452a7c7c7c7048c2f887f61572b18fc2", "MyConversationId", 2738))
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int("messaging.message_payload_size_bytes", 0),
+	}, mc.Message("", "", 0))
+
+	assert.Nil(t, mc.Message("", "", -1))
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/semconv/internal/v2"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RPCConv are the RPC semantic convention attributes defined for a version of
+// the OpenTelemetry specification.
+type RPCConv struct {
+	NetConv *NetConv
+
+	RPCGRPCStatusCodeKey attribute.Key
+	RPCMethodKey         attribute.Key
+	RPCServiceKey        attribute.Key
+	RPCSystemGRPC        attribute.KeyValue
+}
+
+// GRPCClientRequest returns the span name and attributes for a gRPC call of
+// fullMethod made by a client to target. The following attributes are always
+// returned: "rpc.system". The following attributes are returned if the
+// related values are defined in fullMethod or target: "rpc.service",
+// "rpc.method", "net.peer.name", "net.peer.port".
+//
+// The fullMethod is expected to be of the form "/package.service/method" and
+// target to be the gRPC dial target (i.e. "dns:///example.com:443").
+func (c *RPCConv) GRPCClientRequest(fullMethod, target string) (string, []attribute.KeyValue) {
+	name, service, method := parseFullMethod(fullMethod)
+	peer, p := splitHostPort(grpcTargetAddr(target))
+	n := 1 + nonZeroStr(service, method, peer)
+	if peer != "" && p > 0 {
+		n++
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+	attrs = c.appendMethod(attrs, service, method)
+
+	if peer != "" {
+		attrs = append(attrs, c.NetConv.PeerName(peer))
+		if p > 0 {
+			attrs = append(attrs, c.NetConv.PeerPort(p))
+		}
+	}
+	return name, attrs
+}
+
+// GRPCServerRequest returns the span name and attributes for a gRPC call of
+// fullMethod received by a server from peer. The following attributes are
+// always returned: "rpc.system". The following attributes are returned if the
+// related values are defined in fullMethod or peer: "rpc.service",
+// "rpc.method", "net.sock.peer.addr", "net.sock.peer.port".
+//
+// The fullMethod is expected to be of the form "/package.service/method" and
+// peer to be the "IP:port" address of the remote end of the connection.
+func (c *RPCConv) GRPCServerRequest(fullMethod, peer string) (string, []attribute.KeyValue) {
+	name, service, method := parseFullMethod(fullMethod)
+	addr, p := splitHostPort(peer)
+	n := 1 + nonZeroStr(service, method, addr)
+	if addr != "" && p > 0 {
+		n++
+	}
+	attrs := make([]attribute.KeyValue, 0, n)
+	attrs = c.appendMethod(attrs, service, method)
+
+	if addr != "" {
+		attrs = append(attrs, c.NetConv.SockPeerAddr(addr))
+		if p > 0 {
+			attrs = append(attrs, c.NetConv.SockPeerPort(p))
+		}
+	}
+	return name, attrs
+}
+
+func (c *RPCConv) appendMethod(attrs []attribute.KeyValue, service, method string) []attribute.KeyValue {
+	attrs = append(attrs, c.RPCSystemGRPC)
+	if service != "" {
+		attrs = append(attrs, c.RPCServiceKey.String(service))
+	}
+	if method != "" {
+		attrs = append(attrs, c.RPCMethodKey.String(method))
+	}
+	return attrs
+}
+
+// GRPCStatusCode returns an attribute for the numeric gRPC status code.
+func (c *RPCConv) GRPCStatusCode(code uint32) attribute.KeyValue {
+	return c.RPCGRPCStatusCodeKey.Int64(int64(code))
+}
+
+// GRPCClientStatus returns a span status code and message for a gRPC status
+// code value received by a client. All codes other than OK are returned as
+// errors.
+func (c *RPCConv) GRPCClientStatus(code uint32) (codes.Code, string) {
+	if code > grpcMaxStatusCode {
+		return codes.Error, fmt.Sprintf("Invalid gRPC status code %d", code)
+	}
+	if code == grpcStatusOK {
+		return codes.Unset, ""
+	}
+	return codes.Error, ""
+}
+
+// GRPCServerStatus returns a span status code and message for a gRPC status
+// code value returned by a server. Only codes that indicate a server fault
+// (Unknown, DeadlineExceeded, Unimplemented, Internal, Unavailable, and
+// DataLoss) are returned as errors.
+func (c *RPCConv) GRPCServerStatus(code uint32) (codes.Code, string) {
+	if code > grpcMaxStatusCode {
+		return codes.Error, fmt.Sprintf("Invalid gRPC status code %d", code)
+	}
+	switch code {
+	case grpcStatusUnknown,
+		grpcStatusDeadlineExceeded,
+		grpcStatusUnimplemented,
+		grpcStatusInternal,
+		grpcStatusUnavailable,
+		grpcStatusDataLoss:
+		return codes.Error, ""
+	}
+	return codes.Unset, ""
+}
+
+// gRPC status codes as defined in google.golang.org/grpc/codes. They are
+// replicated here to not depend on the gRPC module.
+const (
+	grpcStatusOK               uint32 = 0
+	grpcStatusUnknown          uint32 = 2
+	grpcStatusDeadlineExceeded uint32 = 4
+	grpcStatusUnimplemented    uint32 = 12
+	grpcStatusInternal         uint32 = 13
+	grpcStatusUnavailable      uint32 = 14
+	grpcStatusDataLoss         uint32 = 15
+	grpcMaxStatusCode          uint32 = 16
+)
+
+// parseFullMethod returns the span name, service, and method of the gRPC
+// fullMethod ("/package.service/method"). The span name is fullMethod without
+// the leading slash. The service or method is empty if it cannot be parsed.
+func parseFullMethod(fullMethod string) (name, service, method string) {
+	name = strings.TrimLeft(fullMethod, "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		// Invalid format, does not follow "/package.service/method".
+		return name, "", ""
+	}
+	return name, name[:i], name[i+1:]
+}
+
+// grpcTargetAddr returns the network address contained in the gRPC dial
+// target. Targets using the "scheme://authority/endpoint" naming syntax are
+// resolved to their endpoint. An empty string is returned for Unix domain
+// socket targets as they are not network addresses.
+func grpcTargetAddr(target string) string {
+	if strings.HasPrefix(target, "unix:") || strings.HasPrefix(target, "unix-abstract:") {
+		return ""
+	}
+	if i := strings.Index(target, "://"); i >= 0 {
+		target = target[i+len("://"):]
+		if j := strings.LastIndex(target, "/"); j >= 0 {
+			target = target[j+1:]
+		}
+	}
+	return target
+}
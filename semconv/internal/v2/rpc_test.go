@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var rc = &RPCConv{
+	NetConv: nc,
+
+	RPCGRPCStatusCodeKey: attribute.Key("rpc.grpc.status_code"),
+	RPCMethodKey:         attribute.Key("rpc.method"),
+	RPCServiceKey:        attribute.Key("rpc.service"),
+	RPCSystemGRPC:        attribute.String("rpc.system", "grpc"),
+}
+
+func TestGRPCClientRequest(t *testing.T) {
+	name, got := rc.GRPCClientRequest("/helloworld.Greeter/SayHello", "dns:///example.com:443")
+	assert.Equal(t, "helloworld.Greeter/SayHello", name)
+	assert.Equal(t, 5, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "helloworld.Greeter"),
+		attribute.String("rpc.method", "SayHello"),
+		attribute.String("net.peer.name", "example.com"),
+		attribute.Int("net.peer.port", 443),
+	}, got)
+}
+
+func TestGRPCServerRequest(t *testing.T) {
+	name, got := rc.GRPCServerRequest("/helloworld.Greeter/SayHello", "127.0.0.1:52342")
+	assert.Equal(t, "helloworld.Greeter/SayHello", name)
+	assert.Equal(t, 5, cap(got), "slice capacity")
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "helloworld.Greeter"),
+		attribute.String("rpc.method", "SayHello"),
+		attribute.String("net.sock.peer.addr", "127.0.0.1"),
+		attribute.Int("net.sock.peer.port", 52342),
+	}, got)
+}
+
+func TestGRPCRequestInvalidMethod(t *testing.T) {
+	name, got := rc.GRPCClientRequest("invalid", "")
+	assert.Equal(t, "invalid", name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+	}, got)
+}
+
+func TestParseFullMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod, name, service, method string
+	}{
+		{"/pkg.Service/Method", "pkg.Service/Method", "pkg.Service", "Method"},
+		{"/Service/Method", "Service/Method", "Service", "Method"},
+		{"pkg.Service/Method", "pkg.Service/Method", "pkg.Service", "Method"},
+		{"/pkg.Service/", "pkg.Service/", "pkg.Service", ""},
+		{"/Method", "Method", "", ""},
+		{"", "", "", ""},
+	}
+	for _, test := range tests {
+		name, service, method := parseFullMethod(test.fullMethod)
+		assert.Equal(t, test.name, name, test.fullMethod)
+		assert.Equal(t, test.service, service, test.fullMethod)
+		assert.Equal(t, test.method, method, test.fullMethod)
+	}
+}
+
+func TestGRPCTargetAddr(t *testing.T) {
+	tests := map[string]string{
+		"example.com:443":               "example.com:443",
+		"dns:///example.com:443":        "example.com:443",
+		"dns://8.8.8.8/example.com:443": "example.com:443",
+		"passthrough:///127.0.0.1:4317": "127.0.0.1:4317",
+		"unix:///tmp/grpc.sock":         "",
+		"unix:relative.sock":            "",
+		"unix-abstract:name":            "",
+		"":                              "",
+	}
+	for target, want := range tests {
+		assert.Equal(t, want, grpcTargetAddr(target), target)
+	}
+}
+
+func TestGRPCStatusCode(t *testing.T) {
+	assert.Equal(t, attribute.Int64("rpc.grpc.status_code", 14), rc.GRPCStatusCode(14))
+}
+
+func TestGRPCStatus(t *testing.T) {
+	tests := []struct {
+		code   uint32
+		client codes.Code
+		server codes.Code
+	}{
+		{0, codes.Unset, codes.Unset},  // OK
+		{1, codes.Error, codes.Unset},  // Canceled
+		{2, codes.Error, codes.Error},  // Unknown
+		{3, codes.Error, codes.Unset},  // InvalidArgument
+		{4, codes.Error, codes.Error},  // DeadlineExceeded
+		{5, codes.Error, codes.Unset},  // NotFound
+		{6, codes.Error, codes.Unset},  // AlreadyExists
+		{7, codes.Error, codes.Unset},  // PermissionDenied
+		{8, codes.Error, codes.Unset},  // ResourceExhausted
+		{9, codes.Error, codes.Unset},  // FailedPrecondition
+		{10, codes.Error, codes.Unset}, // Aborted
+		{11, codes.Error, codes.Unset}, // OutOfRange
+		{12, codes.Error, codes.Error}, // Unimplemented
+		{13, codes.Error, codes.Error}, // Internal
+		{14, codes.Error, codes.Error}, // Unavailable
+		{15, codes.Error, codes.Error}, // DataLoss
+		{16, codes.Error, codes.Unset}, // Unauthenticated
+		{17, codes.Error, codes.Error}, // Invalid
+	}
+	for _, test := range tests {
+		c, msg := rc.GRPCClientStatus(test.code)
+		assert.Equal(t, test.client, c, "client status code %d", test.code)
+		assertGRPCStatusMsg(t, test.code, msg)
+
+		c, msg = rc.GRPCServerStatus(test.code)
+		assert.Equal(t, test.server, c, "server status code %d", test.code)
+		assertGRPCStatusMsg(t, test.code, msg)
+	}
+}
+
+func assertGRPCStatusMsg(t *testing.T, code uint32, msg string) {
+	t.Helper()
+	if code > 16 {
+		assert.Equal(t, "Invalid gRPC status code 17", msg)
+	} else {
+		assert.Empty(t, msg, "status code %d", code)
+	}
+}
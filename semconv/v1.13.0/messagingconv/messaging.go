@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package messagingconv provides OpenTelemetry semantic convetions for
+// messaging systems.
+package messagingconv // import "go.opentelemetry.io/otel/semconv/v1.13.0/messagingconv"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv/internal/v2"
+	semconv "go.opentelemetry.io/otel/semconv/v1.13.0"
+)
+
+var mc = &internal.MessagingConv{
+	MessagingConversationIDKey:          semconv.MessagingConversationIDKey,
+	MessagingDestinationKey:             semconv.MessagingDestinationKey,
+	MessagingDestinationKindQueue:       semconv.MessagingDestinationKindQueue,
+	MessagingDestinationKindTopic:       semconv.MessagingDestinationKindTopic,
+	MessagingMessageIDKey:               semconv.MessagingMessageIDKey,
+	MessagingMessagePayloadSizeBytesKey: semconv.MessagingMessagePayloadSizeBytesKey,
+	MessagingOperationProcess:           semconv.MessagingOperationProcess,
+	MessagingOperationReceive:           semconv.MessagingOperationReceive,
+	MessagingSystemKey:                  semconv.MessagingSystemKey,
+	MessagingTempDestinationKey:         semconv.MessagingTempDestinationKey,
+}
+
+// Destination describes where a message is sent to or received from. An
+// empty Name means the destination is anonymous.
+type Destination = internal.MessagingDestination
+
+// DestinationKind is the kind of a message destination.
+type DestinationKind = internal.MessagingDestinationKind
+
+const (
+	// Unknown is used when the kind of the message destination is not known.
+	Unknown = internal.MessagingDestinationKindUnknown
+	// Queue is a message queue.
+	Queue = internal.MessagingDestinationKindQueue
+	// Topic is a message topic.
+	Topic = internal.MessagingDestinationKindTopic
+)
+
+// Send returns the span name and attributes for a message sent by a producer
+// of the messaging system to dest. The following attributes are returned if
+// the related values are defined: "messaging.system",
+// "messaging.destination", "messaging.destination_kind",
+// "messaging.temp_destination".
+func Send(system string, dest Destination) (string, []attribute.KeyValue) {
+	return mc.Send(system, dest)
+}
+
+// Receive returns the span name and attributes for messages received by a
+// consumer of the messaging system from dest. The "messaging.operation"
+// attribute is always returned. The following attributes are returned if the
+// related values are defined: "messaging.system", "messaging.destination",
+// "messaging.destination_kind", "messaging.temp_destination".
+func Receive(system string, dest Destination) (string, []attribute.KeyValue) {
+	return mc.Receive(system, dest)
+}
+
+// Process returns the span name and attributes for messages processed by a
+// consumer of the messaging system that were received from dest. The
+// "messaging.operation" attribute is always returned. The following
+// attributes are returned if the related values are defined:
+// "messaging.system", "messaging.destination", "messaging.destination_kind",
+// "messaging.temp_destination".
+func Process(system string, dest Destination) (string, []attribute.KeyValue) {
+	return mc.Process(system, dest)
+}
+
+// Message returns attributes for a single message. The following attributes
+// are returned if the related values are defined: "messaging.message_id",
+// "messaging.conversation_id", "messaging.message_payload_size_bytes". A
+// negative payloadSize means the payload size is unknown.
+func Message(id, conversationID string, payloadSize int) []attribute.KeyValue {
+	return mc.Message(id, conversationID, payloadSize)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpcconv provides OpenTelemetry semantic convetions for remote
+// procedure calls made with gRPC.
+package rpcconv // import "go.opentelemetry.io/otel/semconv/v1.13.0/rpcconv"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv/internal/v2"
+	semconv "go.opentelemetry.io/otel/semconv/v1.13.0"
+)
+
+var (
+	nc = &internal.NetConv{
+		NetPeerNameKey:     semconv.NetPeerNameKey,
+		NetPeerPortKey:     semconv.NetPeerPortKey,
+		NetSockPeerAddrKey: semconv.NetSockPeerAddrKey,
+		NetSockPeerPortKey: semconv.NetSockPeerPortKey,
+	}
+
+	rc = &internal.RPCConv{
+		NetConv: nc,
+
+		RPCGRPCStatusCodeKey: semconv.RPCGRPCStatusCodeKey,
+		RPCMethodKey:         semconv.RPCMethodKey,
+		RPCServiceKey:        semconv.RPCServiceKey,
+		RPCSystemGRPC:        semconv.RPCSystemGRPC,
+	}
+)
+
+// GRPCClientRequest returns the span name and attributes for a gRPC call of
+// fullMethod made by a client to target. The following attributes are always
+// returned: "rpc.system". The following attributes are returned if the
+// related values are defined in fullMethod or target: "rpc.service",
+// "rpc.method", "net.peer.name", "net.peer.port".
+//
+// The fullMethod is expected to be of the form "/package.service/method" and
+// target to be the gRPC dial target (i.e. "dns:///example.com:443").
+func GRPCClientRequest(fullMethod, target string) (string, []attribute.KeyValue) {
+	return rc.GRPCClientRequest(fullMethod, target)
+}
+
+// GRPCServerRequest returns the span name and attributes for a gRPC call of
+// fullMethod received by a server from peer. The following attributes are
+// always returned: "rpc.system". The following attributes are returned if the
+// related values are defined in fullMethod or peer: "rpc.service",
+// "rpc.method", "net.sock.peer.addr", "net.sock.peer.port".
+//
+// The fullMethod is expected to be of the form "/package.service/method" and
+// peer to be the "IP:port" address of the remote end of the connection.
+func GRPCServerRequest(fullMethod, peer string) (string, []attribute.KeyValue) {
+	return rc.GRPCServerRequest(fullMethod, peer)
+}
+
+// GRPCStatusCode returns an attribute for the numeric gRPC status code.
+func GRPCStatusCode(code uint32) attribute.KeyValue {
+	return rc.GRPCStatusCode(code)
+}
+
+// GRPCClientStatus returns a span status code and message for a gRPC status
+// code value received by a client. All codes other than OK are returned as
+// errors.
+func GRPCClientStatus(code uint32) (codes.Code, string) {
+	return rc.GRPCClientStatus(code)
+}
+
+// GRPCServerStatus returns a span status code and message for a gRPC status
+// code value returned by a server. Only codes that indicate a server fault
+// (Unknown, DeadlineExceeded, Unimplemented, Internal, Unavailable, and
+// DataLoss) are returned as errors.
+func GRPCServerStatus(code uint32) (codes.Code, string) {
+	return rc.GRPCServerStatus(code)
+}
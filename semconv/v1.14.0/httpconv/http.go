@@ -87,6 +87,17 @@ func ClientStatus(code int) (codes.Code, string) {
 	return hc.ClientStatus(code)
 }
 
+// ClientRequestMetrics returns the low-cardinality attributes used for HTTP
+// client metrics of req that received a response with the status code. The
+// following attributes are always returned: "http.method", "http.flavor",
+// "net.peer.name". The following attributes are returned if the related
+// values are defined: "net.peer.port", "http.status_code".
+//
+// A code of 0 means no response was received, or it is not yet known.
+func ClientRequestMetrics(req *http.Request, code int) []attribute.KeyValue {
+	return hc.ClientRequestMetrics(req, code)
+}
+
 // ServerRequest returns attributes for an HTTP request received by a server.
 // The following attributes are always returned: "http.method", "http.scheme",
 // "http.flavor", "http.target", "net.host.name". The following attributes are
@@ -97,6 +108,21 @@ func ServerRequest(req *http.Request) []attribute.KeyValue {
 	return hc.ServerRequest(req)
 }
 
+// ServerRequestMetrics returns the low-cardinality attributes used for HTTP
+// server metrics of req that matched route and was responded to with the
+// status code. The following attributes are always returned: "http.method",
+// "http.scheme", "http.flavor", "net.host.name". The following attributes
+// are returned if the related values are defined: "net.host.port",
+// "http.route", "http.status_code".
+//
+// The route is the matched route template (e.g. "/users/{id}"), never the
+// request path. An empty route means it is not known, and a code of 0 means
+// no response has been sent, or it is not yet known (i.e. for the
+// "http.server.active_requests" metric).
+func ServerRequestMetrics(req *http.Request, code int, route string) []attribute.KeyValue {
+	return hc.ServerRequestMetrics(req, code, route)
+}
+
 // ServerStatus returns a span status code and message for an HTTP status code
 // value returned by a server. Status codes in the 400-499 range are not
 // returned as errors.
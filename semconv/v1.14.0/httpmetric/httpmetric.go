@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpmetric provides the OpenTelemetry semantic convention metric
+// instruments for HTTP clients and servers.
+//
+// The instruments are created with the names and units defined by the
+// specification and measurements are recorded with the low-cardinality
+// attributes returned from the ClientRequestMetrics and ServerRequestMetrics
+// functions of the go.opentelemetry.io/otel/semconv/v1.14.0/httpconv package.
+//
+// The duration instruments record values in milliseconds. The default
+// explicit bucket histogram boundaries of the OpenTelemetry SDK are designed
+// for this unit and should not need to be changed with a view.
+package httpmetric // import "go.opentelemetry.io/otel/semconv/v1.14.0/httpmetric"
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/semconv/v1.14.0/httpconv"
+)
+
+// HTTP metric instrument names.
+const (
+	ServerDuration       = "http.server.duration"
+	ServerActiveRequests = "http.server.active_requests"
+	ServerRequestSize    = "http.server.request.size"
+	ServerResponseSize   = "http.server.response.size"
+	ClientDuration       = "http.client.duration"
+	ClientRequestSize    = "http.client.request.size"
+	ClientResponseSize   = "http.client.response.size"
+)
+
+// Server records the metrics of an HTTP server.
+type Server struct {
+	duration       syncfloat64.Histogram
+	activeRequests syncint64.UpDownCounter
+	requestSize    syncint64.Histogram
+	responseSize   syncint64.Histogram
+}
+
+// NewServer returns a Server with its instruments created from meter. An
+// error is returned if any of the instruments cannot be created.
+func NewServer(meter metric.Meter) (*Server, error) {
+	var (
+		s   Server
+		err error
+	)
+	s.duration, err = meter.Float64Histogram(
+		ServerDuration,
+		instrument.WithUnit(unit.Milliseconds),
+		instrument.WithDescription("Measures the duration of inbound HTTP requests."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.activeRequests, err = meter.Int64UpDownCounter(
+		ServerActiveRequests,
+		instrument.WithUnit("{requests}"),
+		instrument.WithDescription("Measures the number of concurrent HTTP requests that are currently in-flight."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.requestSize, err = meter.Int64Histogram(
+		ServerRequestSize,
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Measures the size of HTTP request messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.responseSize, err = meter.Int64Histogram(
+		ServerResponseSize,
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Measures the size of HTTP response messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Start records the start of the handling of req. The returned function needs
+// to be called once the response has been written, with the status code and
+// size of the response body in bytes, to record the end of the request. A
+// negative responseSize means the size is unknown and it is not recorded.
+//
+// The route passed to the returned function is the route template req
+// matched (e.g. "/users/{id}"), it is often only known once the request has
+// been routed. It is recorded as the "http.route" attribute if it is not
+// empty. The request path must not be used as it is not low-cardinality.
+//
+// The request body size is recorded from the ContentLength of req if it is
+// known.
+func (s *Server) Start(ctx context.Context, req *http.Request) func(code int, responseSize int64, route string) {
+	start := time.Now()
+	active := httpconv.ServerRequestMetrics(req, 0, "")
+	s.activeRequests.Add(ctx, 1, active...)
+
+	return func(code int, responseSize int64, route string) {
+		elapsed := time.Since(start)
+		s.activeRequests.Add(ctx, -1, active...)
+
+		attrs := httpconv.ServerRequestMetrics(req, code, route)
+		s.duration.Record(ctx, milliseconds(elapsed), attrs...)
+		if req.ContentLength >= 0 {
+			s.requestSize.Record(ctx, req.ContentLength, attrs...)
+		}
+		if responseSize >= 0 {
+			s.responseSize.Record(ctx, responseSize, attrs...)
+		}
+	}
+}
+
+// Client records the metrics of an HTTP client.
+type Client struct {
+	duration     syncfloat64.Histogram
+	requestSize  syncint64.Histogram
+	responseSize syncint64.Histogram
+}
+
+// NewClient returns a Client with its instruments created from meter. An
+// error is returned if any of the instruments cannot be created.
+func NewClient(meter metric.Meter) (*Client, error) {
+	var (
+		c   Client
+		err error
+	)
+	c.duration, err = meter.Float64Histogram(
+		ClientDuration,
+		instrument.WithUnit(unit.Milliseconds),
+		instrument.WithDescription("Measures the duration of outbound HTTP requests."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.requestSize, err = meter.Int64Histogram(
+		ClientRequestSize,
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Measures the size of HTTP request messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.responseSize, err = meter.Int64Histogram(
+		ClientResponseSize,
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Measures the size of HTTP response messages (compressed)."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Start records the start of req being sent. The returned function needs to
+// be called once the response has been received, with the status code and
+// size of the response body in bytes, to record the end of the request. A
+// code of 0 means no response was received, and a negative responseSize
+// means the size is unknown and it is not recorded.
+//
+// The request body size is recorded from the ContentLength of req if it is
+// known.
+func (c *Client) Start(ctx context.Context, req *http.Request) func(code int, responseSize int64) {
+	start := time.Now()
+	return func(code int, responseSize int64) {
+		elapsed := time.Since(start)
+
+		attrs := httpconv.ClientRequestMetrics(req, code)
+		c.duration.Record(ctx, milliseconds(elapsed), attrs...)
+		if req.ContentLength >= 0 {
+			c.requestSize.Record(ctx, req.ContentLength, attrs...)
+		}
+		if responseSize >= 0 {
+			c.responseSize.Record(ctx, responseSize, attrs...)
+		}
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
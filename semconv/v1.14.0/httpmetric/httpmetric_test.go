@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpmetric
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/unit"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func setup(t *testing.T) (sdkmetric.Reader, *sdkmetric.MeterProvider) {
+	t.Helper()
+	r := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+	t.Cleanup(func() { assert.NoError(t, mp.Shutdown(context.Background())) })
+	return r, mp
+}
+
+func collect(t *testing.T, r sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+	rm, err := r.Collect(context.Background())
+	require.NoError(t, err)
+
+	got := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m
+		}
+	}
+	return got
+}
+
+func TestServer(t *testing.T) {
+	r, mp := setup(t)
+	s, err := NewServer(mp.Meter("test"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	req := httptest.NewRequest(http.MethodPost, "http://example.com:8080/users", strings.NewReader("hello"))
+	end := s.Start(ctx, req)
+
+	active := attribute.NewSet(
+		attribute.String("http.method", "POST"),
+		attribute.String("http.scheme", "http"),
+		attribute.String("http.flavor", "1.1"),
+		attribute.String("net.host.name", "example.com"),
+		attribute.Int("net.host.port", 8080),
+	)
+	wantActive := func(v int64) metricdata.Metrics {
+		return metricdata.Metrics{
+			Name:        ServerActiveRequests,
+			Description: "Measures the number of concurrent HTTP requests that are currently in-flight.",
+			Unit:        "{requests}",
+			Data: metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: active,
+					Value:      v,
+				}},
+			},
+		}
+	}
+	got := collect(t, r)
+	require.Len(t, got, 1)
+	metricdatatest.AssertEqual(t, wantActive(1), got[ServerActiveRequests], metricdatatest.IgnoreTimestamp())
+
+	end(http.StatusCreated, 11, "/users")
+
+	done := attribute.NewSet(append(
+		active.ToSlice(),
+		attribute.String("http.route", "/users"),
+		attribute.Int("http.status_code", http.StatusCreated),
+	)...)
+	got = collect(t, r)
+	require.Len(t, got, 4)
+	metricdatatest.AssertEqual(t, wantActive(0), got[ServerActiveRequests], metricdatatest.IgnoreTimestamp())
+	assertHistogram(t, got[ServerDuration], unit.Milliseconds, done, -1)
+	assertHistogram(t, got[ServerRequestSize], unit.Bytes, done, 5)
+	assertHistogram(t, got[ServerResponseSize], unit.Bytes, done, 11)
+}
+
+func TestClient(t *testing.T) {
+	r, mp := setup(t)
+	c, err := NewClient(mp.Meter("test"))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/users", nil)
+	require.NoError(t, err)
+	end := c.Start(context.Background(), req)
+	assert.Empty(t, collect(t, r))
+
+	// Unknown response size is not recorded.
+	end(http.StatusOK, -1)
+
+	attrs := attribute.NewSet(
+		attribute.String("http.method", "GET"),
+		attribute.String("http.flavor", "1.1"),
+		attribute.String("net.peer.name", "example.com"),
+		attribute.Int("http.status_code", http.StatusOK),
+	)
+	got := collect(t, r)
+	require.Len(t, got, 2)
+	assertHistogram(t, got[ClientDuration], unit.Milliseconds, attrs, -1)
+	assertHistogram(t, got[ClientRequestSize], unit.Bytes, attrs, 0)
+}
+
+// assertHistogram asserts m is a histogram with a single data point of attrs.
+// The sum of the data point is only checked if sum is not negative.
+func assertHistogram(t *testing.T, m metricdata.Metrics, u unit.Unit, attrs attribute.Set, sum float64) {
+	t.Helper()
+	assert.Equal(t, u, m.Unit, "unit")
+	h, ok := m.Data.(metricdata.Histogram)
+	require.True(t, ok, "not a histogram: %T", m.Data)
+	require.Len(t, h.DataPoints, 1)
+	dPt := h.DataPoints[0]
+	assert.True(t, attrs.Equals(&dPt.Attributes), "attributes: %v", dPt.Attributes.ToSlice())
+	assert.Equal(t, uint64(1), dPt.Count, "count")
+	if sum >= 0 {
+		assert.Equal(t, sum, dPt.Sum, "sum")
+	}
+}